@@ -5,8 +5,11 @@ package app
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"time"
 
+	"github.com/leg100/pug/internal/audit"
 	"github.com/leg100/pug/internal/logging"
 	"github.com/leg100/pug/internal/module"
 	"github.com/leg100/pug/internal/plan"
@@ -30,7 +33,16 @@ type App struct {
 // subscribing to events. The returned app is used for constructing the TUI and
 // relaying events. The app's cleanup function should be called when finished.
 func New(cfg Config) (*App, error) {
-	// Setup logging
+	// Setup logging, writing log messages to a file if enabled
+	var logFile *os.File
+	if cfg.LogFile != "" {
+		f, err := os.OpenFile(cfg.LogFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("opening log file: %w", err)
+		}
+		logFile = f
+		cfg.Logging.File = logFile
+	}
 	logger := logging.NewLogger(cfg.Logging)
 
 	// Log some info useful to the user
@@ -41,8 +53,20 @@ func New(cfg Config) (*App, error) {
 		"program", cfg.Program,
 		"work_dir", cfg.Workdir,
 		"data_dir", cfg.DataDir,
+		"isolate_data_dir", cfg.IsolateDataDir,
 	)
 
+	// Compile patterns matching the output of tasks that have failed for
+	// transient reasons.
+	retryPatterns := cfg.RetryPatterns
+	if len(retryPatterns) == 0 {
+		retryPatterns = task.DefaultRetryPatterns
+	}
+	compiledRetryPatterns, err := task.CompileRetryPatterns(retryPatterns)
+	if err != nil {
+		return nil, err
+	}
+
 	// Instantiate services
 	tasks := task.NewService(task.ServiceOptions{
 		Program:    cfg.Program,
@@ -51,13 +75,21 @@ func New(cfg Config) (*App, error) {
 		UserEnvs:   cfg.Envs,
 		UserArgs:   cfg.Args,
 		Terragrunt: cfg.Terragrunt,
+		Retry: task.RetryOptions{
+			MaxAttempts: cfg.RetryAttempts,
+			Backoff:     cfg.RetryBackoff,
+			Patterns:    compiledRetryPatterns,
+		},
 	})
 	modules := module.NewService(module.ServiceOptions{
-		Tasks:       tasks,
-		Workdir:     cfg.Workdir,
-		PluginCache: cfg.PluginCache,
-		Logger:      logger,
-		Terragrunt:  cfg.Terragrunt,
+		Tasks:            tasks,
+		Workdir:          cfg.Workdir,
+		PluginCache:      cfg.PluginCache,
+		Logger:           logger,
+		Terragrunt:       cfg.Terragrunt,
+		MaxDepth:         cfg.ModuleDepth,
+		IgnoreDirs:       cfg.IgnoreDirs,
+		DependenciesFile: cfg.DependenciesFile,
 	})
 	workspaces := workspace.NewService(workspace.ServiceOptions{
 		Tasks:   tasks,
@@ -73,18 +105,47 @@ func New(cfg Config) (*App, error) {
 		Logger:     logger,
 	})
 	plans := plan.NewService(plan.ServiceOptions{
-		Tasks:      tasks,
-		Modules:    modules,
-		Workspaces: workspaces,
-		States:     states,
-		DataDir:    cfg.DataDir,
-		Workdir:    cfg.Workdir,
-		Logger:     logger,
-		Terragrunt: cfg.Terragrunt,
+		Tasks:              tasks,
+		Modules:            modules,
+		Workspaces:         workspaces,
+		States:             states,
+		DataDir:            cfg.DataDir,
+		Workdir:            cfg.Workdir,
+		Logger:             logger,
+		Terragrunt:         cfg.Terragrunt,
+		ModuleDependencies: cfg.DependenciesFile != "",
+		IsolateDataDir:     cfg.IsolateDataDir,
+		Retention:          cfg.PlanRetention,
+		SavePlans:          cfg.SavePlans,
 	})
+	// Remove plan files left behind by previous instances
+	plans.PruneArtefacts(time.Now())
 
 	ctx, cancel := context.WithCancel(context.Background())
 
+	// Record tasks in the audit log if enabled
+	var auditor *audit.Logger
+	if cfg.AuditLog != "" {
+		auditor, err = audit.NewLogger(cfg.AuditLog, workspaces, logger)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("opening audit log: %w", err)
+		}
+		go auditor.Start(tasks.TaskBroker.Subscribe(ctx))
+	}
+
+	// Write events to the event stream if enabled
+	var stream *audit.Stream
+	if cfg.EventStream != "" {
+		stream, err = audit.NewStream(cfg.EventStream, logger)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("opening event stream: %w", err)
+		}
+		go audit.Watch(stream, plans.Subscribe(ctx))
+		go audit.Watch(stream, tasks.TaskBroker.Subscribe(ctx))
+	}
+
 	// Start daemons
 	task.StartEnqueuer(tasks)
 	waitTasks := task.StartRunner(ctx, logger, tasks, cfg.MaxTasks)
@@ -108,10 +169,20 @@ func New(cfg Config) (*App, error) {
 		// shut itself down.
 		waitTasks()
 
-		// Remove all run artefacts (plan files etc,...)
-		for _, plan := range plans.List() {
-			_ = os.RemoveAll(plan.ArtefactsPath)
+		if auditor != nil {
+			_ = auditor.Close()
+		}
+		if stream != nil {
+			_ = stream.Close()
 		}
+		if logFile != nil {
+			_ = logFile.Sync()
+			_ = logFile.Close()
+		}
+
+		// Remove all run artefacts (plan files etc,...), saving unapplied
+		// plans if enabled.
+		plans.RemoveArtefacts()
 	}
 
 	return &App{
@@ -7,10 +7,12 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/command/cliconfig"
 	"github.com/leg100/pug/internal"
 	"github.com/leg100/pug/internal/logging"
+	"github.com/leg100/pug/internal/plan"
 	"github.com/peterbourgon/ff/v4"
 	"github.com/peterbourgon/ff/v4/ffhelp"
 	"github.com/peterbourgon/ff/v4/ffyaml"
@@ -23,7 +25,37 @@ type Config struct {
 	FirstPage               string
 	Debug                   bool
 	DisableReloadAfterApply bool
+	IsolateDataDir          bool
+	Minimap                 bool
+	ExitCode                bool
+	ColumnOrder             []string
+	FollowNew               bool
+	PinFirstColumn          bool
+	HeaderRule              bool
+	Paginate                []string
+	DefaultFilters          []string
+	Spinner                 string
+	SpinnerInterval         time.Duration
+	AuditLog                string
+	EventStream             string
+	Headless                string
+	Select                  []string
+	LogFile                 string
+	PlanRetention           plan.Retention
+	SavePlans               bool
+	ReadOnly                bool
+	SkipApplyConfirm        bool
+	RestorePage             bool
+	Theme                   string
+	ThemeFile               string
+	RetryAttempts           int
+	RetryBackoff            time.Duration
+	RetryPatterns           []string
+	Mouse                   bool
 	Workdir                 internal.Workdir
+	ModuleDepth             int
+	IgnoreDirs              []string
+	DependenciesFile        string
 	DataDir                 string
 	Envs                    []string
 	Args                    []string
@@ -49,20 +81,53 @@ func Parse(stderr io.Writer, args []string) (Config, error) {
 	fs.StringVar(&cfg.Program, 'p', "program", "terraform", "The default program to use with pug.")
 	workdir := fs.String('w', "workdir", ".", "The working directory containing modules.")
 	fs.IntVar(&cfg.MaxTasks, 't', "max-tasks", 2*runtime.NumCPU(), "The maximum number of parallel tasks.")
+	fs.IntVar(&cfg.ModuleDepth, 0, "module-depth", 0, "Maximum depth of directories beneath the working directory in which to search for modules. Set to 0 for no maximum.")
+	fs.StringListVar(&cfg.IgnoreDirs, 0, "ignore-dir", "Name of directory in which not to search for modules, e.g. .git. Can set more than once.")
+	fs.StringVar(&cfg.DependenciesFile, 0, "dependencies-file", "", "Path to YAML file mapping module paths to the paths of modules on which they depend.")
 	fs.StringVar(&cfg.DataDir, 0, "data-dir", defaultDataDir, "Directory in which to store plan files.")
+	fs.DurationVar(&cfg.PlanRetention.MaxAge, 0, "plan-max-age", 0, "Remove plan files of finished plans older than this age. Defaults to no maximum age.")
+	fs.IntVar(&cfg.PlanRetention.MaxPerWorkspace, 0, "plan-max-count", 0, "Maximum number of finished plans per workspace whose plan files are kept. Set to 0 for no maximum.")
+	fs.BoolVar(&cfg.SavePlans, 0, "save-plans", "Keep the latest unapplied plan of each workspace upon exiting, to apply after restarting.")
 	fs.StringListVar(&cfg.Envs, 'e', "env", "Environment variable to pass to terraform process. Can set more than once.")
 	fs.StringListVar(&cfg.Args, 'a', "arg", "CLI arg to pass to terraform process. Can set more than once.")
+	fs.StringListVar(&cfg.ColumnOrder, 0, "column-order", "Key of table column to show first. Can set more than once.")
 	fs.StringEnumVar(&cfg.FirstPage, 'f', "first-page", "The first page to open on startup.", "modules", "workspaces", "runs", "tasks", "logs")
+	fs.BoolVar(&cfg.RestorePage, 0, "restore-page", "Open the page last visited in the working directory on startup, instead of the first page.")
 	fs.BoolVar(&cfg.Debug, 'd', "debug", "Log bubbletea messages to messages.log")
 	fs.BoolVar(&cfg.Version, 'v', "version", "Print version.")
 	_ = fs.String('c', "config", defaultConfigFile, "Path to config file.")
 
 	fs.BoolVar(&cfg.DisableReloadAfterApply, 0, "disable-reload-after-apply", "Disable automatic reload of state following an apply.")
+	fs.BoolVar(&cfg.IsolateDataDir, 0, "isolate-data-dir", "Run plans and applies with a separate TF_DATA_DIR for each workspace.")
+	fs.BoolVar(&cfg.Minimap, 0, "minimap", "Show a mini-map of errors and warnings alongside task output.")
+	fs.BoolVar(&cfg.ExitCode, 0, "exit-code", "Exit with status 2 if any task errored or was canceled.")
+	fs.BoolVar(&cfg.FollowNew, 0, "follow-new", "Move the cursor to newly created workspaces and tasks.")
+	fs.BoolVar(&cfg.PinFirstColumn, 0, "pin-first-column", "Keep the first table column in view when scrolling horizontally.")
+	fs.BoolVar(&cfg.HeaderRule, 0, "header-rule", "Draw a rule between table headers and rows.")
+	fs.StringListVar(&cfg.Paginate, 0, "paginate", "List to divide into pages rather than scroll: modules, workspaces, tasks, task-groups, resources, or logs. Can set more than once.")
+	fs.StringListVar(&cfg.DefaultFilters, 0, "default-filter", "Filter to apply to a list when it is opened, in the form LIST=EXPRESSION, e.g. tasks=status:running. Can set more than once.")
+	fs.StringEnumVar(&cfg.Spinner, 0, "spinner", "Style of spinner shown whilst tasks are running.", "line", "dot", "minidot", "jump", "pulse", "points", "globe", "moon", "monkey", "meter", "hamburger", "ellipsis")
+	fs.DurationVar(&cfg.SpinnerInterval, 0, "spinner-interval", 0, "Interval between spinner frames, which is also how often the durations of running tasks are refreshed. Defaults to the interval of the spinner style.")
+	fs.StringVar(&cfg.AuditLog, 0, "audit-log", "", "Path to file to which an audit log of tasks is written.")
+	fs.StringVar(&cfg.EventStream, 0, "event-stream", "", "Path to file or fifo to which a JSON stream of events is written.")
+	fs.StringVar(&cfg.Headless, 0, "headless", "", "Run an action without the TUI and exit: init, validate, fmt, plan, apply, or destroy.")
+	fs.StringListVar(&cfg.Select, 0, "select", "Select modules and workspaces on which to run a headless action, in the form MODULE[:WORKSPACE]. Can set more than once.")
+	fs.BoolVar(&cfg.ReadOnly, 0, "read-only", "Disable actions that change infrastructure, state, or files.")
+	fs.BoolVar(&cfg.SkipApplyConfirm, 0, "skip-apply-confirm", "Apply without prompting for confirmation. Destroys are always confirmed.")
+	fs.IntVar(&cfg.RetryAttempts, 0, "retry-attempts", 1, "Maximum number of attempts at a task that fails for a transient reason.")
+	fs.DurationVar(&cfg.RetryBackoff, 0, "retry-backoff", 5*time.Second, "Delay before retrying a failed task, doubling with each retry.")
+	fs.StringListVar(&cfg.RetryPatterns, 0, "retry-pattern", "Regular expression matching output of a task that has failed for a transient reason. Can set more than once.")
+	fs.BoolVar(&cfg.Mouse, 0, "mouse", "Enable mouse support. Selecting text with the mouse then requires holding shift.")
+	fs.StringVar(&cfg.Theme, 0, "theme", "", "Built-in color theme: dark or light. Defaults to colors adapted to the terminal background.")
+	fs.StringVar(&cfg.ThemeFile, 0, "theme-file", "", "Path to YAML file of colors, overriding those of the theme.")
 
 	{
 		usage := fmt.Sprintf("Logging level (valid: %s).", strings.Join(logging.ValidLevels(), ","))
 		fs.StringEnumVar(&cfg.Logging.Level, 'l', "log-level", usage, logging.ValidLevels()...)
 	}
+	fs.IntVar(&cfg.Logging.MaxMessages, 0, "max-log-messages", 10000, "Maximum number of log messages kept in memory. Set to 0 for no maximum.")
+	fs.StringVar(&cfg.LogFile, 0, "log-file", "", "Path to file to which log messages are written.")
+	fs.StringEnumVar(&cfg.Logging.FileFormat, 0, "log-format", "Encoding of log messages written to --log-file (valid: logfmt,json).", logging.LogfmtFormat, logging.JSONFormat)
 
 	// Plugin cache is enabled not via pug flags but via terraform config
 	tfcfg, _ := cliconfig.LoadConfig()
@@ -8,6 +8,7 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/leg100/pug/internal"
 	"github.com/leg100/pug/internal/logging"
@@ -44,13 +45,18 @@ func TestConfig(t *testing.T) {
 				require.NoError(t, err)
 
 				want := Config{
-					Program:   "terraform",
-					MaxTasks:  2 * runtime.NumCPU(),
-					FirstPage: "modules",
-					Workdir:   wd,
-					DataDir:   filepath.Join(os.Getenv("HOME"), ".pug"),
+					Program:       "terraform",
+					MaxTasks:      2 * runtime.NumCPU(),
+					FirstPage:     "modules",
+					Workdir:       wd,
+					DataDir:       filepath.Join(os.Getenv("HOME"), ".pug"),
+					Spinner:       "line",
+					RetryAttempts: 1,
+					RetryBackoff:  5 * time.Second,
 					Logging: logging.Options{
-						Level: "info",
+						Level:       "info",
+						MaxMessages: 10000,
+						FileFormat:  "logfmt",
 					},
 				}
 				assert.Equal(t, want, got)
@@ -0,0 +1,20 @@
+package app
+
+import "fmt"
+
+// ExitCodeTasksFailed is the exit code when at least one task errored or was
+// canceled, and either the exit code option is enabled or pug is running
+// headless.
+const ExitCodeTasksFailed = 2
+
+// TasksFailedError is returned upon exit when at least one task errored or was
+// canceled, and either the exit code option is enabled or pug is running
+// headless.
+type TasksFailedError struct {
+	// Failed is the number of tasks that errored or were canceled.
+	Failed int
+}
+
+func (e TasksFailedError) Error() string {
+	return fmt.Sprintf("%d task(s) errored or were canceled", e.Failed)
+}
@@ -0,0 +1,137 @@
+// Package audit records tasks to a JSON-lines audit log, for compliance
+// purposes, and writes a JSON stream of events, for external tooling. Both are
+// distinct from the application log.
+package audit
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/leg100/pug/internal/logging"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/task"
+	"github.com/leg100/pug/internal/workspace"
+)
+
+// Event is the stage in the lifecycle of an action that is recorded.
+type Event string
+
+const (
+	Created   Event = "created"
+	Exited    Event = "exited"
+	Errored   Event = "errored"
+	Canceled  Event = "canceled"
+	Discarded Event = "discarded"
+)
+
+// Record is a line in the audit log.
+type Record struct {
+	Time time.Time `json:"time"`
+	// Action is the action carried out, e.g. apply.
+	Action string `json:"action"`
+	Event  Event  `json:"event"`
+	// Task is the ID of the task carrying out the action.
+	Task      string   `json:"task"`
+	Module    string   `json:"module,omitempty"`
+	Workspace string   `json:"workspace,omitempty"`
+	Args      []string `json:"args,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+type workspaceGetter interface {
+	Get(workspaceID resource.ID) (*workspace.Workspace, error)
+}
+
+// Logger writes a record to the audit log whenever a task is created and
+// whenever it finishes.
+type Logger struct {
+	workspaces workspaceGetter
+	sink       *sink
+
+	mu sync.Mutex
+	// unfinished tracks tasks that have yet to finish, to ensure only one
+	// finished record is written per task. A task is removed once it has
+	// finished.
+	unfinished map[resource.ID]struct{}
+}
+
+// NewLogger constructs an audit logger, appending records to the file at the
+// given path, creating the file if it doesn't exist.
+func NewLogger(path string, workspaces workspaceGetter, logger logging.Interface) (*Logger, error) {
+	sink, err := openSink(path, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &Logger{
+		workspaces: workspaces,
+		sink:       sink,
+		unfinished: make(map[resource.ID]struct{}),
+	}, nil
+}
+
+func newLogger(w io.Writer, workspaces workspaceGetter, logger logging.Interface) *Logger {
+	return &Logger{
+		workspaces: workspaces,
+		sink:       newSink(w, logger),
+		unfinished: make(map[resource.ID]struct{}),
+	}
+}
+
+// Start records task events until the subscription is closed.
+func (l *Logger) Start(sub <-chan resource.Event[*task.Task]) {
+	for event := range sub {
+		l.handle(event)
+	}
+}
+
+func (l *Logger) handle(event resource.Event[*task.Task]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	t := event.Payload
+	// Take the state from the event rather than from the task, which may
+	// have since changed.
+	state := task.Status(event.Status)
+	var ev Event
+	switch {
+	case event.Type == resource.CreatedEvent:
+		l.unfinished[t.ID] = struct{}{}
+		ev = Created
+	case event.Type == resource.UpdatedEvent && state.IsFinal():
+		if _, ok := l.unfinished[t.ID]; !ok {
+			return
+		}
+		delete(l.unfinished, t.ID)
+		ev = Event(state)
+	case event.Type == resource.DeletedEvent:
+		delete(l.unfinished, t.ID)
+		return
+	default:
+		return
+	}
+	record := Record{
+		Time:   time.Now(),
+		Action: t.String(),
+		Event:  ev,
+		Task:   t.ID.String(),
+		Module: t.Path,
+		Args:   t.RedactedArgs(),
+	}
+	if t.WorkspaceID != nil {
+		if ws, err := l.workspaces.Get(*t.WorkspaceID); err == nil {
+			record.Workspace = ws.Name
+		}
+	}
+	if t.Err != nil {
+		record.Error = t.Err.Error()
+	}
+	// Failing to write the record is reported to the application log rather
+	// than interrupting the user.
+	l.sink.write(record)
+}
+
+// Close writes any buffered records and closes the audit log file.
+func (l *Logger) Close() error {
+	return l.sink.close()
+}
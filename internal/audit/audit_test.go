@@ -0,0 +1,81 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/leg100/pug/internal/logging"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/task"
+	"github.com/leg100/pug/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeWorkspaceGetter struct {
+	ws *workspace.Workspace
+}
+
+func (f *fakeWorkspaceGetter) Get(resource.ID) (*workspace.Workspace, error) {
+	if f.ws == nil {
+		return nil, errors.New("not found")
+	}
+	return f.ws, nil
+}
+
+func TestLogger_Apply(t *testing.T) {
+	ws := &workspace.Workspace{ID: resource.NewID(resource.Workspace), Name: "dev"}
+	taskID := resource.NewID(resource.Task)
+	// apply returns the apply task in the given state.
+	apply := func(state task.Status) *task.Task {
+		return &task.Task{
+			ID:          taskID,
+			WorkspaceID: &ws.ID,
+			Description: "apply",
+			Path:        "/infra/vpc",
+			Args:        []string{"apply", "-auto-approve", "plan.out"},
+			State:       state,
+		}
+	}
+
+	var buf bytes.Buffer
+	logger := newLogger(&buf, &fakeWorkspaceGetter{ws: ws}, logging.Discard)
+
+	event := func(typ resource.EventType, state task.Status) resource.Event[*task.Task] {
+		return resource.Event[*task.Task]{Type: typ, Payload: apply(state), Status: string(state)}
+	}
+	sub := make(chan resource.Event[*task.Task], 4)
+	sub <- event(resource.CreatedEvent, task.Pending)
+	sub <- event(resource.UpdatedEvent, task.Running)
+	sub <- event(resource.UpdatedEvent, task.Exited)
+	// A duplicate final event should not produce another record.
+	sub <- event(resource.UpdatedEvent, task.Exited)
+	close(sub)
+	logger.Start(sub)
+	require.NoError(t, logger.Close())
+
+	// The finished task should no longer be tracked.
+	assert.Empty(t, logger.unfinished)
+
+	var got []Record
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var record Record
+		require.NoError(t, dec.Decode(&record))
+		got = append(got, record)
+	}
+	require.Len(t, got, 2)
+
+	assert.Equal(t, Created, got[0].Event)
+	assert.Equal(t, Exited, got[1].Event)
+	for _, record := range got {
+		assert.Equal(t, "apply", record.Action)
+		assert.Equal(t, taskID.String(), record.Task)
+		assert.Equal(t, "/infra/vpc", record.Module)
+		assert.Equal(t, "dev", record.Workspace)
+		assert.Equal(t, []string{"apply", "-auto-approve", "plan.out"}, record.Args)
+		assert.False(t, record.Time.IsZero())
+	}
+}
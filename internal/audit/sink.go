@@ -0,0 +1,113 @@
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/leg100/pug/internal/logging"
+)
+
+const (
+	// sinkBufferSize is the number of records buffered for writing, beyond
+	// which records are dropped rather than holding up whoever is recording
+	// them.
+	sinkBufferSize = 1024
+	// sinkCloseTimeout is how long closing a sink waits for buffered records
+	// to be written before giving up on them.
+	sinkCloseTimeout = time.Second
+)
+
+// sink writes records as newline-delimited JSON from its own goroutine, so
+// that a slow or absent reader never holds up whoever is recording them.
+type sink struct {
+	logger  logging.Interface
+	records chan any
+	// dropped is the number of records dropped since it was last reported.
+	dropped atomic.Int64
+	// done is closed once all records have been written.
+	done   chan struct{}
+	closer io.Closer
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// openSink opens the file at the given path for appending records, creating
+// the file if it doesn't exist. The file is opened without blocking, so if it
+// is a fifo then it must already have a reader.
+func openSink(path string, logger logging.Interface) (*sink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY|syscall.O_NONBLOCK, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	s := newSink(f, logger)
+	s.closer = f
+	return s, nil
+}
+
+func newSink(w io.Writer, logger logging.Interface) *sink {
+	s := &sink{
+		logger:  logger,
+		records: make(chan any, sinkBufferSize),
+		done:    make(chan struct{}),
+	}
+	go s.run(w)
+	return s
+}
+
+func (s *sink) run(w io.Writer) {
+	defer close(s.done)
+
+	enc := json.NewEncoder(w)
+	for record := range s.records {
+		if err := enc.Encode(record); err != nil {
+			s.logger.Error("writing audit record", "error", err)
+		}
+		if n := s.dropped.Swap(0); n > 0 {
+			s.logger.Warn("dropped audit records because the reader is falling behind", "dropped", n)
+		}
+	}
+}
+
+// write queues a record for writing, dropping it if the buffer is full.
+func (s *sink) write(record any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+	select {
+	case s.records <- record:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// close writes buffered records, waiting no longer than sinkCloseTimeout, and
+// then closes the file.
+func (s *sink) close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.records)
+	s.mu.Unlock()
+
+	select {
+	case <-s.done:
+	case <-time.After(sinkCloseTimeout):
+		s.logger.Warn("timed out writing audit records")
+	}
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
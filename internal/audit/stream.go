@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/leg100/pug/internal/logging"
+	"github.com/leg100/pug/internal/resource"
+)
+
+// StreamRecord is a line in the event stream.
+type StreamRecord struct {
+	Time time.Time `json:"time"`
+	// ID is the ID of the resource, e.g. #3.
+	ID string `json:"id"`
+	// Type is the kind of resource, e.g. task.
+	Type  string             `json:"type"`
+	Event resource.EventType `json:"event"`
+	// OldStatus is the status of the resource before the event. Empty if the
+	// resource has just been created or has no status.
+	OldStatus string `json:"old_status,omitempty"`
+	// NewStatus is the status of the resource after the event. Empty if the
+	// resource has no status.
+	NewStatus string `json:"new_status,omitempty"`
+}
+
+// Stream writes a record to a newline-delimited JSON stream whenever a
+// resource is created or deleted, and whenever its status changes, for
+// consumption by external tooling.
+type Stream struct {
+	sink *sink
+
+	mu sync.Mutex
+	// statuses tracks the most recent status of each resource, to determine
+	// whether an update changes its status.
+	statuses map[resource.ID]string
+}
+
+// NewStream constructs an event stream, appending records to the file at the
+// given path, creating the file if it doesn't exist. The path may be a fifo,
+// in which case it must already have a reader.
+func NewStream(path string, logger logging.Interface) (*Stream, error) {
+	sink, err := openSink(path, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &Stream{sink: sink, statuses: make(map[resource.ID]string)}, nil
+}
+
+func newStream(w io.Writer, logger logging.Interface) *Stream {
+	return &Stream{
+		sink:     newSink(w, logger),
+		statuses: make(map[resource.ID]string),
+	}
+}
+
+// Watch records events for resources of a given type until the subscription
+// is closed. The status of a resource is taken from each event; a resource
+// without a status only has its creation and deletion recorded.
+func Watch[T resource.Resource](s *Stream, sub <-chan resource.Event[T]) {
+	for event := range sub {
+		s.handle(event.Type, event.Payload, event.Status)
+	}
+}
+
+func (s *Stream) handle(typ resource.EventType, res resource.Resource, newStatus string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := res.GetID()
+	oldStatus, ok := s.statuses[id]
+	switch typ {
+	case resource.CreatedEvent:
+		oldStatus = ""
+		s.statuses[id] = newStatus
+	case resource.UpdatedEvent:
+		if !ok || oldStatus == newStatus {
+			// Only record updates that change the status.
+			return
+		}
+		s.statuses[id] = newStatus
+	case resource.DeletedEvent:
+		delete(s.statuses, id)
+	}
+	record := StreamRecord{
+		Time:      time.Now(),
+		ID:        id.String(),
+		Type:      res.GetKind().String(),
+		Event:     typ,
+		OldStatus: oldStatus,
+		NewStatus: newStatus,
+	}
+	s.sink.write(record)
+}
+
+// Close writes any buffered records and closes the event stream file.
+func (s *Stream) Close() error {
+	return s.sink.close()
+}
@@ -0,0 +1,114 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/leg100/pug/internal/logging"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStream_Task(t *testing.T) {
+	taskID := resource.NewID(resource.Task)
+	// The task is shared between events and has long since finished by the
+	// time they are received, so the status must be taken from each event.
+	shared := &task.Task{ID: taskID, State: task.Exited}
+	event := func(typ resource.EventType, state task.Status) resource.Event[*task.Task] {
+		return resource.Event[*task.Task]{Type: typ, Payload: shared, Status: string(state)}
+	}
+
+	var buf bytes.Buffer
+	stream := newStream(&buf, logging.Discard)
+
+	sub := make(chan resource.Event[*task.Task], 5)
+	sub <- event(resource.CreatedEvent, task.Pending)
+	sub <- event(resource.UpdatedEvent, task.Running)
+	// An update that doesn't change the status should not produce a record.
+	sub <- event(resource.UpdatedEvent, task.Running)
+	sub <- event(resource.UpdatedEvent, task.Exited)
+	sub <- event(resource.DeletedEvent, task.Exited)
+	close(sub)
+	Watch(stream, sub)
+	require.NoError(t, stream.Close())
+
+	var got []StreamRecord
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var record StreamRecord
+		require.NoError(t, dec.Decode(&record))
+		got = append(got, record)
+	}
+	require.Len(t, got, 4)
+
+	want := []struct {
+		event     resource.EventType
+		oldStatus string
+		newStatus string
+	}{
+		{resource.CreatedEvent, "", "pending"},
+		{resource.UpdatedEvent, "pending", "running"},
+		{resource.UpdatedEvent, "running", "exited"},
+		{resource.DeletedEvent, "exited", "exited"},
+	}
+	for i, record := range got {
+		assert.Equal(t, taskID.String(), record.ID)
+		assert.Equal(t, "task", record.Type)
+		assert.Equal(t, want[i].event, record.Event)
+		assert.Equal(t, want[i].oldStatus, record.OldStatus)
+		assert.Equal(t, want[i].newStatus, record.NewStatus)
+		assert.False(t, record.Time.IsZero())
+	}
+}
+
+func TestStream_NoStatus(t *testing.T) {
+	id := resource.NewID(resource.Plan)
+
+	var buf bytes.Buffer
+	stream := newStream(&buf, logging.Discard)
+
+	sub := make(chan resource.Event[resource.ID], 2)
+	sub <- resource.Event[resource.ID]{Type: resource.CreatedEvent, Payload: id}
+	// Updates to a resource without a status should not produce a record.
+	sub <- resource.Event[resource.ID]{Type: resource.UpdatedEvent, Payload: id}
+	close(sub)
+	Watch(stream, sub)
+	require.NoError(t, stream.Close())
+
+	var record StreamRecord
+	dec := json.NewDecoder(&buf)
+	require.NoError(t, dec.Decode(&record))
+	assert.False(t, dec.More())
+
+	assert.Equal(t, id.String(), record.ID)
+	assert.Equal(t, "run", record.Type)
+	assert.Equal(t, resource.CreatedEvent, record.Event)
+	assert.Empty(t, record.OldStatus)
+	assert.Empty(t, record.NewStatus)
+}
+
+func TestStream_SlowReader(t *testing.T) {
+	r, w := io.Pipe()
+	defer r.Close()
+	stream := newStream(w, logging.Discard)
+
+	// Nothing reads the stream, yet recording more events than can be
+	// buffered must not block.
+	done := make(chan struct{})
+	go func() {
+		for range sinkBufferSize * 2 {
+			stream.handle(resource.CreatedEvent, resource.NewID(resource.Task), "")
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("recording events blocked on a slow reader")
+	}
+}
@@ -0,0 +1,228 @@
+// Package headless runs tasks on modules and workspaces without the TUI, for
+// use in scripts and pipelines.
+package headless
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/leg100/pug/internal/app"
+	"github.com/leg100/pug/internal/module"
+	"github.com/leg100/pug/internal/plan"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/task"
+	"github.com/leg100/pug/internal/workspace"
+)
+
+// Actions are the actions that can be run headless. The first three are run
+// on modules, and the remainder on workspaces.
+var Actions = []string{"init", "validate", "fmt", "plan", "apply", "destroy"}
+
+// Start runs the action on the modules or workspaces matching the selectors in
+// the config, writing the output of tasks to w, and blocks until the tasks
+// have finished. If any task errors or is canceled then an
+// app.TasksFailedError is returned.
+func Start(cfg app.Config, w io.Writer) error {
+	if !slices.Contains(Actions, cfg.Headless) {
+		return fmt.Errorf("invalid headless action: %q: must be one of: %s", cfg.Headless, strings.Join(Actions, ", "))
+	}
+	selectors := make([]Selector, len(cfg.Select))
+	for i, s := range cfg.Select {
+		sel, err := ParseSelector(s)
+		if err != nil {
+			return err
+		}
+		selectors[i] = sel
+	}
+	if len(selectors) == 0 {
+		// Select all modules and their current workspaces.
+		selectors = []Selector{{}}
+	}
+
+	app, err := app.New(cfg)
+	if err != nil {
+		return err
+	}
+	defer app.Cleanup()
+
+	return run(app, cfg.Headless, selectors, w)
+}
+
+func run(a *app.App, action string, selectors []Selector, w io.Writer) error {
+	if _, _, err := a.Modules.Reload(); err != nil {
+		return fmt.Errorf("loading modules: %w", err)
+	}
+	var modules []*module.Module
+	for _, mod := range a.Modules.List() {
+		if slices.ContainsFunc(selectors, func(s Selector) bool { return s.matchModule(mod) }) {
+			modules = append(modules, mod)
+		}
+	}
+	if len(modules) == 0 {
+		return errors.New("no modules match the selectors")
+	}
+
+	var (
+		specs  []task.Spec
+		failed int
+	)
+	if fn, ok := workspaceAction(a, action); ok {
+		if err := loadWorkspaces(a, modules); err != nil {
+			return err
+		}
+		var workspaces []*workspace.Workspace
+		for _, mod := range modules {
+			for _, ws := range a.Workspaces.List(workspace.ListOptions{ModuleID: &mod.ID}) {
+				if slices.ContainsFunc(selectors, func(s Selector) bool { return s.matchWorkspace(mod, ws) }) {
+					workspaces = append(workspaces, ws)
+				}
+			}
+		}
+		if len(workspaces) == 0 {
+			return errors.New("no workspaces match the selectors")
+		}
+		for _, ws := range workspaces {
+			spec, err := fn(ws.ID)
+			if err != nil {
+				fmt.Fprintf(w, "%s:%s: %s\n", ws.ModulePath, ws.Name, err)
+				failed++
+				continue
+			}
+			specs = append(specs, spec)
+		}
+	} else {
+		fn := moduleAction(a, action)
+		for _, mod := range modules {
+			spec, err := fn(mod.ID)
+			if err != nil {
+				fmt.Fprintf(w, "%s: %s\n", mod.Path, err)
+				failed++
+				continue
+			}
+			specs = append(specs, spec)
+		}
+	}
+	if len(specs) > 0 {
+		group, err := a.Tasks.CreateGroup(specs...)
+		if err != nil {
+			return fmt.Errorf("creating tasks: %w", err)
+		}
+		for _, err := range group.CreateErrors {
+			fmt.Fprintln(w, err)
+			failed++
+		}
+		failed += stream(a, group.Tasks, w)
+	}
+	if failed > 0 {
+		return app.TasksFailedError{Failed: failed}
+	}
+	return nil
+}
+
+// moduleAction returns a func that creates a task spec for an action run on
+// a module.
+// workspaceAction returns the func creating a task spec for an action run on
+// workspaces, or false if the action is run on modules. An apply or destroy
+// is applied without confirmation.
+func workspaceAction(a *app.App, action string) (task.SpecFunc, bool) {
+	switch action {
+	case "plan":
+		return func(workspaceID resource.ID) (task.Spec, error) {
+			return a.Plans.Plan(workspaceID, plan.CreateOptions{})
+		}, true
+	case "apply":
+		return func(workspaceID resource.ID) (task.Spec, error) {
+			return a.Plans.Apply(workspaceID, plan.CreateOptions{})
+		}, true
+	case "destroy":
+		return func(workspaceID resource.ID) (task.Spec, error) {
+			return a.Plans.Apply(workspaceID, plan.CreateOptions{Destroy: true})
+		}, true
+	default:
+		return nil, false
+	}
+}
+
+func moduleAction(a *app.App, action string) task.SpecFunc {
+	switch action {
+	case "init":
+		return func(moduleID resource.ID) (task.Spec, error) {
+			return a.Modules.Init(moduleID, false)
+		}
+	case "validate":
+		return a.Modules.Validate
+	default:
+		return a.Modules.Format
+	}
+}
+
+// loadWorkspaces loads the workspaces of the modules, blocking until they are
+// loaded.
+func loadWorkspaces(a *app.App, modules []*module.Module) error {
+	specs := make([]task.Spec, 0, len(modules))
+	for _, mod := range modules {
+		spec, err := a.Workspaces.Reload(mod.ID)
+		if err != nil {
+			return fmt.Errorf("loading workspaces: %w", err)
+		}
+		specs = append(specs, spec)
+	}
+	group, err := a.Tasks.CreateGroup(specs...)
+	if err != nil {
+		return fmt.Errorf("loading workspaces: %w", err)
+	}
+	var errs []error
+	for _, t := range group.Tasks {
+		if err := t.Wait(); err != nil {
+			errs = append(errs, fmt.Errorf("loading workspaces: %s: %w", t.Path, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// stream writes the output of the tasks to w, prefixing each line with the
+// module path and, if applicable, the workspace name of the task, followed by
+// a summary of each task once it has finished. It blocks until the tasks have
+// finished and returns the number of tasks that errored or were canceled.
+func stream(a *app.App, tasks []*task.Task, w io.Writer) int {
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed int
+	)
+	for _, t := range tasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			label := t.Path
+			if t.WorkspaceID != nil {
+				if ws, err := a.Workspaces.Get(*t.WorkspaceID); err == nil {
+					label += ":" + ws.Name
+				}
+			}
+			pw := &prefixWriter{mu: &mu, w: w, prefix: label + " | "}
+			for b := range t.NewStreamer() {
+				_, _ = pw.Write(b)
+			}
+			pw.Flush()
+
+			err := t.Wait()
+
+			mu.Lock()
+			defer mu.Unlock()
+			summary := fmt.Sprintf("%s: %s %s", label, t, t.State)
+			if err != nil {
+				summary += ": " + err.Error()
+				failed++
+			}
+			fmt.Fprintln(w, summary)
+		}()
+	}
+	wg.Wait()
+	return failed
+}
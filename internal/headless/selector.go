@@ -0,0 +1,55 @@
+package headless
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/leg100/pug/internal/module"
+	"github.com/leg100/pug/internal/workspace"
+)
+
+// Selector selects modules, and optionally their workspaces, by matching
+// their module paths and workspace names against glob patterns. A selector
+// takes the form MODULE[:WORKSPACE], e.g. `modules/*:prod`. Patterns follow
+// the syntax of path.Match, i.e. `*` does not match `/`.
+type Selector struct {
+	// Module is a glob pattern matching module paths. If empty then all
+	// modules are selected.
+	Module string
+	// Workspace is a glob pattern matching workspace names. If empty then
+	// the current workspace of each selected module is selected.
+	Workspace string
+}
+
+// ParseSelector parses a selector of the form MODULE[:WORKSPACE].
+func ParseSelector(s string) (Selector, error) {
+	mod, ws, _ := strings.Cut(s, ":")
+	sel := Selector{Module: mod, Workspace: ws}
+	// Check patterns are well-formed.
+	for _, pattern := range []string{sel.Module, sel.Workspace} {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return Selector{}, fmt.Errorf("invalid selector: %q: %w", s, err)
+		}
+	}
+	return sel, nil
+}
+
+func (s Selector) matchModule(mod *module.Module) bool {
+	if s.Module == "" {
+		return true
+	}
+	ok, _ := path.Match(s.Module, mod.Path)
+	return ok
+}
+
+func (s Selector) matchWorkspace(mod *module.Module, ws *workspace.Workspace) bool {
+	if ws.ModuleID != mod.ID || !s.matchModule(mod) {
+		return false
+	}
+	if s.Workspace == "" {
+		return mod.CurrentWorkspaceID != nil && *mod.CurrentWorkspaceID == ws.ID
+	}
+	ok, _ := path.Match(s.Workspace, ws.Name)
+	return ok
+}
@@ -0,0 +1,70 @@
+package headless
+
+import (
+	"testing"
+
+	"github.com/leg100/pug/internal/module"
+	"github.com/leg100/pug/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Selector
+		wantErr bool
+	}{
+		{"module", "modules/*", Selector{Module: "modules/*"}, false},
+		{"module and workspace", "modules/*:prod-*", Selector{Module: "modules/*", Workspace: "prod-*"}, false},
+		{"workspace of all modules", ":prod", Selector{Workspace: "prod"}, false},
+		{"malformed module pattern", "modules/[", Selector{}, true},
+		{"malformed workspace pattern", "modules/a:[", Selector{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSelector(tt.s)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSelector_Match(t *testing.T) {
+	mod := module.New(module.Options{Path: "modules/a"})
+	dev, err := workspace.New(mod, "dev")
+	require.NoError(t, err)
+	prod, err := workspace.New(mod, "prod")
+	require.NoError(t, err)
+	mod.CurrentWorkspaceID = &dev.ID
+
+	tests := []struct {
+		name          string
+		selector      Selector
+		wantModule    bool
+		wantWorkspace []*workspace.Workspace
+	}{
+		{"all modules", Selector{}, true, []*workspace.Workspace{dev}},
+		{"glob module", Selector{Module: "modules/*"}, true, []*workspace.Workspace{dev}},
+		{"glob does not match separator", Selector{Module: "*"}, false, nil},
+		{"glob workspace", Selector{Module: "modules/a", Workspace: "*"}, true, []*workspace.Workspace{dev, prod}},
+		{"named workspace", Selector{Workspace: "prod"}, true, []*workspace.Workspace{prod}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantModule, tt.selector.matchModule(mod))
+			var got []*workspace.Workspace
+			for _, ws := range []*workspace.Workspace{dev, prod} {
+				if tt.selector.matchWorkspace(mod, ws) {
+					got = append(got, ws)
+				}
+			}
+			assert.Equal(t, tt.wantWorkspace, got)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package headless
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// prefixWriter writes lines prefixed with a string, buffering an incomplete
+// line until it is completed. The mutex is shared by the writers of several
+// tasks so that their lines are not interleaved.
+type prefixWriter struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	prefix string
+	buf    []byte
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.buf = append(p.buf, b...)
+	for {
+		i := bytes.IndexByte(p.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if err := p.writeLine(p.buf[:i+1]); err != nil {
+			return 0, err
+		}
+		p.buf = p.buf[i+1:]
+	}
+	return len(b), nil
+}
+
+// Flush writes any incomplete line.
+func (p *prefixWriter) Flush() {
+	if len(p.buf) > 0 {
+		_ = p.writeLine(append(p.buf, '\n'))
+		p.buf = nil
+	}
+}
+
+func (p *prefixWriter) writeLine(line []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err := io.WriteString(p.w, p.prefix); err != nil {
+		return err
+	}
+	_, err := p.w.Write(line)
+	return err
+}
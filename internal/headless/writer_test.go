@@ -0,0 +1,24 @@
+package headless
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		mu  sync.Mutex
+	)
+	pw := &prefixWriter{mu: &mu, w: &buf, prefix: "modules/a:dev | "}
+
+	_, _ = pw.Write([]byte("first line\nsecond "))
+	_, _ = pw.Write([]byte("line\nincomplete"))
+	assert.Equal(t, "modules/a:dev | first line\nmodules/a:dev | second line\n", buf.String())
+
+	pw.Flush()
+	assert.Equal(t, "modules/a:dev | first line\nmodules/a:dev | second line\nmodules/a:dev | incomplete\n", buf.String())
+}
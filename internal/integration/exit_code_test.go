@@ -0,0 +1,67 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/leg100/pug/internal/app"
+	"github.com/leg100/pug/internal/tui/top"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode_TaskErrored(t *testing.T) {
+	t.Parallel()
+
+	tm := setup(t, "./testdata/single_module", withExitCode())
+
+	// Expect single module to be listed
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "modules/a")
+	})
+
+	// Validate module without first initializing it, which fails.
+	tm.Type("v")
+	waitFor(t, tm, func(s string) bool {
+		return matchPattern(t, "Task.*validate.*modules/a.*errored", s)
+	})
+
+	quit(t, tm)
+
+	err := top.ExitError(tm.FinalModel(t))
+	assert.Equal(t, app.TasksFailedError{Failed: 1}, err)
+}
+
+func TestExitCode_NoTasksFailed(t *testing.T) {
+	t.Parallel()
+
+	tm := setup(t, "./testdata/single_module", withExitCode())
+
+	// Expect single module to be listed
+	waitFor(t, tm, func(s string) bool {
+		return strings.Contains(s, "modules/a")
+	})
+
+	quit(t, tm)
+
+	assert.NoError(t, top.ExitError(tm.FinalModel(t)))
+}
+
+func withExitCode() configOption {
+	return func(cfg *app.Config) {
+		cfg.ExitCode = true
+	}
+}
+
+// quit quits pug, which quits without confirmation as long as no tasks are
+// active.
+func quit(t *testing.T, tm *testModel) {
+	t.Helper()
+
+	tm.Send(tea.KeyMsg{
+		Type: tea.KeyCtrlC,
+	})
+	tm.WaitFinished(t, teatest.WithFinalTimeout(time.Second))
+}
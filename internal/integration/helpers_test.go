@@ -57,6 +57,7 @@ func setup(t *testing.T, workdir string, opts ...configOption) *testModel {
 		MaxTasks:  3,
 		DataDir:   t.TempDir(),
 		Debug:     true,
+		Spinner:   "line",
 		Envs:      []string{fmt.Sprintf("TF_CLI_CONFIG_FILE=%s", mirrorConfigPath)},
 		Logging: logging.Options{
 			Level: "debug",
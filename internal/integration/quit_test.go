@@ -1,7 +1,6 @@
 package app
 
 import (
-	"strings"
 	"testing"
 	"time"
 
@@ -14,15 +13,11 @@ func TestQuit(t *testing.T) {
 
 	tm := setup(t, "./testdata/module_list")
 
+	// No tasks are active, so pug should quit without prompting for
+	// confirmation.
 	tm.Send(tea.KeyMsg{
 		Type: tea.KeyCtrlC,
 	})
 
-	waitFor(t, tm, func(s string) bool {
-		return strings.Contains(s, "Quit pug? (y/N): ")
-	})
-
-	tm.Type("y")
-
 	tm.WaitFinished(t, teatest.WithFinalTimeout(time.Second))
 }
@@ -0,0 +1,48 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// multiHandler fans out log records to several handlers, permitting records to
+// be encoded differently for each destination.
+type multiHandler []slog.Handler
+
+func (h multiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h multiHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, handler := range h {
+		if handler.Enabled(ctx, r.Level) {
+			// Each handler receives its own copy of the record, because a
+			// handler may modify it.
+			errs = append(errs, handler.Handle(ctx, r.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(multiHandler, len(h))
+	for i, handler := range h {
+		handlers[i] = handler.WithAttrs(attrs)
+	}
+	return handlers
+}
+
+func (h multiHandler) WithGroup(name string) slog.Handler {
+	handlers := make(multiHandler, len(h))
+	for i, handler := range h {
+		handlers[i] = handler.WithGroup(name)
+	}
+	return handlers
+}
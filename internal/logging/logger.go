@@ -12,6 +12,12 @@ import (
 
 const DefaultLevel = "info"
 
+// Encodings of log records written to a log file.
+const (
+	LogfmtFormat = "logfmt"
+	JSONFormat   = "json"
+)
+
 var levels = map[string]slog.Level{
 	"debug":      slog.LevelDebug,
 	DefaultLevel: slog.LevelInfo,
@@ -43,14 +49,38 @@ func ValidLevels() []string {
 func NewLogger(opts Options) *Logger {
 	logger := &Logger{}
 	broker := pubsub.NewBroker[Message](logger)
-	writer := &writer{table: resource.NewTable(broker)}
-
-	handler := slog.NewTextHandler(
-		io.MultiWriter(append(opts.AdditionalWriters, writer)...),
-		&slog.HandlerOptions{
-			Level: slog.Level(levels[opts.Level]),
-		},
-	)
+	writer := &writer{
+		table:       resource.NewTable(broker),
+		maxMessages: opts.MaxMessages,
+	}
+
+	// Skip any nil writers, which would otherwise cause a panic upon writing a
+	// log record.
+	var writers []io.Writer
+	for _, w := range opts.AdditionalWriters {
+		if w != nil {
+			writers = append(writers, w)
+		}
+	}
+	writers = append(writers, writer)
+
+	handlerOpts := &slog.HandlerOptions{
+		Level: slog.Level(levels[opts.Level]),
+	}
+	// Records kept in memory are always encoded as logfmt, which is what the
+	// writer parses. Records written to the log file are encoded separately,
+	// in whichever format the user chooses.
+	var handler slog.Handler = slog.NewTextHandler(io.MultiWriter(writers...), handlerOpts)
+	if opts.File != nil {
+		var fileHandler slog.Handler
+		switch opts.FileFormat {
+		case JSONFormat:
+			fileHandler = slog.NewJSONHandler(opts.File, handlerOpts)
+		default:
+			fileHandler = slog.NewTextHandler(opts.File, handlerOpts)
+		}
+		handler = multiHandler{handler, fileHandler}
+	}
 
 	logger.logger = slog.New(handler)
 	logger.Broker = broker
@@ -65,6 +95,15 @@ type Options struct {
 	Level string
 	// Any additional writers the log handler should write to.
 	AdditionalWriters []io.Writer
+	// MaxMessages is the maximum number of messages kept in memory, beyond
+	// which the oldest messages are dropped. Zero means there is no maximum.
+	// Additional writers still receive every message.
+	MaxMessages int
+	// File, if non-nil, is written log records encoded in FileFormat.
+	File io.Writer
+	// FileFormat is the encoding of log records written to File: either
+	// logfmt or json. Defaults to logfmt.
+	FileFormat string
 }
 
 // Logger wraps slog, providing further functionality such as emitting log
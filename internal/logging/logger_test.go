@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLogger_File(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		check  func(t *testing.T, got []byte)
+	}{
+		{
+			name: "default",
+			check: func(t *testing.T, got []byte) {
+				assert.Contains(t, string(got), `msg=hello animal=cat`)
+			},
+		},
+		{
+			name:   "logfmt",
+			format: LogfmtFormat,
+			check: func(t *testing.T, got []byte) {
+				assert.Contains(t, string(got), `msg=hello animal=cat`)
+			},
+		},
+		{
+			name:   "json",
+			format: JSONFormat,
+			check: func(t *testing.T, got []byte) {
+				var record map[string]any
+				require.NoError(t, json.Unmarshal(got, &record))
+				assert.Equal(t, "INFO", record["level"])
+				assert.Equal(t, "hello", record["msg"])
+				assert.Equal(t, "cat", record["animal"])
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var file bytes.Buffer
+			logger := NewLogger(Options{
+				Level:      "info",
+				File:       &file,
+				FileFormat: tt.format,
+			})
+
+			logger.Info("hello", "animal", "cat")
+			logger.Debug("not logged")
+
+			tt.check(t, file.Bytes())
+			assert.NotContains(t, file.String(), "not logged")
+
+			// Messages kept in memory are parsed regardless of the file's
+			// encoding.
+			msgs := logger.List()
+			require.Len(t, msgs, 1)
+			assert.Equal(t, "hello", msgs[0].Message)
+			assert.Equal(t, "INFO", msgs[0].Level)
+			require.Len(t, msgs[0].Attributes, 1)
+			assert.Equal(t, "animal", msgs[0].Attributes[0].Key)
+			assert.Equal(t, "cat", msgs[0].Attributes[0].Value)
+		})
+	}
+}
@@ -1,8 +1,11 @@
 package logging
 
 import (
+	"log/slog"
+	"strings"
 	"time"
 
+	"github.com/leg100/pug/internal"
 	"github.com/leg100/pug/internal/resource"
 )
 
@@ -19,6 +22,31 @@ type Message struct {
 	Attributes []Attr
 }
 
+// SlogLevel maps the message's level back to a slog.Level. An unrecognised
+// level is treated as info.
+func (m Message) SlogLevel() slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(m.Level)); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
+
+// String renders the message on a single line: its time, level, text, and
+// then each attribute's key and value, with any ANSI escape codes removed.
+// Attributes are rendered in the order in which they are held, which the
+// writer sorts by key.
+func (m Message) String() string {
+	var b strings.Builder
+	b.WriteString(m.Time.Format(time.RFC3339))
+	b.WriteString(" " + m.Level)
+	b.WriteString(" " + m.Message)
+	for _, attr := range m.Attributes {
+		b.WriteString(" " + attr.Key + "=" + attr.Value)
+	}
+	return internal.StripAnsi(b.String())
+}
+
 type Attr struct {
 	Key   string
 	Value string
@@ -0,0 +1,40 @@
+package logging
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessage_SlogLevel(t *testing.T) {
+	tests := []struct {
+		level string
+		want  slog.Level
+	}{
+		{"DEBUG", slog.LevelDebug},
+		{"INFO", slog.LevelInfo},
+		{"WARN", slog.LevelWarn},
+		{"ERROR", slog.LevelError},
+		{"bogus", slog.LevelInfo},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			assert.Equal(t, tt.want, Message{Level: tt.level}.SlogLevel())
+		})
+	}
+}
+
+func TestMessage_String(t *testing.T) {
+	msg := Message{
+		Time:    time.Date(2024, 6, 1, 12, 30, 0, 0, time.UTC),
+		Level:   "ERROR",
+		Message: "task failed",
+		Attributes: []Attr{
+			{Key: "error", Value: "\x1b[31mexit status 1\x1b[0m"},
+			{Key: "task", Value: "abc"},
+		},
+	}
+	assert.Equal(t, "2024-06-01T12:30:00Z ERROR task failed error=exit status 1 task=abc", msg.String())
+}
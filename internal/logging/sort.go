@@ -7,3 +7,9 @@ func BySerialDesc(i, j Message) int {
 	}
 	return -1
 }
+
+// BySerial sorts log messages by their serial in ascending order, i.e. oldest
+// first.
+func BySerial(i, j Message) int {
+	return BySerialDesc(j, i)
+}
@@ -2,7 +2,9 @@ package logging
 
 import (
 	"bytes"
+	"cmp"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/go-logfmt/logfmt"
@@ -13,6 +15,11 @@ import (
 // memory and emits and them as pug events.
 type writer struct {
 	table *resource.Table[Message]
+	// maxMessages is the maximum number of messages to keep in memory. Zero
+	// means there is no maximum.
+	maxMessages int
+	// retained are the IDs of messages kept in memory, oldest first.
+	retained []resource.ID
 }
 
 func (w *writer) Write(p []byte) (int, error) {
@@ -41,10 +48,30 @@ func (w *writer) Write(p []byte) (int, error) {
 				})
 			}
 		}
+		// Sort attributes by key so that they're rendered in a consistent
+		// order, retaining the original order of any duplicate keys.
+		slices.SortStableFunc(msg.Attributes, func(a, b Attr) int {
+			return cmp.Compare(a.Key, b.Key)
+		})
 		w.table.Add(msg.ID, msg)
+		w.evict(msg.ID)
 	}
 	if d.Err() != nil {
 		return 0, d.Err()
 	}
 	return len(p), nil
 }
+
+// evict records the addition of a message, removing the oldest messages from
+// memory once there are more than the maximum. Subscribers are not notified,
+// having already received the messages.
+func (w *writer) evict(added resource.ID) {
+	if w.maxMessages <= 0 {
+		return
+	}
+	w.retained = append(w.retained, added)
+	for len(w.retained) > w.maxMessages {
+		w.table.Evict(w.retained[0])
+		w.retained = w.retained[1:]
+	}
+}
@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter_SortAttributes(t *testing.T) {
+	logger := NewLogger(Options{Level: "debug"})
+
+	logger.Info("sorted", "zebra", "1", "apple", "2", "mango", "3", "apple", "4")
+
+	msgs := logger.List()
+	require.Len(t, msgs, 1)
+
+	var got [][2]string
+	for _, attr := range msgs[0].Attributes {
+		got = append(got, [2]string{attr.Key, attr.Value})
+	}
+	want := [][2]string{
+		{"apple", "2"},
+		{"apple", "4"},
+		{"mango", "3"},
+		{"zebra", "1"},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestWriter_MaxMessages(t *testing.T) {
+	logger := NewLogger(Options{Level: "debug", MaxMessages: 2})
+
+	logger.Info("first")
+	logger.Info("second")
+	logger.Info("third")
+
+	msgs := logger.List()
+	slices.SortFunc(msgs, BySerialDesc)
+	require.Len(t, msgs, 2)
+	assert.Equal(t, "third", msgs[0].Message)
+	assert.Equal(t, "second", msgs[1].Message)
+}
+
+func TestNewLogger_NilAdditionalWriter(t *testing.T) {
+	logger := NewLogger(Options{
+		Level:             "info",
+		AdditionalWriters: []io.Writer{nil},
+	})
+
+	assert.NotPanics(t, func() { logger.Info("hello") })
+	assert.Len(t, logger.List(), 1)
+}
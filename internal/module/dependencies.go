@@ -0,0 +1,46 @@
+package module
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// loadDependenciesFile loads dependencies between modules from the
+// dependencies file, which maps the path of a module to the paths of the
+// modules on which it depends, e.g.
+//
+//	modules/app:
+//	  - modules/vpc
+//	  - modules/database
+//
+// Modules missing from the file are left with no dependencies. If the file
+// does not exist then no module has any dependencies.
+func (s *Service) loadDependenciesFile() error {
+	path := s.dependenciesFile
+	if !filepath.IsAbs(path) {
+		path = s.workdir.Join(path)
+	}
+	results := make(map[string][]string)
+	body, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	} else if err == nil {
+		if err := yaml.Unmarshal(body, &results); err != nil {
+			return fmt.Errorf("parsing %s: %w", s.dependenciesFile, err)
+		}
+	}
+	// Reset the dependencies of modules missing from the file, in case they
+	// have been removed since the file was last loaded.
+	for _, mod := range s.table.List() {
+		if _, ok := results[mod.Path]; !ok {
+			results[mod.Path] = nil
+		}
+	}
+	s.setDependencies(results)
+	return nil
+}
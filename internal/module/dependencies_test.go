@@ -0,0 +1,61 @@
+package module
+
+import (
+	"os"
+	"testing"
+
+	"github.com/leg100/pug/internal"
+	"github.com/leg100/pug/internal/logging"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDependenciesFile(t *testing.T) {
+	workdir := internal.NewTestWorkdir(t)
+	vpc := New(Options{Path: "modules/vpc"})
+	db := New(Options{Path: "modules/database"})
+	app := New(Options{Path: "modules/app"})
+	svc := &Service{
+		table:            &fakeModuleTable{modules: []*Module{vpc, db, app}},
+		workdir:          workdir,
+		logger:           logging.Discard,
+		dependenciesFile: "deps.yaml",
+	}
+
+	body := `
+modules/app:
+  - modules/vpc
+  - modules/database
+  - modules/unknown
+modules/database:
+  - modules/vpc
+`
+	err := os.WriteFile(workdir.Join("deps.yaml"), []byte(body), 0o644)
+	require.NoError(t, err)
+
+	require.NoError(t, svc.loadDependenciesFile())
+
+	assert.Empty(t, vpc.Dependencies())
+	assert.Equal(t, []resource.ID{vpc.ID}, db.Dependencies())
+	// Unknown module is skipped.
+	assert.Equal(t, []resource.ID{vpc.ID, db.ID}, app.Dependencies())
+
+	t.Run("module removed from file", func(t *testing.T) {
+		err := os.WriteFile(workdir.Join("deps.yaml"), []byte("modules/app: [modules/vpc]"), 0o644)
+		require.NoError(t, err)
+
+		require.NoError(t, svc.loadDependenciesFile())
+
+		assert.Empty(t, db.Dependencies())
+		assert.Equal(t, []resource.ID{vpc.ID}, app.Dependencies())
+	})
+
+	t.Run("no file", func(t *testing.T) {
+		require.NoError(t, os.Remove(workdir.Join("deps.yaml")))
+
+		require.NoError(t, svc.loadDependenciesFile())
+
+		assert.Empty(t, app.Dependencies())
+	})
+}
@@ -0,0 +1,39 @@
+package module
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// HooksFilename is the name of the file in a module's directory that configures
+// the module's hooks.
+const HooksFilename = ".pug-hooks.yaml"
+
+// hooks are shell commands to run before and after terraform runs on a module.
+type hooks struct {
+	// Pre hooks are run before a plan.
+	Pre []string `yaml:"pre"`
+	// Post hooks are run after an apply.
+	Post []string `yaml:"post"`
+}
+
+// loadHooks loads the hooks configured in the given module directory. If there
+// is no hooks file in the directory then no hooks are returned.
+func loadHooks(dir string) (hooks, error) {
+	var h hooks
+	body, err := os.ReadFile(filepath.Join(dir, HooksFilename))
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	} else if err != nil {
+		return h, err
+	}
+	if err := yaml.Unmarshal(body, &h); err != nil {
+		return h, fmt.Errorf("parsing %s: %w", HooksFilename, err)
+	}
+	return h, nil
+}
@@ -0,0 +1,37 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadHooks(t *testing.T) {
+	dir := t.TempDir()
+	body := `
+pre:
+  - tflint
+  - ./check.sh
+post:
+  - ./notify.sh
+`
+	err := os.WriteFile(filepath.Join(dir, HooksFilename), []byte(body), 0o644)
+	require.NoError(t, err)
+
+	got, err := loadHooks(dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"tflint", "./check.sh"}, got.Pre)
+	assert.Equal(t, []string{"./notify.sh"}, got.Post)
+}
+
+func TestLoadHooks_NoFile(t *testing.T) {
+	got, err := loadHooks(t.TempDir())
+	require.NoError(t, err)
+
+	assert.Empty(t, got.Pre)
+	assert.Empty(t, got.Post)
+}
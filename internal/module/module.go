@@ -5,6 +5,8 @@ import (
 	"io/fs"
 	"log/slog"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 
 	"github.com/hashicorp/hcl/v2"
@@ -26,6 +28,12 @@ type Module struct {
 	// The module's backend type
 	Backend string
 
+	// PreHooks are shell commands run in the module directory before a plan.
+	PreHooks []string
+	// PostHooks are shell commands run in the module directory after an
+	// apply.
+	PostHooks []string
+
 	// Dependencies on other modules
 	dependencies []resource.ID
 }
@@ -36,14 +44,20 @@ type Options struct {
 	Path string
 	// Backend is the type of terraform backend
 	Backend string
+	// PreHooks are shell commands to run before a plan.
+	PreHooks []string
+	// PostHooks are shell commands to run after an apply.
+	PostHooks []string
 }
 
 // New constructs a module.
 func New(opts Options) *Module {
 	return &Module{
-		ID:      resource.NewID(resource.Module),
-		Path:    opts.Path,
-		Backend: opts.Backend,
+		ID:        resource.NewID(resource.Module),
+		Path:      opts.Path,
+		Backend:   opts.Backend,
+		PreHooks:  opts.PreHooks,
+		PostHooks: opts.PostHooks,
 	}
 }
 
@@ -61,6 +75,25 @@ func (m *Module) Dependencies() []resource.ID {
 	return m.dependencies
 }
 
+// findOptions configures the search for modules.
+type findOptions struct {
+	// maxDepth is the maximum depth of directories beneath the workdir to
+	// search. Zero means there is no maximum.
+	maxDepth int
+	// ignore is the names of directories to skip.
+	ignore []string
+}
+
+// depth returns the number of directories between the workdir and the given
+// path.
+func depth(workdir internal.Workdir, path string) int {
+	rel, err := filepath.Rel(workdir.String(), path)
+	if err != nil || rel == "." {
+		return 0
+	}
+	return len(strings.Split(rel, string(filepath.Separator)))
+}
+
 // find finds root modules that are descendents of the workdir and
 // returns options for creating equivalent pug modules.
 //
@@ -73,7 +106,7 @@ func (m *Module) Dependencies() []resource.ID {
 // errors encountered.
 //
 // When finished, both channels are closed.
-func find(ctx context.Context, workdir internal.Workdir) (<-chan Options, <-chan error) {
+func find(ctx context.Context, workdir internal.Workdir, opts findOptions) (<-chan Options, <-chan error) {
 	modules := make(chan Options)
 	errc := make(chan error, 1)
 
@@ -89,6 +122,15 @@ func find(ctx context.Context, workdir internal.Workdir) (<-chan Options, <-chan
 				case ".terraform", ".terragrunt-cache":
 					return filepath.SkipDir
 				}
+				if path == workdir.String() {
+					return nil
+				}
+				if slices.Contains(opts.ignore, d.Name()) {
+					return filepath.SkipDir
+				}
+				if opts.maxDepth > 0 && depth(workdir, path) > opts.maxDepth {
+					return filepath.SkipDir
+				}
 				return nil
 			}
 
@@ -117,9 +159,16 @@ func find(ctx context.Context, workdir internal.Workdir) (<-chan Options, <-chan
 						errc <- err
 						return
 					}
+					hooks, err := loadHooks(filepath.Dir(path))
+					if err != nil {
+						errc <- err
+						return
+					}
 					modules <- Options{
-						Path:    stripped,
-						Backend: backend,
+						Path:      stripped,
+						Backend:   backend,
+						PreHooks:  hooks.Pre,
+						PostHooks: hooks.Post,
 					}
 				}()
 			}
@@ -3,10 +3,12 @@ package module
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/leg100/pug/internal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNew(t *testing.T) {
@@ -18,7 +20,7 @@ func TestNew(t *testing.T) {
 
 func TestFindModules(t *testing.T) {
 	workdir, _ := internal.NewWorkdir("./testdata/modules")
-	modules, errch := find(context.Background(), workdir)
+	modules, errch := find(context.Background(), workdir, findOptions{})
 
 	var got []Options
 	for opts := range modules {
@@ -40,3 +42,47 @@ func TestFindModules(t *testing.T) {
 	_, closed := <-errch
 	assert.False(t, closed)
 }
+
+func TestFindModules_Options(t *testing.T) {
+	dir := t.TempDir()
+	for _, path := range []string{"a", "a/b", "a/b/c", ".git/d", "e/modules/f"} {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(path, 0o755))
+		err := os.WriteFile(filepath.Join(path, "main.tf"), []byte("terraform {\n  backend \"local\" {}\n}\n"), 0o644)
+		require.NoError(t, err)
+	}
+	workdir, err := internal.NewWorkdir(dir)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		opts findOptions
+		want []string
+	}{
+		{
+			name: "no options",
+			want: []string{"a", "a/b", "a/b/c", ".git/d", "e/modules/f"},
+		},
+		{
+			name: "max depth",
+			opts: findOptions{maxDepth: 2},
+			want: []string{"a", "a/b", ".git/d"},
+		},
+		{
+			name: "ignore dirs",
+			opts: findOptions{ignore: []string{".git", "modules"}},
+			want: []string{"a", "a/b", "a/b/c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modules, _ := find(context.Background(), workdir, tt.opts)
+
+			var got []string
+			for opts := range modules {
+				got = append(got, opts.Path)
+			}
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
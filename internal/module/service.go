@@ -7,6 +7,7 @@ import (
 	"io"
 	"path/filepath"
 	"slices"
+	"strings"
 
 	"github.com/leg100/pug/internal"
 	"github.com/leg100/pug/internal/logging"
@@ -22,6 +23,10 @@ type Service struct {
 	pluginCache bool
 	logger      logging.Interface
 	terragrunt  bool
+	find        findOptions
+	// dependenciesFile is the path to a file declaring dependencies between
+	// modules, or empty if there is no such file.
+	dependenciesFile string
 
 	*pubsub.Broker[*Module]
 }
@@ -32,10 +37,21 @@ type ServiceOptions struct {
 	PluginCache bool
 	Logger      logging.Interface
 	Terragrunt  bool
+	// MaxDepth is the maximum depth of directories beneath the workdir in
+	// which to search for modules. Zero means there is no maximum.
+	MaxDepth int
+	// IgnoreDirs are the names of directories in which not to search for
+	// modules.
+	IgnoreDirs []string
+	// DependenciesFile is the path to a file declaring dependencies between
+	// modules, relative to the working directory. Ignored if terragrunt is in
+	// use, in which case terragrunt determines the dependencies.
+	DependenciesFile string
 }
 
 type taskCreator interface {
 	Create(spec task.Spec) (*task.Task, error)
+	List(opts task.ListOptions) []*task.Task
 }
 
 type moduleTable interface {
@@ -64,6 +80,11 @@ func NewService(opts ServiceOptions) *Service {
 		pluginCache: opts.PluginCache,
 		logger:      opts.Logger,
 		terragrunt:  opts.Terragrunt,
+		find: findOptions{
+			maxDepth: opts.MaxDepth,
+			ignore:   opts.IgnoreDirs,
+		},
+		dependenciesFile: opts.DependenciesFile,
 	}
 }
 
@@ -73,7 +94,7 @@ func NewService(opts ServiceOptions) *Service {
 //
 // TODO: separate into Load and Reload
 func (s *Service) Reload() (added []string, removed []string, err error) {
-	ch, errc := find(context.TODO(), s.workdir)
+	ch, errc := find(context.TODO(), s.workdir, s.find)
 	var found []string
 	for ch != nil || errc != nil {
 		select {
@@ -92,9 +113,11 @@ func (s *Service) Reload() (added []string, removed []string, err error) {
 			} else if err != nil {
 				s.logger.Error("reloading modules", "error", err)
 			} else {
-				// Update in-place; the backend may have changed.
+				// Update in-place; the backend and hooks may have changed.
 				s.table.Update(mod.ID, func(existing *Module) error {
 					existing.Backend = opts.Backend
+					existing.PreHooks = opts.PreHooks
+					existing.PostHooks = opts.PostHooks
 					return nil
 				})
 			}
@@ -108,9 +131,16 @@ func (s *Service) Reload() (added []string, removed []string, err error) {
 			}
 		}
 	}
-	// Cleanup existing modules, removing those that are no longer to be found
+	// Cleanup existing modules, removing those that are no longer to be found.
+	// Modules with active tasks are retained so as not to disrupt the tasks;
+	// they are removed on a subsequent reload.
+	active := s.activeModules()
 	for _, existing := range s.table.List() {
 		if !slices.Contains(found, existing.Path) {
+			if slices.Contains(active, existing.ID) {
+				s.logger.Warn("not removing module with active tasks", "module", existing)
+				continue
+			}
 			s.table.Delete(existing.ID)
 			removed = append(removed, existing.Path)
 		}
@@ -121,10 +151,28 @@ func (s *Service) Reload() (added []string, removed []string, err error) {
 		if err := s.loadTerragruntDependencies(); err != nil {
 			s.logger.Error("loading terragrunt dependencies: %w", err)
 		}
+	} else if s.dependenciesFile != "" {
+		if err := s.loadDependenciesFile(); err != nil {
+			s.logger.Error("loading module dependencies", "error", err)
+		}
 	}
 	return
 }
 
+// activeModules returns the IDs of modules with tasks that have yet to finish.
+func (s *Service) activeModules() []resource.ID {
+	var ids []resource.ID
+	tasks := s.tasks.List(task.ListOptions{
+		Status: []task.Status{task.Pending, task.Queued, task.Running},
+	})
+	for _, t := range tasks {
+		if t.ModuleID != nil {
+			ids = append(ids, *t.ModuleID)
+		}
+	}
+	return ids
+}
+
 func (s *Service) loadTerragruntDependencies() error {
 	task, err := s.tasks.Create(task.Spec{
 		Execution: task.Execution{
@@ -143,13 +191,21 @@ func (s *Service) loadTerragruntDependenciesFromDigraph(r io.Reader) error {
 	if err != nil {
 		return fmt.Errorf("parsing terragrunt dependency graph: %w", err)
 	}
+	s.setDependencies(results)
+	return nil
+}
+
+// setDependencies sets the dependencies of modules, given a map of module
+// paths to the paths of the modules on which they depend. Paths are either
+// relative to pug's working directory or absolute.
+func (s *Service) setDependencies(results map[string][]string) {
 	for path, depPaths := range results {
 		// If absolute path then convert to path relative to pug's working
 		// directory.
 		if filepath.IsAbs(path) {
 			var err error
 			if path, err = s.workdir.Rel(path); err != nil {
-				s.logger.Error("loading terragrunt dependencies", "error", err)
+				s.logger.Error("loading module dependencies", "error", err)
 				// Skip loading dependencies for this module
 				continue
 			}
@@ -160,9 +216,9 @@ func (s *Service) loadTerragruntDependenciesFromDigraph(r io.Reader) error {
 		mod, err := s.GetByPath(path)
 		if err != nil {
 			if errors.Is(err, resource.ErrNotFound) {
-				s.logger.Warn("loading terragrunt dependencies", "error", err)
+				s.logger.Warn("loading module dependencies", "error", err)
 			} else {
-				s.logger.Error("loading terragrunt dependencies", "error", err)
+				s.logger.Error("loading module dependencies", "error", err)
 			}
 			// Skip handling dependencies for this module.
 			continue
@@ -175,8 +231,9 @@ func (s *Service) loadTerragruntDependenciesFromDigraph(r io.Reader) error {
 			if filepath.IsAbs(path) {
 				var err error
 				if path, err = s.workdir.Rel(path); err != nil {
+					s.logger.Error("loading module dependency", "error", err)
 					// Skip loading this dependency
-					return err
+					continue
 				}
 			}
 			// Retrieve module. If it cannot be found it is probably because the
@@ -185,9 +242,9 @@ func (s *Service) loadTerragruntDependenciesFromDigraph(r io.Reader) error {
 			mod, err := s.GetByPath(path)
 			if err != nil {
 				if errors.Is(err, resource.ErrNotFound) {
-					s.logger.Warn("loading terragrunt dependency", "error", err)
+					s.logger.Warn("loading module dependency", "error", err)
 				} else {
-					s.logger.Error("loading terragrunt dependency", "error", err)
+					s.logger.Error("loading module dependency", "error", err)
 				}
 				// Skip loading this dependency
 				continue
@@ -199,7 +256,6 @@ func (s *Service) loadTerragruntDependenciesFromDigraph(r io.Reader) error {
 			return nil
 		})
 	}
-	return nil
 }
 
 const InitTask task.Identifier = "init"
@@ -286,8 +342,15 @@ func (s *Service) SetCurrent(moduleID, workspaceID resource.ID) error {
 	return err
 }
 
+// ErrEmptyProgram is returned when attempting to execute a program without a
+// name.
+var ErrEmptyProgram = errors.New("program cannot be empty")
+
 // Execute a program in a module's directory.
 func (s *Service) Execute(moduleID resource.ID, program string, args ...string) (task.Spec, error) {
+	if strings.TrimSpace(program) == "" {
+		return task.Spec{}, ErrEmptyProgram
+	}
 	mod, err := s.table.Get(moduleID)
 	if err != nil {
 		return task.Spec{}, err
@@ -303,6 +366,9 @@ func (s *Service) Execute(moduleID resource.ID, program string, args ...string)
 		// mutually exclusive actions that prevent other tasks from running as
 		// expected, so we make it a blocking task to be on the safe side.
 		Blocking: true,
+		// Describe the task using the full command line, so that the user
+		// knows what produced the output.
+		Description: strings.Join(append([]string{program}, args...), " "),
 	}
 	return spec, nil
 }
@@ -3,11 +3,13 @@ package module
 import (
 	"bytes"
 	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/leg100/pug/internal"
 	"github.com/leg100/pug/internal/logging"
 	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/task"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -98,7 +100,9 @@ type fakeModuleTable struct {
 }
 
 func (f *fakeModuleTable) List() []*Module {
-	return f.modules
+	// Return a copy, as the real table does, so that callers can delete
+	// modules whilst iterating over them.
+	return slices.Clone(f.modules)
 }
 
 func (f *fakeModuleTable) Update(id resource.ID, updater func(*Module) error) (*Module, error) {
@@ -112,3 +116,72 @@ func (f *fakeModuleTable) Update(id resource.ID, updater func(*Module) error) (*
 	}
 	return nil, resource.ErrNotFound
 }
+
+func (f *fakeModuleTable) Get(id resource.ID) (*Module, error) {
+	for _, mod := range f.modules {
+		if mod.ID == id {
+			return mod, nil
+		}
+	}
+	return nil, resource.ErrNotFound
+}
+
+func (f *fakeModuleTable) Delete(id resource.ID) {
+	f.modules = slices.DeleteFunc(f.modules, func(mod *Module) bool {
+		return mod.ID == id
+	})
+}
+
+type fakeTaskLister struct {
+	tasks []*task.Task
+
+	taskCreator
+}
+
+func (f *fakeTaskLister) List(task.ListOptions) []*task.Task {
+	return f.tasks
+}
+
+func TestService_Reload_RetainModulesWithActiveTasks(t *testing.T) {
+	// Neither module exists on disk.
+	idle := New(Options{Path: "idle"})
+	busy := New(Options{Path: "busy"})
+	table := &fakeModuleTable{modules: []*Module{idle, busy}}
+	svc := &Service{
+		table:   table,
+		tasks:   &fakeTaskLister{tasks: []*task.Task{{ModuleID: &busy.ID, State: task.Running}}},
+		workdir: internal.NewTestWorkdir(t),
+		logger:  logging.Discard,
+	}
+
+	added, removed, err := svc.Reload()
+	require.NoError(t, err)
+
+	assert.Empty(t, added)
+	assert.Equal(t, []string{"idle"}, removed)
+	assert.Equal(t, []*Module{busy}, table.modules)
+}
+
+func TestService_Execute(t *testing.T) {
+	mod := New(Options{Path: "a/b/c"})
+	svc := &Service{
+		table:   &fakeModuleTable{modules: []*Module{mod}},
+		workdir: internal.NewTestWorkdir(t),
+		logger:  logging.Discard,
+	}
+
+	t.Run("program with args", func(t *testing.T) {
+		spec, err := svc.Execute(mod.ID, "terraform", "providers", "schema")
+		require.NoError(t, err)
+
+		assert.Equal(t, "a/b/c", spec.Path)
+		assert.Equal(t, "terraform", spec.Execution.Program)
+		assert.Equal(t, []string{"providers", "schema"}, spec.Execution.Args)
+		assert.Equal(t, "terraform providers schema", spec.Description)
+	})
+
+	t.Run("empty program", func(t *testing.T) {
+		_, err := svc.Execute(mod.ID, " ")
+		assert.ErrorIs(t, err, ErrEmptyProgram)
+	})
+}
@@ -0,0 +1,54 @@
+package plan
+
+import (
+	"slices"
+
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/task"
+)
+
+// BatchMode is the mode shared by a batch of runs, created for several
+// workspaces at once.
+type BatchMode string
+
+const (
+	// PlanOnly creates plans that can be applied later.
+	PlanOnly BatchMode = "plan only"
+	// PlanAndApply creates plans and applies them straight away, i.e.
+	// `terraform apply -auto-approve`.
+	PlanAndApply BatchMode = "plan and apply"
+)
+
+// Batch returns a func that creates a task spec for a run on a workspace,
+// using the given mode and options for every workspace in the batch.
+func (s *Service) Batch(mode BatchMode, opts CreateOptions) task.SpecFunc {
+	return func(workspaceID resource.ID) (task.Spec, error) {
+		if mode == PlanOnly {
+			return s.Plan(workspaceID, opts)
+		}
+		return s.Apply(workspaceID, opts)
+	}
+}
+
+// BatchModeOf returns the mode of the batch of runs to which the given tasks
+// belong, or false if they are not all runs in the same mode.
+func BatchModeOf(tasks []*task.Task) (BatchMode, bool) {
+	var mode BatchMode
+	for _, t := range tasks {
+		var m BatchMode
+		switch {
+		case t.Identifier == PlanTask:
+			m = PlanOnly
+		case t.Identifier == ApplyTask && slices.Contains(t.Args, "-auto-approve"):
+			// An apply without a plan file creates its own plan.
+			m = PlanAndApply
+		default:
+			return "", false
+		}
+		if mode != "" && mode != m {
+			return "", false
+		}
+		mode = m
+	}
+	return mode, mode != ""
+}
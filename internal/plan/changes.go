@@ -0,0 +1,66 @@
+package plan
+
+import (
+	"regexp"
+
+	"github.com/leg100/pug/internal"
+	"github.com/leg100/pug/internal/state"
+)
+
+// Action is the action a plan proposes to take on a resource.
+type Action string
+
+const (
+	CreateAction  Action = "create"
+	UpdateAction  Action = "update"
+	ReplaceAction Action = "replace"
+	DestroyAction Action = "destroy"
+	ReadAction    Action = "read"
+)
+
+// Actions lists actions in the order in which they're reported.
+var Actions = []Action{
+	CreateAction,
+	UpdateAction,
+	ReplaceAction,
+	DestroyAction,
+	ReadAction,
+}
+
+// ResourceChange is a change a plan proposes to make to a resource.
+type ResourceChange struct {
+	Address state.ResourceAddress
+	Action  Action
+}
+
+// resourceChangeRegex matches the comment preceding each resource in the
+// output of `terraform plan`, e.g.:
+//
+//	# aws_instance.web will be created
+//	# aws_instance.db is tainted, so must be replaced
+var resourceChangeRegex = regexp.MustCompile(`(?m)^\s*# (.+?)(?: is tainted, so)? (?:will be (created|updated in-place|destroyed|read during apply)|must be (replaced))$`)
+
+// parseResourceChanges reads the logs from `terraform plan` and returns the
+// changes proposed for each resource, in the order in which they're listed.
+func parseResourceChanges(logs string) []ResourceChange {
+	raw := internal.StripAnsi(logs)
+
+	var changes []ResourceChange
+	for _, match := range resourceChangeRegex.FindAllStringSubmatch(raw, -1) {
+		change := ResourceChange{Address: state.ResourceAddress(match[1])}
+		switch match[2] + match[3] {
+		case "created":
+			change.Action = CreateAction
+		case "updated in-place":
+			change.Action = UpdateAction
+		case "replaced":
+			change.Action = ReplaceAction
+		case "destroyed":
+			change.Action = DestroyAction
+		case "read during apply":
+			change.Action = ReadAction
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
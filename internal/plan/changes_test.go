@@ -0,0 +1,51 @@
+package plan
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseResourceChanges(t *testing.T) {
+	logs, err := os.ReadFile("testdata/plan_with_changes.txt")
+	require.NoError(t, err)
+
+	want := []ResourceChange{
+		{Address: "null_resource.demo2", Action: DestroyAction},
+		{Address: "null_resource.demo5", Action: CreateAction},
+	}
+	assert.Equal(t, want, parseResourceChanges(string(logs)))
+}
+
+func Test_ParseResourceChanges_AllActions(t *testing.T) {
+	logs := `
+  # aws_instance.a will be created
+  # aws_instance.b will be updated in-place
+  # aws_instance.c must be replaced
+  # aws_instance.d is tainted, so must be replaced
+  # aws_instance.e will be destroyed
+  # (because aws_instance.e is not in configuration)
+  # data.aws_ami.f will be read during apply
+  # aws_instance.g["a key"] will be created
+  # aws_instance.h has moved to aws_instance.i
+`
+	want := []ResourceChange{
+		{Address: "aws_instance.a", Action: CreateAction},
+		{Address: "aws_instance.b", Action: UpdateAction},
+		{Address: "aws_instance.c", Action: ReplaceAction},
+		{Address: "aws_instance.d", Action: ReplaceAction},
+		{Address: "aws_instance.e", Action: DestroyAction},
+		{Address: "data.aws_ami.f", Action: ReadAction},
+		{Address: `aws_instance.g["a key"]`, Action: CreateAction},
+	}
+	assert.Equal(t, want, parseResourceChanges(logs))
+}
+
+func Test_ParseResourceChanges_NoChanges(t *testing.T) {
+	logs, err := os.ReadFile("testdata/plan_no_changes.txt")
+	require.NoError(t, err)
+
+	assert.Empty(t, parseResourceChanges(string(logs)))
+}
@@ -0,0 +1,84 @@
+package plan
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ErrStalePlan is returned when applying a plan that was created before a
+// change to the module's files.
+var ErrStalePlan = errors.New("plan is stale: module files have changed since it was created")
+
+// fingerprintSuffixes are the suffixes of files that contribute to a
+// fingerprint of a module.
+var fingerprintSuffixes = []string{
+	".tf",
+	".tf.json",
+	".tfvars",
+	".tfvars.json",
+	".terraform.lock.hcl",
+}
+
+// fingerprint returns a hash of the terraform files found in the module
+// directory and its sub-directories, skipping hidden directories such as
+// .terraform, and the directories of any nested modules, which are absolute
+// paths. A change to the name or contents of any such file changes the
+// fingerprint.
+func fingerprint(dir string, nested ...string) (string, error) {
+	h := sha256.New()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == dir {
+				return nil
+			}
+			if strings.HasPrefix(d.Name(), ".") || slices.Contains(nested, path) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || !hasFingerprintSuffix(d.Name()) {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		// Separate the name from the contents, and each file from the next,
+		// with a null byte, to ensure distinct inputs produce distinct hashes.
+		io.WriteString(h, rel)
+		h.Write([]byte{0})
+		if _, err := io.Copy(h, f); err != nil {
+			return err
+		}
+		h.Write([]byte{0})
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hasFingerprintSuffix(name string) bool {
+	for _, suffix := range fingerprintSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
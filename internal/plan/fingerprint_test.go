@@ -0,0 +1,119 @@
+package plan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprint(t *testing.T) {
+	tests := []struct {
+		name string
+		// change makes a change to the module directory
+		change func(t *testing.T, dir string)
+		stale  bool
+	}{
+		{
+			"unchanged",
+			func(t *testing.T, dir string) {},
+			false,
+		},
+		{
+			"modify config",
+			func(t *testing.T, dir string) {
+				writeFile(t, dir, "main.tf", `resource "random_pet" "pet" { length = 3 }`)
+			},
+			true,
+		},
+		{
+			"add config",
+			func(t *testing.T, dir string) {
+				writeFile(t, dir, "outputs.tf", `output "pet" { value = random_pet.pet.id }`)
+			},
+			true,
+		},
+		{
+			"remove config",
+			func(t *testing.T, dir string) {
+				require.NoError(t, os.Remove(filepath.Join(dir, "main.tf")))
+			},
+			true,
+		},
+		{
+			"modify vars file in sub-directory",
+			func(t *testing.T, dir string) {
+				writeFile(t, dir, "vars/dev.tfvars", `length = 4`)
+			},
+			true,
+		},
+		{
+			"modify lock file",
+			func(t *testing.T, dir string) {
+				writeFile(t, dir, ".terraform.lock.hcl", `# changed`)
+			},
+			true,
+		},
+		{
+			"modify non-terraform file",
+			func(t *testing.T, dir string) {
+				writeFile(t, dir, "README.md", `# changed`)
+			},
+			false,
+		},
+		{
+			"modify file in hidden directory",
+			func(t *testing.T, dir string) {
+				writeFile(t, dir, ".terraform/modules/modules.json", `{}`)
+				writeFile(t, dir, ".terraform/modules/child/main.tf", `# changed`)
+			},
+			false,
+		},
+		{
+			"modify nested module",
+			func(t *testing.T, dir string) {
+				writeFile(t, dir, "nested/main.tf", `# changed`)
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFile(t, dir, "main.tf", `resource "random_pet" "pet" { length = 2 }`)
+			writeFile(t, dir, "vars/dev.tfvars", `length = 2`)
+			writeFile(t, dir, ".terraform.lock.hcl", `# lock`)
+			writeFile(t, dir, "README.md", `# readme`)
+			writeFile(t, dir, "nested/main.tf", `terraform { backend "local" {} }`)
+			nested := filepath.Join(dir, "nested")
+
+			before, err := fingerprint(dir, nested)
+			require.NoError(t, err)
+			plan := &plan{dir: dir, Fingerprint: before, nestedModules: []string{nested}}
+
+			tt.change(t, dir)
+
+			stale, err := plan.stale()
+			require.NoError(t, err)
+			assert.Equal(t, tt.stale, stale)
+		})
+	}
+}
+
+func TestPlan_StaleWithoutFingerprint(t *testing.T) {
+	plan := &plan{dir: t.TempDir()}
+
+	stale, err := plan.stale()
+	require.NoError(t, err)
+	assert.False(t, stale)
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
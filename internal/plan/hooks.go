@@ -0,0 +1,61 @@
+package plan
+
+import (
+	"fmt"
+
+	"github.com/leg100/pug/internal/task"
+)
+
+type taskCreator interface {
+	Create(spec task.Spec) (*task.Task, error)
+}
+
+// preHookExecutions returns executions of the module's pre-hooks, which are
+// run by a plan or apply task before terraform is executed, so that a failed
+// pre-hook fails the task.
+func (r *plan) preHookExecutions() []task.Execution {
+	executions := make([]task.Execution, len(r.preHooks))
+	for i, cmd := range r.preHooks {
+		executions[i] = task.Execution{
+			Program: "sh",
+			Args:    []string{"-c", cmd},
+		}
+	}
+	return executions
+}
+
+// postHookSpecs returns specs for tasks that run the module's post-hooks.
+func (r *plan) postHookSpecs() []task.Spec {
+	return r.hookSpecs("post-hook", r.postHooks)
+}
+
+func (r *plan) hookSpecs(kind string, commands []string) []task.Spec {
+	specs := make([]task.Spec, len(commands))
+	for i, cmd := range commands {
+		specs[i] = task.Spec{
+			ModuleID:    &r.ModuleID,
+			WorkspaceID: &r.WorkspaceID,
+			Path:        r.ModulePath,
+			Env:         r.envs,
+			Execution: task.Execution{
+				Program: "sh",
+				Args:    []string{"-c", cmd},
+			},
+			Description: fmt.Sprintf("%s: %s", kind, cmd),
+		}
+	}
+	return specs
+}
+
+// runHooks runs hook tasks in order, waiting for each task to finish before
+// creating the next. If a hook fails then the remaining hooks are skipped and
+// an error is returned.
+func runHooks(tasks taskCreator, specs ...task.Spec) error {
+	for _, spec := range specs {
+		spec.Wait = true
+		if _, err := tasks.Create(spec); err != nil {
+			return fmt.Errorf("%s: %w", spec.Description, err)
+		}
+	}
+	return nil
+}
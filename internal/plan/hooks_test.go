@@ -0,0 +1,84 @@
+package plan
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/leg100/pug/internal/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlan_HookSpecs(t *testing.T) {
+	f, mod, ws := setupTest(t)
+	mod.PreHooks = []string{"tflint", "./check.sh"}
+	mod.PostHooks = []string{"./notify.sh"}
+
+	run, err := f.newPlan(ws.ID, CreateOptions{})
+	require.NoError(t, err)
+
+	// Pre-hooks are run by both plan and apply tasks.
+	applySpec, err := run.applyTaskSpec()
+	require.NoError(t, err)
+	for _, spec := range []task.Spec{run.planTaskSpec(), applySpec} {
+		pre := spec.PreExecutions
+		require.Len(t, pre, 2)
+		assert.Equal(t, []string{"-c", "tflint"}, pre[0].Args)
+		assert.Equal(t, []string{"-c", "./check.sh"}, pre[1].Args)
+	}
+
+	post := run.postHookSpecs()
+	require.Len(t, post, 1)
+	assert.Equal(t, []string{"-c", "./notify.sh"}, post[0].Execution.Args)
+}
+
+func TestRunHooks(t *testing.T) {
+	specs := []task.Spec{
+		{Description: "first"},
+		{Description: "second"},
+		{Description: "third"},
+	}
+
+	t.Run("run in order", func(t *testing.T) {
+		tasks := &fakeTaskCreator{}
+
+		err := runHooks(tasks, specs...)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"first", "second", "third"}, tasks.created)
+	})
+
+	t.Run("wait for each hook", func(t *testing.T) {
+		tasks := &fakeTaskCreator{}
+
+		err := runHooks(tasks, specs...)
+		require.NoError(t, err)
+
+		assert.True(t, tasks.waited)
+	})
+
+	t.Run("skip remaining hooks after failure", func(t *testing.T) {
+		tasks := &fakeTaskCreator{fail: "second"}
+
+		err := runHooks(tasks, specs...)
+		assert.Error(t, err)
+
+		assert.Equal(t, []string{"first", "second"}, tasks.created)
+	})
+}
+
+type fakeTaskCreator struct {
+	// fail is the description of a spec for which to return an error
+	fail    string
+	created []string
+	waited  bool
+}
+
+func (f *fakeTaskCreator) Create(spec task.Spec) (*task.Task, error) {
+	f.created = append(f.created, spec.Description)
+	f.waited = spec.Wait
+	if spec.Description == f.fail {
+		return nil, errors.New("hook failed")
+	}
+	return &task.Task{}, nil
+}
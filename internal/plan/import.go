@@ -0,0 +1,111 @@
+package plan
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/task"
+)
+
+// ErrStateNotLoaded is returned when importing a plan file for a workspace
+// whose state has not been loaded, without which the plan file cannot be
+// checked against the workspace.
+var ErrStateNotLoaded = errors.New("workspace state has not been loaded: reload state before applying a plan file")
+
+// planFileStateEntry is the name of the entry in a plan file containing a
+// snapshot of the state against which the plan was created.
+const planFileStateEntry = "tfstate"
+
+// ImportPlan creates a task spec to apply a plan file created elsewhere, e.g.
+// in CI, i.e. `terraform apply path/to/plan.file`. A relative path is relative
+// to the module directory. The plan file must have been created against the
+// workspace's state, which must have been loaded in order to check as much.
+func (s *Service) ImportPlan(workspaceID resource.ID, path string) (task.Spec, error) {
+	if err := s.CheckLock(workspaceID); err != nil {
+		return task.Spec{}, err
+	}
+	state, err := s.states.Get(workspaceID)
+	if errors.Is(err, resource.ErrNotFound) {
+		return task.Spec{}, ErrStateNotLoaded
+	} else if err != nil {
+		return task.Spec{}, err
+	}
+	plan, err := s.newPlan(workspaceID, CreateOptions{})
+	if err != nil {
+		return task.Spec{}, err
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(plan.dir, path)
+	}
+	snapshot, err := planFileSnapshot(path)
+	if err != nil {
+		return task.Spec{}, fmt.Errorf("reading plan file: %w", err)
+	}
+	// Check the plan file matches the workspace.
+	if err := checkLineage(snapshot.Lineage, state.Lineage); err != nil {
+		return task.Spec{}, err
+	}
+	plan.ImportedFrom = path
+	plan.planFile = true
+	// It cannot be known whether the plan has changes without inspecting it,
+	// so leave that to terraform.
+	plan.HasChanges = true
+	s.table.Add(plan.ID, plan)
+
+	return s.applyTaskSpec(plan)
+}
+
+// checkLineage checks the lineage of the state against which a plan was
+// created matches the lineage of a workspace's state. The check passes if
+// either lineage is unknown, e.g. the workspace has no state yet.
+func checkLineage(planLineage, workspaceLineage string) error {
+	if planLineage == "" || workspaceLineage == "" {
+		return nil
+	}
+	if planLineage != workspaceLineage {
+		return errors.New("plan file was created for a different workspace: state lineage does not match")
+	}
+	return nil
+}
+
+// stateSnapshot is the snapshot of the state against which a plan was created.
+type stateSnapshot struct {
+	Lineage string `json:"lineage"`
+	Serial  int64  `json:"serial"`
+}
+
+// planFileSnapshot returns the state snapshot embedded in a plan file. An empty
+// lineage is returned if the plan was created without any prior state.
+func planFileSnapshot(path string) (stateSnapshot, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return stateSnapshot{}, err
+	}
+	if !info.Mode().IsRegular() {
+		return stateSnapshot{}, fmt.Errorf("%s is not a regular file", path)
+	}
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return stateSnapshot{}, fmt.Errorf("%s is not a terraform plan file: %w", path, err)
+	}
+	defer r.Close()
+
+	f, err := r.Open(planFileStateEntry)
+	if errors.Is(err, os.ErrNotExist) {
+		return stateSnapshot{}, fmt.Errorf("%s is not a terraform plan file: missing state snapshot", path)
+	} else if err != nil {
+		return stateSnapshot{}, err
+	}
+	defer f.Close()
+
+	var snapshot stateSnapshot
+	if err := json.NewDecoder(f).Decode(&snapshot); err != nil {
+		return stateSnapshot{}, fmt.Errorf("decoding state snapshot: %w", err)
+	}
+	return snapshot, nil
+}
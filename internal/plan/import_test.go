@@ -0,0 +1,164 @@
+package plan
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leg100/pug/internal/logging"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/state"
+	"github.com/leg100/pug/internal/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_ImportPlan(t *testing.T) {
+	f, mod, ws := setupTest(t)
+	svc := &Service{
+		table:   resource.NewTable(&fakePublisher[*plan]{}),
+		tasks:   task.NewService(task.ServiceOptions{Logger: logging.Discard}),
+		states:  &fakeStateGetter{state: &state.State{Lineage: "abc"}},
+		factory: f,
+	}
+
+	t.Run("valid plan file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "plan.out")
+		writePlanFile(t, path, map[string]string{
+			"tfplan":  "",
+			"tfstate": `{"lineage":"abc"}`,
+		})
+
+		spec, err := svc.ImportPlan(ws.ID, path)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"apply"}, spec.Execution.TerraformCommand)
+		assert.Contains(t, spec.Execution.Args, path)
+		assert.NotContains(t, spec.Execution.Args, "-auto-approve")
+		assert.Equal(t, "apply (imported plan)", spec.Description)
+
+		// The source of the plan is recorded on the run.
+		if assert.Len(t, svc.List(), 1) {
+			assert.Equal(t, path, svc.List()[0].ImportedFrom)
+		}
+	})
+
+	t.Run("relative to module directory", func(t *testing.T) {
+		path := f.workdir.Join(mod.Path, "plan.out")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		writePlanFile(t, path, map[string]string{
+			"tfplan":  "",
+			"tfstate": `{"lineage":"abc"}`,
+		})
+
+		spec, err := svc.ImportPlan(ws.ID, "plan.out")
+		require.NoError(t, err)
+
+		assert.Contains(t, spec.Execution.Args, path)
+	})
+
+	t.Run("different workspace", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "plan.out")
+		writePlanFile(t, path, map[string]string{
+			"tfplan":  "",
+			"tfstate": `{"lineage":"xyz"}`,
+		})
+
+		_, err := svc.ImportPlan(ws.ID, path)
+		assert.ErrorContains(t, err, "state lineage does not match")
+	})
+
+	t.Run("state not loaded", func(t *testing.T) {
+		svc := *svc
+		svc.states = &fakeStateGetter{}
+		path := filepath.Join(t.TempDir(), "plan.out")
+		writePlanFile(t, path, map[string]string{
+			"tfplan":  "",
+			"tfstate": `{"lineage":"abc"}`,
+		})
+
+		_, err := svc.ImportPlan(ws.ID, path)
+		assert.ErrorIs(t, err, ErrStateNotLoaded)
+	})
+
+	t.Run("missing plan file", func(t *testing.T) {
+		_, err := svc.ImportPlan(ws.ID, filepath.Join(t.TempDir(), "plan.out"))
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("not a plan file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "main.tf")
+		require.NoError(t, os.WriteFile(path, []byte(`resource "random_pet" "pet" {}`), 0o644))
+
+		_, err := svc.ImportPlan(ws.ID, path)
+		assert.ErrorContains(t, err, "not a terraform plan file")
+	})
+
+	t.Run("archive without state snapshot", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "plan.out")
+		writePlanFile(t, path, map[string]string{"tfplan": ""})
+
+		_, err := svc.ImportPlan(ws.ID, path)
+		assert.ErrorContains(t, err, "missing state snapshot")
+	})
+}
+
+func TestCheckLineage(t *testing.T) {
+	tests := []struct {
+		name      string
+		plan      string
+		workspace string
+		wantErr   bool
+	}{
+		{"matching", "abc", "abc", false},
+		{"mismatching", "abc", "xyz", true},
+		{"plan without prior state", "", "xyz", false},
+		{"workspace without state", "abc", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkLineage(tt.plan, tt.workspace)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+type fakeStateGetter struct {
+	// state is nil if the state has not been loaded.
+	state *state.State
+}
+
+func (f *fakeStateGetter) Get(resource.ID) (*state.State, error) {
+	if f.state == nil {
+		return nil, resource.ErrNotFound
+	}
+	return f.state, nil
+}
+
+func (f *fakeStateGetter) CreateReloadTask(resource.ID) (*task.Task, error) {
+	return &task.Task{}, nil
+}
+
+// writePlanFile writes a zip archive mimicking a terraform plan file, with
+// the given entries.
+func writePlanFile(t *testing.T, path string, entries map[string]string) {
+	t.Helper()
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	for name, content := range entries {
+		entry, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+}
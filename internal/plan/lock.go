@@ -0,0 +1,47 @@
+package plan
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/task"
+)
+
+// ErrWorkspaceLocked is returned when creating a plan or an apply for a
+// workspace that already has a plan or an apply that has yet to finish.
+var ErrWorkspaceLocked = errors.New("workspace is locked")
+
+// LockedBy returns the first of the given unfinished tasks that is a plan or
+// apply task for the workspace, i.e. the task that locks the workspace, if
+// there is one.
+func LockedBy(workspaceID resource.ID, unfinished []*task.Task) (*task.Task, bool) {
+	for _, t := range unfinished {
+		if t.WorkspaceID == nil || *t.WorkspaceID != workspaceID {
+			continue
+		}
+		if t.Identifier == PlanTask || t.Identifier == ApplyTask {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// LockedBy returns the plan or apply task that locks the workspace, if there is
+// one. A workspace is locked until its plan or apply task finishes, to prevent
+// plans and applies being queued one behind the other, and to prevent its
+// state being altered whilst a plan or apply is in progress.
+func (s *Service) LockedBy(workspaceID resource.ID) (*task.Task, bool) {
+	return LockedBy(workspaceID, s.tasks.List(task.ListOptions{
+		Status: []task.Status{task.Pending, task.Queued, task.Running},
+		Oldest: true,
+	}))
+}
+
+// CheckLock returns an error if the workspace is locked.
+func (s *Service) CheckLock(workspaceID resource.ID) error {
+	if t, ok := s.LockedBy(workspaceID); ok {
+		return fmt.Errorf("%w: %s task %s has yet to finish", ErrWorkspaceLocked, t.Identifier, t.ID)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/leg100/pug/internal/logging"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Lock(t *testing.T) {
+	f, _, ws := setupTest(t)
+	svc := &Service{
+		table:   resource.NewTable(&fakePublisher[*plan]{}),
+		tasks:   task.NewService(task.ServiceOptions{Logger: logging.Discard}),
+		logger:  logging.Discard,
+		factory: f,
+	}
+
+	_, locked := svc.LockedBy(ws.ID)
+	assert.False(t, locked)
+
+	spec, err := svc.Plan(ws.ID, CreateOptions{})
+	require.NoError(t, err)
+	tsk, err := svc.tasks.Create(spec)
+	require.NoError(t, err)
+
+	// The unfinished plan task locks the workspace.
+	lockedBy, locked := svc.LockedBy(ws.ID)
+	require.True(t, locked)
+	assert.Equal(t, tsk.ID, lockedBy.ID)
+
+	_, err = svc.Plan(ws.ID, CreateOptions{})
+	assert.ErrorIs(t, err, ErrWorkspaceLocked)
+	_, err = svc.Apply(ws.ID, CreateOptions{})
+	assert.ErrorIs(t, err, ErrWorkspaceLocked)
+
+	// Finishing the plan task releases the lock.
+	_, err = svc.tasks.Discard(tsk.ID)
+	require.NoError(t, err)
+
+	_, locked = svc.LockedBy(ws.ID)
+	assert.False(t, locked)
+	_, err = svc.Plan(ws.ID, CreateOptions{})
+	assert.NoError(t, err)
+}
+
+func TestLockedBy(t *testing.T) {
+	ws1 := resource.NewID(resource.Workspace)
+	ws2 := resource.NewID(resource.Workspace)
+
+	tests := []struct {
+		name   string
+		tasks  []*task.Task
+		locked bool
+	}{
+		{
+			name: "no tasks",
+		},
+		{
+			name:   "plan for workspace",
+			tasks:  []*task.Task{{WorkspaceID: &ws1, Identifier: PlanTask}},
+			locked: true,
+		},
+		{
+			name:   "apply for workspace",
+			tasks:  []*task.Task{{WorkspaceID: &ws1, Identifier: ApplyTask}},
+			locked: true,
+		},
+		{
+			name:  "plan for different workspace",
+			tasks: []*task.Task{{WorkspaceID: &ws2, Identifier: PlanTask}},
+		},
+		{
+			name:  "other task for workspace",
+			tasks: []*task.Task{{WorkspaceID: &ws1}},
+		},
+		{
+			name:  "module task",
+			tasks: []*task.Task{{Identifier: PlanTask}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, locked := LockedBy(ws1, tt.tasks)
+			assert.Equal(t, tt.locked, locked)
+		})
+	}
+}
@@ -4,14 +4,21 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/leg100/pug/internal"
+	"github.com/leg100/pug/internal/module"
 	"github.com/leg100/pug/internal/pubsub"
 	"github.com/leg100/pug/internal/resource"
 	"github.com/leg100/pug/internal/state"
 	"github.com/leg100/pug/internal/task"
+	"golang.org/x/exp/maps"
 )
 
 type plan struct {
@@ -24,24 +31,91 @@ type plan struct {
 	ArtefactsPath string
 	Destroy       bool
 	TargetAddrs   []state.ResourceAddress
+	// ReplaceAddrs are the addresses of resources the plan forces to be
+	// replaced.
+	ReplaceAddrs []state.ResourceAddress
+	// VarFiles are paths to variable files, relative to the module directory,
+	// passed to terraform.
+	VarFiles []string
+	// Vars are variable values passed to terraform, overriding those in
+	// variable files.
+	Vars map[string]string
+	// Env are environment variables set for the plan and apply tasks, whose
+	// values may be sensitive.
+	Env map[string]string
+	// ImportedFrom is the path to a plan file created outside of pug. Empty if
+	// the plan was created by pug.
+	ImportedFrom string
+	// Fingerprint is a hash of the module's files, taken when the plan task
+	// starts running. Empty if no plan task has run, or if the hash could not
+	// be computed. Guarded by mu.
+	Fingerprint string
+	// ResourceChanges are the changes the plan proposes to make to
+	// resources. Only populated once the plan task has finished.
+	ResourceChanges []ResourceChange
+	// AutoApply is true if the plan is to be applied as soon as it finishes.
+	// Taken from the workspace when the plan is created.
+	AutoApply bool
+	// Ephemeral is true if the plan is a preview that is yet to be kept.
+	Ephemeral bool
 
-	targetArgs         []string
-	terragrunt         bool
-	planFile           bool
-	varsFileArg        *string
+	// dir is the absolute path to the module directory.
+	dir         string
+	targetArgs  []string
+	replaceArgs []string
+	respectDeps bool
+	planFile    bool
+	// varsFile is the workspace's variables file, relative to the module
+	// directory. Empty if the workspace has no variables file.
+	varsFile           string
 	envs               []string
 	moduleDependencies []resource.ID
+	preHooks           []string
+	postHooks          []string
+	// nestedModules are the absolute paths of other modules beneath the
+	// module directory, whose files are excluded from the fingerprint.
+	nestedModules []string
+	// tfDataDir is the workspace's isolated terraform data directory. Empty
+	// if data directories are not isolated.
+	tfDataDir string
 
 	// taskID is the ID of the plan task, and is only set once the task is
 	// created.
 	taskID *resource.ID
+	// started is true once the plan's first task has been created.
+	started bool
+	// left is true if the plan is a preview that the user navigated away from
+	// before it finished, in which case it is discarded once it finishes.
+	left bool
+	// finished is the time at which the plan's most recent task finished.
+	// Zero whilst the task has yet to finish. It, started, left, and
+	// Fingerprint are written and read by different goroutines, so they are
+	// guarded by mu.
+	finished time.Time
+	mu       sync.Mutex
 }
 
 type CreateOptions struct {
 	// TargetAddrs creates a plan targeting specific resources.
 	TargetAddrs []state.ResourceAddress
+	// ReplaceAddrs creates a plan forcing the replacement of specific
+	// resources. Can be combined with TargetAddrs.
+	ReplaceAddrs []state.ResourceAddress
 	// Destroy creates a plan to destroy all resources.
 	Destroy bool
+	// VarFiles are paths to variable files, relative to the module directory,
+	// to pass to terraform with -var-file. Each file must exist.
+	VarFiles []string
+	// Vars are variable values to pass to terraform with -var.
+	Vars map[string]string
+	// Env are environment variables, e.g. TF_VAR_* or provider credentials,
+	// to set for the plan and apply tasks, taking precedence over the
+	// inherited environment.
+	Env map[string]string
+	// Ephemeral creates a preview plan, which is discarded once it has
+	// finished and the user has navigated away from it, unless it is kept.
+	// Only applicable to plans created with Plan.
+	Ephemeral bool
 	// planFile is true if a plan file is first created with `terraform plan
 	// -out plan.file`.
 	planFile bool
@@ -53,7 +127,12 @@ type factory struct {
 	modules    moduleGetter
 	workspaces workspaceGetter
 	broker     *pubsub.Broker[*plan]
-	terragrunt bool
+	// respectDeps is true if applies are to respect dependencies between
+	// modules.
+	respectDeps bool
+	// isolateDataDir is true if each workspace is to be given its own
+	// terraform data directory.
+	isolateDataDir bool
 }
 
 func (f *factory) newPlan(workspaceID resource.ID, opts CreateOptions) (*plan, error) {
@@ -70,12 +149,22 @@ func (f *factory) newPlan(workspaceID resource.ID, opts CreateOptions) (*plan, e
 		ModuleID:           mod.ID,
 		WorkspaceID:        ws.ID,
 		ModulePath:         mod.Path,
+		dir:                f.workdir.Join(mod.Path),
 		Destroy:            opts.Destroy,
 		TargetAddrs:        opts.TargetAddrs,
+		ReplaceAddrs:       opts.ReplaceAddrs,
+		VarFiles:           opts.VarFiles,
+		Vars:               opts.Vars,
+		Env:                opts.Env,
 		planFile:           opts.planFile,
-		terragrunt:         f.terragrunt,
+		respectDeps:        f.respectDeps,
 		envs:               []string{ws.TerraformEnv()},
 		moduleDependencies: mod.Dependencies(),
+		nestedModules:      f.nestedModules(mod),
+		preHooks:           mod.PreHooks,
+		postHooks:          mod.PostHooks,
+		AutoApply:          ws.AutoApply,
+		Ephemeral:          opts.Ephemeral,
 	}
 	if opts.planFile {
 		plan.ArtefactsPath = filepath.Join(f.dataDir, fmt.Sprintf("%d", plan.Serial))
@@ -83,41 +172,264 @@ func (f *factory) newPlan(workspaceID resource.ID, opts CreateOptions) (*plan, e
 			return nil, fmt.Errorf("creating run artefacts directory: %w", err)
 		}
 	}
+	if f.isolateDataDir {
+		// Give each workspace its own TF_DATA_DIR so that concurrent plans and
+		// applies on the same module don't contend over the same .terraform
+		// directory. The directory must exist before the task is started.
+		dir := f.tfDataDir(mod.Path, ws.Name)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("creating terraform data directory: %w", err)
+		}
+		plan.envs = append(plan.envs, fmt.Sprintf("TF_DATA_DIR=%s", dir))
+		plan.tfDataDir = dir
+	}
 	for _, addr := range plan.TargetAddrs {
+		if strings.TrimSpace(string(addr)) == "" {
+			return nil, errors.New("target address cannot be empty")
+		}
 		plan.targetArgs = append(plan.targetArgs, fmt.Sprintf("-target=%s", addr))
 	}
+	for _, addr := range plan.ReplaceAddrs {
+		if strings.TrimSpace(string(addr)) == "" {
+			return nil, errors.New("replace address cannot be empty")
+		}
+		plan.replaceArgs = append(plan.replaceArgs, fmt.Sprintf("-replace=%s", addr))
+	}
 	if fname, ok := ws.VarsFile(f.workdir); ok {
-		flag := fmt.Sprintf("-var-file=%s", fname)
-		plan.varsFileArg = &flag
+		plan.varsFile = fname
+	}
+	for _, fname := range plan.VarFiles {
+		path := fname
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(plan.dir, fname)
+		}
+		if _, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("checking variable file: %w", err)
+		}
+	}
+	for name := range plan.Vars {
+		if strings.TrimSpace(name) == "" {
+			return nil, errors.New("variable name cannot be empty")
+		}
+	}
+	for name := range plan.Env {
+		if strings.TrimSpace(name) == "" || strings.Contains(name, "=") {
+			return nil, fmt.Errorf("invalid environment variable name: %q", name)
+		}
 	}
 	return plan, nil
 }
 
+// nestedModules returns the absolute paths of the modules beneath the given
+// module's directory.
+func (f *factory) nestedModules(mod *module.Module) []string {
+	var nested []string
+	for _, other := range f.modules.List() {
+		if other.ID == mod.ID {
+			continue
+		}
+		rel, err := filepath.Rel(mod.Path, other.Path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+			continue
+		}
+		nested = append(nested, f.workdir.Join(other.Path))
+	}
+	return nested
+}
+
+// tfDataDir returns the path to an isolated terraform data directory for the
+// workspace with the given name belonging to the module with the given path.
+func (f *factory) tfDataDir(modulePath, workspaceName string) string {
+	return filepath.Join(f.dataDir, "tfdata", modulePath, workspaceName)
+}
+
+// preExecutions returns the executions to run before terraform plans or
+// applies: first, an isolated data directory that is yet to be initialized is
+// initialized, and then the module's pre-hooks are run.
+func (r *plan) preExecutions() []task.Execution {
+	var executions []task.Execution
+	if r.tfDataDir != "" {
+		if entries, err := os.ReadDir(r.tfDataDir); err != nil || len(entries) == 0 {
+			executions = append(executions, task.Execution{
+				TerraformCommand: []string{"init"},
+				Args:             []string{"-input=false"},
+			})
+		}
+	}
+	return append(executions, r.preHookExecutions()...)
+}
+
+// stale determines whether the module's files have changed since the plan task
+// started running. If the plan was never fingerprinted then it is assumed not
+// to be stale.
+func (r *plan) stale() (bool, error) {
+	want := r.fingerprinted()
+	if want == "" {
+		return false, nil
+	}
+	current, err := fingerprint(r.dir, r.nestedModules...)
+	if err != nil {
+		return false, err
+	}
+	return current != want, nil
+}
+
+// fingerprint takes a fingerprint of the module's files.
+func (r *plan) fingerprint() {
+	sum, _ := fingerprint(r.dir, r.nestedModules...)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Fingerprint = sum
+}
+
+// fingerprinted returns the fingerprint taken of the module's files.
+func (r *plan) fingerprinted() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.Fingerprint
+}
+
+// GetStatus retrieves the status of the plan's most recent task: pending
+// until a task is created, running until the task finishes, and finished
+// thereafter.
+func (r *plan) GetStatus() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch {
+	case !r.started:
+		return "pending"
+	case r.finished.IsZero():
+		return "running"
+	default:
+		return "finished"
+	}
+}
+
 func (r *plan) planPath() string {
+	if r.ImportedFrom != "" {
+		return r.ImportedFrom
+	}
 	return filepath.Join(r.ArtefactsPath, "plan")
 }
 
+func (r *plan) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("id", r.String()),
+		slog.String("module", r.ModulePath),
+		slog.Bool("destroy", r.Destroy),
+	}
+	if len(r.TargetAddrs) > 0 {
+		targets := make([]string, len(r.TargetAddrs))
+		for i, addr := range r.TargetAddrs {
+			targets[i] = string(addr)
+		}
+		attrs = append(attrs, slog.Any("targets", targets))
+	}
+	if len(r.ReplaceAddrs) > 0 {
+		replace := make([]string, len(r.ReplaceAddrs))
+		for i, addr := range r.ReplaceAddrs {
+			replace[i] = string(addr)
+		}
+		attrs = append(attrs, slog.Any("replace", replace))
+	}
+	if len(r.VarFiles) > 0 {
+		attrs = append(attrs, slog.Any("var_files", r.VarFiles))
+	}
+	if len(r.Vars) > 0 {
+		// Only log the names of variables, whose values may be sensitive.
+		attrs = append(attrs, slog.Any("vars", r.varNames()))
+	}
+	if len(r.Env) > 0 {
+		// Likewise only log the names of environment variables.
+		attrs = append(attrs, slog.Any("env", r.envNames()))
+	}
+	return slog.GroupValue(attrs...)
+}
+
 func (r *plan) args() []string {
 	return append([]string{"-input"}, r.targetArgs...)
 }
 
+// variables returns the plan's variable inputs.
+func (r *plan) variables() Variables {
+	return Variables{
+		VarsFile:   r.varsFile,
+		VarFiles:   r.VarFiles,
+		Vars:       r.Vars,
+		InPlanFile: r.planFile,
+	}
+}
+
+// sensitiveVariables returns the plan's variable inputs, noting which
+// variables the module declares sensitive.
+func (r *plan) sensitiveVariables() Variables {
+	vars := r.variables()
+	vars.Sensitive = sensitiveVariables(r.dir, r.Vars)
+	return vars
+}
+
+// varArgs returns the variable flags to pass to terraform.
+func (r *plan) varArgs() []string {
+	return r.variables().args()
+}
+
+// varNames returns the names of the user-specified variables, sorted.
+func (r *plan) varNames() []string {
+	return r.variables().names()
+}
+
+// envNames returns the names of the user-specified environment variables,
+// sorted.
+func (r *plan) envNames() []string {
+	names := maps.Keys(r.Env)
+	slices.Sort(names)
+	return names
+}
+
+// overrideEnv returns the user-specified environment variables in the form
+// NAME=VALUE, sorted by name.
+func (r *plan) overrideEnv() []string {
+	if len(r.Env) == 0 {
+		return nil
+	}
+	env := make([]string, 0, len(r.Env))
+	for _, name := range r.envNames() {
+		env = append(env, fmt.Sprintf("%s=%s", name, r.Env[name]))
+	}
+	return env
+}
+
+const PlanTask task.Identifier = "plan"
+
 func (r *plan) planTaskSpec() task.Spec {
 	// TODO: assert planFile is true first
 	spec := task.Spec{
-		ModuleID:    &r.ModuleID,
-		WorkspaceID: &r.WorkspaceID,
-		Path:        r.ModulePath,
-		Env:         r.envs,
+		Identifier:   PlanTask,
+		ModuleID:     &r.ModuleID,
+		WorkspaceID:  &r.WorkspaceID,
+		Path:         r.ModulePath,
+		Env:          r.envs,
+		OverrideEnv:  r.overrideEnv(),
+		RedactedArgs: r.sensitiveVariables().redactedArgs(),
 		Execution: task.Execution{
 			TerraformCommand: []string{"plan"},
 			Args:             append(r.args(), "-out", r.planPath()),
 		},
+		PreExecutions: r.preExecutions(),
 		// TODO: explain why plan is blocking (?)
 		Blocking:    true,
 		Description: "plan",
 		AfterCreate: func(t *task.Task) {
 			r.taskID = &t.ID
 		},
+		AfterRunning: func(*task.Task) {
+			// Fingerprint module files just as terraform starts reading
+			// them, so that any later change can be detected before the plan
+			// is applied.
+			r.fingerprint()
+		},
 		BeforeExited: func(t *task.Task) (task.Summary, error) {
 			out, err := io.ReadAll(t.NewReader(false))
 			if err != nil {
@@ -128,16 +440,19 @@ func (r *plan) planTaskSpec() task.Spec {
 				return nil, err
 			}
 			r.HasChanges = changes
+			r.ResourceChanges = parseResourceChanges(string(out))
 			return report, nil
 		},
 	}
-	if r.varsFileArg != nil {
-		spec.Execution.Args = append(spec.Execution.Args, *r.varsFileArg)
-	}
+	spec.Execution.Args = append(spec.Execution.Args, r.varArgs()...)
+	spec.Execution.Args = append(spec.Execution.Args, r.replaceArgs...)
 	if r.Destroy {
 		spec.Execution.Args = append(spec.Execution.Args, "-destroy")
 		spec.Description += " (destroy)"
 	}
+	if len(r.replaceArgs) > 0 {
+		spec.Description += " (replace)"
+	}
 	return spec
 }
 
@@ -156,15 +471,17 @@ func (r *plan) applyTaskSpec() (task.Spec, error) {
 			TerraformCommand: []string{"apply"},
 			Args:             r.args(),
 		},
-		Env:         r.envs,
-		Blocking:    true,
-		Description: "apply",
+		PreExecutions: r.preExecutions(),
+		Env:           r.envs,
+		OverrideEnv:   r.overrideEnv(),
+		Blocking:      true,
+		Description:   "apply",
 		BeforeExited: func(t *task.Task) (task.Summary, error) {
 			out, err := io.ReadAll(t.NewReader(false))
 			if err != nil {
 				return nil, err
 			}
-			if r.planFile {
+			if r.planFile && r.ImportedFrom == "" {
 				// Plan file can now be safely removed
 				_ = os.RemoveAll(r.ArtefactsPath)
 			}
@@ -175,8 +492,9 @@ func (r *plan) applyTaskSpec() (task.Spec, error) {
 			return report, nil
 		},
 	}
-	// If terragrunt is in use then respect module dependencies.
-	if r.terragrunt {
+	// If terragrunt is in use, or dependencies have been declared, then
+	// respect module dependencies.
+	if r.respectDeps {
 		spec.Dependencies = &task.Dependencies{
 			ModuleIDs: r.moduleDependencies,
 			// Module dependencies are reversed for a destroy.
@@ -186,10 +504,10 @@ func (r *plan) applyTaskSpec() (task.Spec, error) {
 	if r.planFile {
 		spec.Execution.Args = append(spec.Execution.Args, r.planPath())
 	} else {
-		if r.varsFileArg != nil {
-			spec.Execution.Args = append(spec.Execution.Args, *r.varsFileArg)
-		}
+		spec.Execution.Args = append(spec.Execution.Args, r.varArgs()...)
+		spec.Execution.Args = append(spec.Execution.Args, r.replaceArgs...)
 		spec.Execution.Args = append(spec.Execution.Args, "-auto-approve")
+		spec.RedactedArgs = r.sensitiveVariables().redactedArgs()
 	}
 	if r.Destroy {
 		if !r.planFile {
@@ -197,5 +515,11 @@ func (r *plan) applyTaskSpec() (task.Spec, error) {
 		}
 		spec.Description += " (destroy)"
 	}
+	if len(r.replaceArgs) > 0 {
+		spec.Description += " (replace)"
+	}
+	if r.ImportedFrom != "" {
+		spec.Description += " (imported plan)"
+	}
 	return spec, nil
 }
@@ -1,20 +1,21 @@
 package plan
 
 const (
-	CreateAction ChangeAction = "create"
-	UpdateAction ChangeAction = "update"
-	DeleteAction ChangeAction = "delete"
+	CreateChangeAction ChangeAction = "create"
+	UpdateChangeAction ChangeAction = "update"
+	DeleteChangeAction ChangeAction = "delete"
 )
 
 type (
 	// planFile represents the schema of a plan file
 	planFile struct {
-		ResourceChanges []ResourceChange  `json:"resource_changes"`
-		OutputChanges   map[string]Change `json:"output_changes"`
+		ResourceChanges []planFileResourceChange `json:"resource_changes"`
+		OutputChanges   map[string]Change        `json:"output_changes"`
 	}
 
-	// ResourceChange represents a proposed change to a resource in a plan file
-	ResourceChange struct {
+	// planFileResourceChange represents a proposed change to a resource in a
+	// plan file
+	planFileResourceChange struct {
 		Change Change
 	}
 
@@ -31,11 +32,11 @@ func (pf *planFile) resourceChanges() (resource Report) {
 	for _, rc := range pf.ResourceChanges {
 		for _, action := range rc.Change.Actions {
 			switch action {
-			case CreateAction:
+			case CreateChangeAction:
 				resource.Additions++
-			case UpdateAction:
+			case UpdateChangeAction:
 				resource.Changes++
-			case DeleteAction:
+			case DeleteChangeAction:
 				resource.Destructions++
 			}
 		}
@@ -48,11 +49,11 @@ func (pf *planFile) outputChanges() (output Report) {
 	for _, rc := range pf.OutputChanges {
 		for _, action := range rc.Actions {
 			switch action {
-			case CreateAction:
+			case CreateChangeAction:
 				output.Additions++
-			case UpdateAction:
+			case UpdateChangeAction:
 				output.Changes++
-			case DeleteAction:
+			case DeleteChangeAction:
 				output.Destructions++
 			}
 		}
@@ -8,6 +8,8 @@ import (
 	"github.com/leg100/pug/internal"
 	"github.com/leg100/pug/internal/module"
 	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/state"
+	"github.com/leg100/pug/internal/task"
 	"github.com/leg100/pug/internal/testutils"
 	"github.com/leg100/pug/internal/workspace"
 	"github.com/stretchr/testify/assert"
@@ -27,9 +29,8 @@ func TestPlan_VarsFile(t *testing.T) {
 	run, err := f.newPlan(ws.ID, CreateOptions{})
 	require.NoError(t, err)
 
-	if assert.NotNil(t, run.varsFileArg) {
-		assert.Equal(t, "-var-file=vars/dev.tfvars", *run.varsFileArg)
-	}
+	assert.Equal(t, "vars/dev.tfvars", run.varsFile)
+	assert.Equal(t, []string{"-var-file=vars/dev.tfvars"}, run.varArgs())
 }
 
 func TestPlan_MakeArtefactsPath(t *testing.T) {
@@ -41,6 +42,39 @@ func TestPlan_MakeArtefactsPath(t *testing.T) {
 	assert.DirExists(t, run.ArtefactsPath)
 }
 
+func TestPlan_NestedModules(t *testing.T) {
+	f, mod, ws := setupTest(t)
+	nested := module.New(module.Options{Path: "a/b/c/d"})
+	sibling := module.New(module.Options{Path: "a/b/e"})
+	f.modules = &fakeModuleGetter{mod: mod, others: []*module.Module{nested, sibling}}
+
+	run, err := f.newPlan(ws.ID, CreateOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{f.workdir.Join("a/b/c/d")}, run.nestedModules)
+}
+
+func TestPlan_IsolateDataDir(t *testing.T) {
+	f, mod, ws := setupTest(t)
+	f.isolateDataDir = true
+
+	run, err := f.newPlan(ws.ID, CreateOptions{})
+	require.NoError(t, err)
+
+	want := filepath.Join(f.dataDir, "tfdata", mod.Path, ws.Name)
+	assert.DirExists(t, want)
+	assert.Contains(t, run.envs, "TF_DATA_DIR="+want)
+
+	// An empty data directory is initialized before planning.
+	pre := run.planTaskSpec().PreExecutions
+	require.Len(t, pre, 1)
+	assert.Equal(t, []string{"init"}, pre[0].TerraformCommand)
+
+	// Once initialized, it is not initialized again.
+	require.NoError(t, os.WriteFile(filepath.Join(want, "terraform.tfstate"), nil, 0o644))
+	assert.Empty(t, run.planTaskSpec().PreExecutions)
+}
+
 func setupTest(t *testing.T) (*factory, *module.Module, *workspace.Workspace) {
 	workdir := internal.NewTestWorkdir(t)
 	testutils.ChTempDir(t, workdir.String())
@@ -58,13 +92,18 @@ func setupTest(t *testing.T) (*factory, *module.Module, *workspace.Workspace) {
 }
 
 type fakeModuleGetter struct {
-	mod *module.Module
+	mod    *module.Module
+	others []*module.Module
 }
 
 func (f *fakeModuleGetter) Get(resource.ID) (*module.Module, error) {
 	return f.mod, nil
 }
 
+func (f *fakeModuleGetter) List() []*module.Module {
+	return append([]*module.Module{f.mod}, f.others...)
+}
+
 type fakeWorkspaceGetter struct {
 	ws *workspace.Workspace
 }
@@ -72,3 +111,205 @@ type fakeWorkspaceGetter struct {
 func (f *fakeWorkspaceGetter) Get(resource.ID) (*workspace.Workspace, error) {
 	return f.ws, nil
 }
+
+func TestPlan_Destroy(t *testing.T) {
+	f, _, ws := setupTest(t)
+
+	run, err := f.newPlan(ws.ID, CreateOptions{Destroy: true, planFile: true})
+	require.NoError(t, err)
+
+	plan := run.planTaskSpec()
+	assert.Contains(t, plan.Execution.Args, "-destroy")
+	assert.Equal(t, "plan (destroy)", plan.Description)
+
+	// Apply of a destroy plan file does not need the -destroy flag, as the
+	// plan file already records that it is a destroy.
+	run.HasChanges = true
+	apply, err := run.applyTaskSpec()
+	require.NoError(t, err)
+	assert.NotContains(t, apply.Execution.Args, "-destroy")
+	assert.Equal(t, "apply (destroy)", apply.Description)
+}
+
+func TestPlan_Targets(t *testing.T) {
+	f, _, ws := setupTest(t)
+
+	t.Run("no targets", func(t *testing.T) {
+		run, err := f.newPlan(ws.ID, CreateOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"-input"}, run.args())
+		assert.NotContains(t, run.LogValue().String(), "targets")
+	})
+
+	t.Run("targets", func(t *testing.T) {
+		run, err := f.newPlan(ws.ID, CreateOptions{
+			TargetAddrs: []state.ResourceAddress{"aws_instance.a", "module.b"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"-input", "-target=aws_instance.a", "-target=module.b"}, run.args())
+		assert.Contains(t, run.LogValue().String(), "targets=[aws_instance.a module.b]")
+	})
+
+	t.Run("empty target", func(t *testing.T) {
+		_, err := f.newPlan(ws.ID, CreateOptions{
+			TargetAddrs: []state.ResourceAddress{" "},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestPlan_VarFiles(t *testing.T) {
+	f, mod, ws := setupTest(t)
+
+	path := f.workdir.Join(mod.Path, "prod.tfvars")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	_, err := os.Create(path)
+	require.NoError(t, err)
+
+	t.Run("existing file", func(t *testing.T) {
+		run, err := f.newPlan(ws.ID, CreateOptions{VarFiles: []string{"prod.tfvars"}})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"-var-file=prod.tfvars"}, run.varArgs())
+		assert.Contains(t, run.planTaskSpec().Execution.Args, "-var-file=prod.tfvars")
+		assert.Contains(t, run.LogValue().String(), "var_files=[prod.tfvars]")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := f.newPlan(ws.ID, CreateOptions{VarFiles: []string{"staging.tfvars"}})
+		assert.Error(t, err)
+	})
+}
+
+func TestFindVarFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, fname := range []string{"prod.tfvars", "dev.tfvars", "main.tf"} {
+		_, err := os.Create(filepath.Join(dir, fname))
+		require.NoError(t, err)
+	}
+
+	got, err := FindVarFiles(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev.tfvars", "prod.tfvars"}, got)
+}
+
+func TestPlan_Vars(t *testing.T) {
+	f, mod, ws := setupTest(t)
+
+	t.Run("no vars", func(t *testing.T) {
+		run, err := f.newPlan(ws.ID, CreateOptions{Vars: map[string]string{}})
+		require.NoError(t, err)
+
+		assert.Empty(t, run.varArgs())
+		assert.NotContains(t, run.LogValue().String(), "vars")
+	})
+
+	t.Run("vars", func(t *testing.T) {
+		run, err := f.newPlan(ws.ID, CreateOptions{Vars: map[string]string{
+			"instance_count": "3",
+			"greeting":       `say "hello world"`,
+		}})
+		require.NoError(t, err)
+
+		want := []string{
+			"-var=greeting=say \"hello world\"",
+			"-var=instance_count=3",
+		}
+		assert.Equal(t, want, run.varArgs())
+		assert.Subset(t, run.planTaskSpec().Execution.Args, want)
+		// Values are not logged.
+		assert.Contains(t, run.LogValue().String(), "vars=[greeting instance_count]")
+		assert.NotContains(t, run.LogValue().String(), "hello")
+	})
+
+	t.Run("sensitive vars", func(t *testing.T) {
+		writeFile(t, f.workdir.Join(mod.Path), "variables.tf", `variable "token" { sensitive = true }`)
+
+		run, err := f.newPlan(ws.ID, CreateOptions{Vars: map[string]string{
+			"token":  "secret",
+			"region": "eu-west-1",
+		}})
+		require.NoError(t, err)
+
+		// The value is passed to terraform but redacted wherever the args are
+		// shown or recorded.
+		for _, spec := range []task.Spec{run.planTaskSpec(), mustApplyTaskSpec(t, run)} {
+			assert.Contains(t, spec.Execution.Args, "-var=token=secret")
+			assert.Equal(t, map[string]string{"-var=token=secret": "-var=token=***"}, spec.RedactedArgs)
+		}
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		_, err := f.newPlan(ws.ID, CreateOptions{Vars: map[string]string{" ": "x"}})
+		assert.Error(t, err)
+	})
+}
+
+func mustApplyTaskSpec(t *testing.T, run *plan) task.Spec {
+	t.Helper()
+
+	spec, err := run.applyTaskSpec()
+	require.NoError(t, err)
+	return spec
+}
+
+func TestPlan_Replace(t *testing.T) {
+	f, _, ws := setupTest(t)
+
+	t.Run("replace with targets", func(t *testing.T) {
+		run, err := f.newPlan(ws.ID, CreateOptions{
+			TargetAddrs:  []state.ResourceAddress{"module.b"},
+			ReplaceAddrs: []state.ResourceAddress{"aws_instance.a"},
+			planFile:     true,
+		})
+		require.NoError(t, err)
+
+		plan := run.planTaskSpec()
+		assert.Subset(t, plan.Execution.Args, []string{"-target=module.b", "-replace=aws_instance.a"})
+		assert.Equal(t, "plan (replace)", plan.Description)
+		assert.Contains(t, run.LogValue().String(), "replace=[aws_instance.a]")
+	})
+
+	t.Run("empty replace address", func(t *testing.T) {
+		_, err := f.newPlan(ws.ID, CreateOptions{
+			ReplaceAddrs: []state.ResourceAddress{""},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestPlan_Env(t *testing.T) {
+	f, _, ws := setupTest(t)
+
+	t.Run("no env", func(t *testing.T) {
+		run, err := f.newPlan(ws.ID, CreateOptions{Env: map[string]string{}})
+		require.NoError(t, err)
+
+		assert.Nil(t, run.planTaskSpec().OverrideEnv)
+		assert.NotContains(t, run.LogValue().String(), "env")
+	})
+
+	t.Run("env", func(t *testing.T) {
+		run, err := f.newPlan(ws.ID, CreateOptions{Env: map[string]string{
+			"TF_VAR_region":         "eu-west-2",
+			"AWS_SECRET_ACCESS_KEY": "s3cr3t",
+		}})
+		require.NoError(t, err)
+
+		want := []string{
+			"AWS_SECRET_ACCESS_KEY=s3cr3t",
+			"TF_VAR_region=eu-west-2",
+		}
+		assert.Equal(t, want, run.planTaskSpec().OverrideEnv)
+		// Values are not logged.
+		assert.Contains(t, run.LogValue().String(), "env=[AWS_SECRET_ACCESS_KEY TF_VAR_region]")
+		assert.NotContains(t, run.LogValue().String(), "s3cr3t")
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		_, err := f.newPlan(ws.ID, CreateOptions{Env: map[string]string{"A=B": "x"}})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,110 @@
+package plan
+
+import (
+	"errors"
+	"os"
+
+	"github.com/leg100/pug/internal/resource"
+)
+
+// ErrNotPreview is returned when keeping or discarding a plan that is not a
+// preview.
+var ErrNotPreview = errors.New("plan is not a preview")
+
+// IsPreview returns true if the task created a preview plan that is yet to
+// be kept.
+func (s *Service) IsPreview(taskID resource.ID) bool {
+	plan, err := s.getByTaskID(taskID)
+	if err != nil {
+		return false
+	}
+	return plan.Ephemeral
+}
+
+// Keep keeps the preview plan created by the given task, so that it is no
+// longer discarded and behaves like any other plan.
+func (s *Service) Keep(taskID resource.ID) error {
+	preview, err := s.getByTaskID(taskID)
+	if err != nil {
+		return err
+	}
+	if !preview.Ephemeral {
+		return ErrNotPreview
+	}
+	_, err = s.table.Update(preview.ID, func(existing *plan) error {
+		existing.Ephemeral = false
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.logger.Info("kept preview plan", "plan", preview)
+	return nil
+}
+
+// DiscardPreview discards the preview plan created by the given task, along
+// with the task and the plan file. A preview that has yet to finish is instead
+// discarded once it finishes, in which case false is returned.
+func (s *Service) DiscardPreview(taskID resource.ID) (bool, error) {
+	plan, err := s.getByTaskID(taskID)
+	if err != nil {
+		return false, err
+	}
+	if !plan.Ephemeral {
+		return false, ErrNotPreview
+	}
+	if !plan.leave() {
+		return false, nil
+	}
+	if err := os.RemoveAll(plan.ArtefactsPath); err != nil {
+		return false, err
+	}
+	s.table.Delete(plan.ID)
+	if err := s.tasks.Delete(taskID); err != nil {
+		return false, err
+	}
+	s.logger.Info("discarded preview plan", "plan", plan)
+	return true, nil
+}
+
+// VisitPreview informs the preview plan created by the given task that the
+// user has returned to it, so that it is no longer discarded once it
+// finishes. It does nothing if the task did not create a preview.
+func (s *Service) VisitPreview(taskID resource.ID) {
+	if plan, err := s.getByTaskID(taskID); err == nil && plan.Ephemeral {
+		plan.visit()
+	}
+}
+
+// discardLeftPreview discards the preview plan once its task finishes if the
+// user navigated away from it beforehand.
+func (s *Service) discardLeftPreview(p *plan, taskID resource.ID) {
+	if !p.Ephemeral || !p.wasLeft() {
+		return
+	}
+	if _, err := s.DiscardPreview(taskID); err != nil {
+		s.logger.Error("discarding preview plan", "error", err, "plan", p)
+	}
+}
+
+// leave marks the plan as left by the user, returning true if it has already
+// finished and can be discarded straight away.
+func (r *plan) leave() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.left = true
+	return !r.finished.IsZero()
+}
+
+// visit unmarks the plan as left by the user.
+func (r *plan) visit() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.left = false
+}
+
+func (r *plan) wasLeft() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.left
+}
@@ -0,0 +1,104 @@
+package plan
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/leg100/pug/internal/logging"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Preview(t *testing.T) {
+	setup := func(t *testing.T) (*Service, *plan, resource.ID) {
+		f, _, ws := setupTest(t)
+		svc := &Service{
+			table:   resource.NewTable(&fakePublisher[*plan]{}),
+			tasks:   task.NewService(task.ServiceOptions{Logger: logging.Discard}),
+			logger:  logging.Discard,
+			factory: f,
+		}
+		p, err := svc.newPlan(ws.ID, CreateOptions{planFile: true, Ephemeral: true})
+		require.NoError(t, err)
+		svc.table.Add(p.ID, p)
+		tsk, err := svc.tasks.Create(p.planTaskSpec())
+		require.NoError(t, err)
+		return svc, p, tsk.ID
+	}
+
+	t.Run("discard finished preview", func(t *testing.T) {
+		svc, p, taskID := setup(t)
+		assert.True(t, svc.IsPreview(taskID))
+		p.setFinished(time.Now())
+
+		discarded, err := svc.DiscardPreview(taskID)
+		require.NoError(t, err)
+		assert.True(t, discarded)
+
+		_, err = svc.Get(p.ID)
+		assert.ErrorIs(t, err, resource.ErrNotFound)
+		_, err = svc.tasks.Get(taskID)
+		assert.ErrorIs(t, err, resource.ErrNotFound)
+		_, err = os.Stat(p.ArtefactsPath)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("discard unfinished preview once finished", func(t *testing.T) {
+		svc, p, taskID := setup(t)
+
+		discarded, err := svc.DiscardPreview(taskID)
+		require.NoError(t, err)
+		assert.False(t, discarded)
+
+		_, err = svc.Get(p.ID)
+		assert.NoError(t, err)
+
+		p.setFinished(time.Now())
+		svc.discardLeftPreview(p, taskID)
+
+		_, err = svc.Get(p.ID)
+		assert.ErrorIs(t, err, resource.ErrNotFound)
+	})
+
+	t.Run("do not discard preview that is not left", func(t *testing.T) {
+		svc, p, taskID := setup(t)
+
+		p.setFinished(time.Now())
+		svc.discardLeftPreview(p, taskID)
+
+		_, err := svc.Get(p.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("do not discard preview that is left and then revisited", func(t *testing.T) {
+		svc, p, taskID := setup(t)
+
+		discarded, err := svc.DiscardPreview(taskID)
+		require.NoError(t, err)
+		assert.False(t, discarded)
+
+		svc.VisitPreview(taskID)
+
+		p.setFinished(time.Now())
+		svc.discardLeftPreview(p, taskID)
+
+		_, err = svc.Get(p.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("keep preview", func(t *testing.T) {
+		svc, p, taskID := setup(t)
+		p.setFinished(time.Now())
+
+		require.NoError(t, svc.Keep(taskID))
+		assert.False(t, svc.IsPreview(taskID))
+
+		_, err := svc.DiscardPreview(taskID)
+		assert.ErrorIs(t, err, ErrNotPreview)
+		_, err = svc.Get(p.ID)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,182 @@
+package plan
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/task"
+)
+
+// ErrPlanFileRemoved is returned when applying a plan whose plan file has been
+// removed, e.g. according to the retention policy.
+var ErrPlanFileRemoved = errors.New("plan file has been removed")
+
+// Retention is the policy for removing the artefacts, i.e. plan files, of
+// finished plans. The artefacts of a plan are otherwise only removed once the
+// plan is applied or pug exits.
+type Retention struct {
+	// MaxAge is the time since a plan finished beyond which its artefacts are
+	// removed. Zero means there is no maximum.
+	MaxAge time.Duration
+	// MaxPerWorkspace is the number of finished plans per workspace whose
+	// artefacts are retained, beyond which the artefacts of the oldest plans
+	// are removed. Zero means there is no maximum.
+	MaxPerWorkspace int
+}
+
+func (r Retention) enabled() bool {
+	return r.MaxAge > 0 || r.MaxPerWorkspace > 0
+}
+
+// PruneArtefacts removes artefacts according to the retention policy. Artefacts
+// left behind in the data directory by a previous instance of pug, e.g. one
+// that crashed, are removed once they are older than the maximum age. The
+// artefacts of plans that have yet to finish, or that have changes yet to be
+// applied, are never removed.
+func (s *Service) PruneArtefacts(now time.Time) {
+	if !s.retention.enabled() {
+		return
+	}
+	plans := s.List()
+
+	if s.retention.MaxAge > 0 {
+		s.pruneOrphanedArtefacts(plans, now)
+	}
+
+	// Group finished plans with artefacts by workspace.
+	byWorkspace := make(map[resource.ID][]*plan)
+	for _, p := range plans {
+		if p.ImportedFrom != "" {
+			// Never remove a plan file created outside of pug.
+			continue
+		}
+		if p.finishedAt().IsZero() {
+			// Plan has yet to finish.
+			continue
+		}
+		if p.planFile && p.HasChanges {
+			// Plan file is yet to be applied; once applied its artefacts
+			// are removed.
+			continue
+		}
+		if _, err := os.Stat(p.ArtefactsPath); err != nil {
+			continue
+		}
+		byWorkspace[p.WorkspaceID] = append(byWorkspace[p.WorkspaceID], p)
+	}
+	for _, finished := range byWorkspace {
+		// Sort newest first.
+		slices.SortFunc(finished, func(i, j *plan) int {
+			return j.finishedAt().Compare(i.finishedAt())
+		})
+		for i, p := range finished {
+			tooMany := s.retention.MaxPerWorkspace > 0 && i >= s.retention.MaxPerWorkspace
+			tooOld := s.retention.MaxAge > 0 && now.Sub(p.finishedAt()) > s.retention.MaxAge
+			if !tooMany && !tooOld {
+				continue
+			}
+			if err := os.RemoveAll(p.ArtefactsPath); err != nil {
+				s.logger.Error("removing plan artefacts", "error", err, "plan", p)
+				continue
+			}
+			s.logger.Debug("removed plan artefacts", "plan", p)
+		}
+	}
+}
+
+// pruneOrphanedArtefacts removes artefact directories in the data directory
+// that belong to none of the given plans and are older than the maximum age.
+func (s *Service) pruneOrphanedArtefacts(plans []*plan, now time.Time) {
+	entries, err := os.ReadDir(s.dataDir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			s.logger.Error("pruning plan artefacts", "error", err)
+		}
+		return
+	}
+	owned := make(map[string]bool, len(plans))
+	for _, p := range plans {
+		owned[p.ArtefactsPath] = true
+	}
+	for _, entry := range entries {
+		// Artefact directories are named after the serial number of their
+		// plan.
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := strconv.Atoi(entry.Name()); err != nil {
+			continue
+		}
+		path := filepath.Join(s.dataDir, entry.Name())
+		if owned[path] {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || now.Sub(info.ModTime()) <= s.retention.MaxAge {
+			continue
+		}
+		if err := os.RemoveAll(path); err != nil {
+			s.logger.Error("removing plan artefacts", "error", err, "path", path)
+			continue
+		}
+		s.logger.Debug("removed plan artefacts", "path", path)
+	}
+}
+
+// trackFinish records on the plan when the task finishes, treating the plan
+// as unfinished until then, and then prunes artefacts according to the
+// retention policy. An event is published whenever the plan's status changes.
+func (s *Service) trackFinish(p *plan, spec *task.Spec) {
+	afterCreate := spec.AfterCreate
+	spec.AfterCreate = func(t *task.Task) {
+		p.setStarted()
+		if afterCreate != nil {
+			afterCreate(t)
+		}
+		s.publishStatus(p)
+	}
+	afterFinish := spec.AfterFinish
+	spec.AfterFinish = func(t *task.Task) {
+		p.setFinished(t.Updated)
+		if afterFinish != nil {
+			afterFinish(t)
+		}
+		s.publishStatus(p)
+		go s.PruneArtefacts(time.Now())
+	}
+}
+
+// publishStatus publishes an event for the plan to inform subscribers of a
+// change to its status. Nothing is published if the plan has been removed,
+// e.g. a discarded preview.
+func (s *Service) publishStatus(p *plan) {
+	_, _ = s.table.Update(p.ID, func(*plan) error { return nil })
+}
+
+// finishedAt returns the time at which the plan's most recent task finished,
+// or zero if it has yet to finish.
+func (r *plan) finishedAt() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.finished
+}
+
+// setStarted marks the plan as having started a new task, which has yet to
+// finish.
+func (r *plan) setStarted() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.started = true
+	r.finished = time.Time{}
+}
+
+func (r *plan) setFinished(t time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.finished = t
+}
@@ -0,0 +1,95 @@
+package plan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/leg100/pug/internal/logging"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_PruneArtefacts(t *testing.T) {
+	now := time.Now()
+
+	setup := func(t *testing.T, retention Retention) (*Service, []*plan) {
+		f, _, ws := setupTest(t)
+		svc := &Service{
+			table:     resource.NewTable(&fakePublisher[*plan]{}),
+			logger:    logging.Discard,
+			factory:   f,
+			retention: retention,
+		}
+		// Create three plans, which finished an hour, two hours, and three
+		// hours ago, and a fourth plan which has yet to finish.
+		var plans []*plan
+		for i := range 4 {
+			p, err := svc.newPlan(ws.ID, CreateOptions{planFile: true})
+			require.NoError(t, err)
+			svc.table.Add(p.ID, p)
+			if i < 3 {
+				p.setFinished(now.Add(-time.Duration(i+1) * time.Hour))
+			}
+			plans = append(plans, p)
+		}
+		return svc, plans
+	}
+	exists := func(p *plan) bool {
+		_, err := os.Stat(p.ArtefactsPath)
+		return err == nil
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		svc, plans := setup(t, Retention{})
+		svc.PruneArtefacts(now)
+		for _, p := range plans {
+			assert.True(t, exists(p))
+		}
+	})
+
+	t.Run("max age", func(t *testing.T) {
+		svc, plans := setup(t, Retention{MaxAge: 90 * time.Minute})
+		svc.PruneArtefacts(now)
+		assert.True(t, exists(plans[0]))
+		assert.False(t, exists(plans[1]))
+		assert.False(t, exists(plans[2]))
+		assert.True(t, exists(plans[3]), "unfinished plan should be retained")
+	})
+
+	t.Run("max per workspace", func(t *testing.T) {
+		svc, plans := setup(t, Retention{MaxPerWorkspace: 2})
+		svc.PruneArtefacts(now)
+		assert.True(t, exists(plans[0]))
+		assert.True(t, exists(plans[1]))
+		assert.False(t, exists(plans[2]))
+		assert.True(t, exists(plans[3]), "unfinished plan should be retained")
+	})
+
+	t.Run("unapplied changes", func(t *testing.T) {
+		svc, plans := setup(t, Retention{MaxAge: 90 * time.Minute})
+		plans[2].HasChanges = true
+		svc.PruneArtefacts(now)
+		assert.False(t, exists(plans[1]))
+		assert.True(t, exists(plans[2]), "plan with unapplied changes should be retained")
+	})
+
+	t.Run("orphaned artefacts", func(t *testing.T) {
+		svc, _ := setup(t, Retention{MaxAge: time.Hour})
+		stale := filepath.Join(svc.dataDir, "999")
+		fresh := filepath.Join(svc.dataDir, "1000")
+		other := filepath.Join(svc.dataDir, "tfdata")
+		for _, dir := range []string{stale, fresh, other} {
+			require.NoError(t, os.MkdirAll(dir, 0o755))
+			require.NoError(t, os.Chtimes(dir, now, now.Add(-2*time.Hour)))
+		}
+		require.NoError(t, os.Chtimes(fresh, now, now))
+
+		svc.PruneArtefacts(now)
+		assert.NoDirExists(t, stale)
+		assert.DirExists(t, fresh)
+		assert.DirExists(t, other)
+	})
+}
@@ -0,0 +1,248 @@
+package plan
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/state"
+	"github.com/leg100/pug/internal/task"
+)
+
+// ErrNoSavedPlan is returned when retrieving the saved plan of a workspace
+// that has no saved plan.
+var ErrNoSavedPlan = errors.New("workspace has no saved plan")
+
+const (
+	// savedPlansDir is the directory, relative to the data directory, in
+	// which plans are saved.
+	savedPlansDir = "saved"
+	// savedPlanMetadataFile is the name of the file alongside a saved plan
+	// file recording details of the plan that cannot be read from the plan
+	// file.
+	savedPlanMetadataFile = "plan.json"
+)
+
+// SavedPlan is a plan that was saved when pug last exited, and which can be
+// applied now that pug has restarted.
+type SavedPlan struct {
+	// Path is the path to the plan file.
+	Path string
+	// Destroy is true if the plan destroys all resources.
+	Destroy bool
+	// StateChanged is true if the workspace's state has changed since the
+	// plan was created, in which case terraform is likely to refuse to apply
+	// it.
+	StateChanged bool
+	// Variables are the variable inputs with which the plan was created,
+	// which are baked into the plan file.
+	Variables Variables
+
+	fingerprint string
+}
+
+type savedPlanMetadata struct {
+	Destroy     bool              `json:"destroy"`
+	Fingerprint string            `json:"fingerprint"`
+	VarsFile    string            `json:"vars_file,omitempty"`
+	VarFiles    []string          `json:"var_files,omitempty"`
+	Vars        map[string]string `json:"vars,omitempty"`
+	Sensitive   []string          `json:"sensitive,omitempty"`
+}
+
+// savedPlanDir returns the directory in which the saved plan of a workspace
+// is kept. The directory is named after the workspace's module path and
+// name, rather than its ID, which differs each time pug is started.
+func (f *factory) savedPlanDir(workspaceID resource.ID) (string, error) {
+	ws, err := f.workspaces.Get(workspaceID)
+	if err != nil {
+		return "", fmt.Errorf("retrieving workspace: %w", err)
+	}
+	return filepath.Join(f.dataDir, savedPlansDir, ws.ModulePath, ws.Name), nil
+}
+
+// SavedPlan retrieves the saved plan of a workspace.
+func (s *Service) SavedPlan(workspaceID resource.ID) (SavedPlan, error) {
+	dir, err := s.savedPlanDir(workspaceID)
+	if err != nil {
+		return SavedPlan{}, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, savedPlanMetadataFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return SavedPlan{}, ErrNoSavedPlan
+	} else if err != nil {
+		return SavedPlan{}, err
+	}
+	var metadata savedPlanMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return SavedPlan{}, fmt.Errorf("decoding saved plan metadata: %w", err)
+	}
+	saved := SavedPlan{
+		Path:    filepath.Join(dir, "plan"),
+		Destroy: metadata.Destroy,
+		Variables: Variables{
+			VarsFile:   metadata.VarsFile,
+			VarFiles:   metadata.VarFiles,
+			Vars:       metadata.Vars,
+			Sensitive:  metadata.Sensitive,
+			InPlanFile: true,
+		},
+		fingerprint: metadata.Fingerprint,
+	}
+	snapshot, err := planFileSnapshot(saved.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return SavedPlan{}, ErrPlanFileRemoved
+	} else if err != nil {
+		return SavedPlan{}, fmt.Errorf("reading plan file: %w", err)
+	}
+	// The checks are skipped if the workspace's state has not been loaded.
+	if current, err := s.states.Get(workspaceID); err == nil {
+		if err := checkLineage(snapshot.Lineage, current.Lineage); err != nil {
+			return SavedPlan{}, err
+		}
+		saved.StateChanged = stateChanged(snapshot, current)
+	}
+	return saved, nil
+}
+
+// stateChanged determines whether the state of a workspace has changed since
+// a snapshot of its state was taken.
+func stateChanged(snapshot stateSnapshot, current *state.State) bool {
+	if current.Serial == -1 {
+		// The workspace has no state.
+		return snapshot.Lineage != ""
+	}
+	if snapshot.Lineage == "" {
+		// The state has been created since the snapshot was taken.
+		return true
+	}
+	return snapshot.Serial != current.Serial
+}
+
+// ApplySavedPlan creates a task spec to apply the saved plan of a workspace,
+// i.e. `terraform apply saved.plan`. The saved plan is removed once it has
+// been successfully applied.
+func (s *Service) ApplySavedPlan(workspaceID resource.ID) (task.Spec, error) {
+	if err := s.CheckLock(workspaceID); err != nil {
+		return task.Spec{}, err
+	}
+	saved, err := s.SavedPlan(workspaceID)
+	if err != nil {
+		return task.Spec{}, err
+	}
+	plan, err := s.newPlan(workspaceID, CreateOptions{Destroy: saved.Destroy})
+	if err != nil {
+		return task.Spec{}, err
+	}
+	plan.planFile = true
+	// Record the variables with which the plan was created. They are baked
+	// into the plan file rather than passed to the apply.
+	plan.varsFile = saved.Variables.VarsFile
+	plan.VarFiles = saved.Variables.VarFiles
+	plan.Vars = saved.Variables.Vars
+	plan.ArtefactsPath = filepath.Dir(saved.Path)
+	plan.Fingerprint = saved.fingerprint
+	// Only plans with changes are saved.
+	plan.HasChanges = true
+	if stale, err := plan.stale(); err != nil {
+		return task.Spec{}, fmt.Errorf("checking whether plan is stale: %w", err)
+	} else if stale {
+		return task.Spec{}, ErrStalePlan
+	}
+	s.table.Add(plan.ID, plan)
+
+	spec, err := s.applyTaskSpec(plan)
+	if err != nil {
+		return task.Spec{}, err
+	}
+	spec.Description += " (saved plan)"
+	return spec, nil
+}
+
+// RemoveArtefacts removes the artefacts of all plans, and is to be called when
+// pug exits. If plans are to be saved then the plan file of the most recent
+// plan of each workspace is instead saved, provided it has changes that are
+// yet to be applied, so that it can be applied after pug restarts. Saved plans
+// that have yet to be applied are left as they are.
+func (s *Service) RemoveArtefacts() {
+	plans := s.List()
+	if s.savePlans {
+		latest := make(map[resource.ID]*plan)
+		for _, p := range plans {
+			if !s.saveable(p) {
+				continue
+			}
+			if prev, ok := latest[p.WorkspaceID]; ok && prev.finishedAt().After(p.finishedAt()) {
+				continue
+			}
+			latest[p.WorkspaceID] = p
+		}
+		for _, p := range latest {
+			if err := s.save(p); err != nil {
+				s.logger.Error("saving plan", "error", err, "plan", p)
+			}
+		}
+	}
+	saved := filepath.Join(s.dataDir, savedPlansDir) + string(filepath.Separator)
+	for _, p := range plans {
+		if strings.HasPrefix(p.ArtefactsPath, saved) {
+			continue
+		}
+		_ = os.RemoveAll(p.ArtefactsPath)
+	}
+}
+
+// saveable determines whether a plan can be saved: it must have been created
+// by a plan task that has successfully finished, and it must have changes
+// that are yet to be applied.
+func (s *Service) saveable(p *plan) bool {
+	if !p.planFile || p.ImportedFrom != "" || p.Ephemeral || !p.HasChanges {
+		return false
+	}
+	if p.taskID == nil {
+		return false
+	}
+	if t, err := s.tasks.Get(*p.taskID); err != nil || t.State != task.Exited {
+		return false
+	}
+	// The plan file is removed once the plan is applied.
+	_, err := os.Stat(p.planPath())
+	return err == nil
+}
+
+// save moves the artefacts of the plan to the workspace's saved plan
+// directory, replacing any plan already saved there.
+func (s *Service) save(p *plan) error {
+	dir, err := s.savedPlanDir(p.WorkspaceID)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dir), 0o755); err != nil {
+		return err
+	}
+	if err := os.Rename(p.ArtefactsPath, dir); err != nil {
+		return err
+	}
+	vars := p.sensitiveVariables()
+	data, err := json.Marshal(savedPlanMetadata{
+		Destroy:     p.Destroy,
+		Fingerprint: p.fingerprinted(),
+		VarsFile:    vars.VarsFile,
+		VarFiles:    vars.VarFiles,
+		// The values of sensitive variables are masked rather than written
+		// to disk.
+		Vars:      vars.redactedVars(),
+		Sensitive: vars.Sensitive,
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, savedPlanMetadataFile), data, 0o644)
+}
@@ -0,0 +1,124 @@
+package plan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leg100/pug/internal/logging"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/state"
+	"github.com/leg100/pug/internal/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_SavedPlan(t *testing.T) {
+	setup := func(t *testing.T, savePlans bool) (*Service, *plan, resource.ID) {
+		f, _, ws := setupTest(t)
+		svc := &Service{
+			table:     resource.NewTable(&fakePublisher[*plan]{}),
+			tasks:     task.NewService(task.ServiceOptions{Logger: logging.Discard}),
+			states:    state.NewService(state.ServiceOptions{Logger: logging.Discard}),
+			logger:    logging.Discard,
+			savePlans: savePlans,
+			factory:   f,
+		}
+		p, err := svc.newPlan(ws.ID, CreateOptions{
+			planFile: true,
+			Destroy:  true,
+			Vars:     map[string]string{"foo": "bar"},
+		})
+		require.NoError(t, err)
+		svc.table.Add(p.ID, p)
+		tsk, err := svc.tasks.Create(p.planTaskSpec())
+		require.NoError(t, err)
+		// Mimic the plan task successfully finishing with changes.
+		tsk.State = task.Exited
+		p.HasChanges = true
+		writePlanFile(t, p.planPath(), map[string]string{
+			"tfplan":  "",
+			"tfstate": `{"lineage":"abc","serial":3}`,
+		})
+		return svc, p, ws.ID
+	}
+
+	t.Run("save and apply", func(t *testing.T) {
+		svc, p, workspaceID := setup(t, true)
+
+		svc.RemoveArtefacts()
+
+		_, err := os.Stat(p.ArtefactsPath)
+		assert.True(t, os.IsNotExist(err))
+
+		saved, err := svc.SavedPlan(workspaceID)
+		require.NoError(t, err)
+		assert.True(t, saved.Destroy)
+		assert.False(t, saved.StateChanged)
+		assert.Equal(t, Variables{Vars: map[string]string{"foo": "bar"}, InPlanFile: true}, saved.Variables)
+
+		spec, err := svc.ApplySavedPlan(workspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"apply"}, spec.Execution.TerraformCommand)
+		assert.Contains(t, spec.Execution.Args, saved.Path)
+		// Variables are baked into the plan file rather than passed to the
+		// apply.
+		assert.NotContains(t, spec.Execution.Args, "-var=foo=bar")
+		assert.Equal(t, "apply (destroy) (saved plan)", spec.Description)
+
+		// A saved plan that has yet to be applied survives another exit.
+		svc.RemoveArtefacts()
+		_, err = os.Stat(saved.Path)
+		assert.NoError(t, err)
+	})
+
+	t.Run("mask sensitive variables", func(t *testing.T) {
+		svc, p, workspaceID := setup(t, true)
+		writeFile(t, p.dir, "variables.tf", `variable "foo" { sensitive = true }`)
+
+		svc.RemoveArtefacts()
+
+		saved, err := svc.SavedPlan(workspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, "-var=foo=***", saved.Variables.String())
+
+		// The value is not written to disk.
+		dir, err := svc.savedPlanDir(workspaceID)
+		require.NoError(t, err)
+		data, err := os.ReadFile(filepath.Join(dir, savedPlanMetadataFile))
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "bar")
+	})
+
+	t.Run("do not save plans", func(t *testing.T) {
+		svc, p, workspaceID := setup(t, false)
+
+		svc.RemoveArtefacts()
+
+		_, err := os.Stat(p.ArtefactsPath)
+		assert.True(t, os.IsNotExist(err))
+
+		_, err = svc.SavedPlan(workspaceID)
+		assert.ErrorIs(t, err, ErrNoSavedPlan)
+	})
+}
+
+func TestStateChanged(t *testing.T) {
+	tests := []struct {
+		name     string
+		snapshot stateSnapshot
+		current  *state.State
+		want     bool
+	}{
+		{"unchanged", stateSnapshot{Lineage: "abc", Serial: 3}, &state.State{Lineage: "abc", Serial: 3}, false},
+		{"changed", stateSnapshot{Lineage: "abc", Serial: 3}, &state.State{Lineage: "abc", Serial: 4}, true},
+		{"still no state", stateSnapshot{}, &state.State{Serial: -1}, false},
+		{"state created", stateSnapshot{}, &state.State{Lineage: "abc", Serial: 1}, true},
+		{"state removed", stateSnapshot{Lineage: "abc", Serial: 3}, &state.State{Serial: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, stateChanged(tt.snapshot, tt.current))
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package plan
 
 import (
+	"errors"
 	"fmt"
+	"os"
 
 	"github.com/leg100/pug/internal"
 	"github.com/leg100/pug/internal/logging"
@@ -20,7 +22,9 @@ type Service struct {
 	tasks      *task.Service
 	modules    moduleGetter
 	workspaces workspaceGetter
-	states     *state.Service
+	states     stateGetter
+	retention  Retention
+	savePlans  bool
 
 	*factory
 	*pubsub.Broker[*plan]
@@ -32,19 +36,36 @@ type ServiceOptions struct {
 	Workspaces *workspace.Service
 	States     *state.Service
 	DataDir    string
+	// Retention is the policy for removing the artefacts of finished plans.
+	Retention  Retention
 	Workdir    internal.Workdir
 	Logger     logging.Interface
 	Terragrunt bool
+	// ModuleDependencies is true if dependencies between modules have been
+	// declared in a dependencies file.
+	ModuleDependencies bool
+	// IsolateDataDir runs each workspace's plans and applies with a
+	// dedicated TF_DATA_DIR.
+	IsolateDataDir bool
+	// SavePlans saves unapplied plans when pug exits so that they can be
+	// applied after pug restarts.
+	SavePlans bool
 }
 
 type moduleGetter interface {
 	Get(moduleID resource.ID) (*module.Module, error)
+	List() []*module.Module
 }
 
 type workspaceGetter interface {
 	Get(workspaceID resource.ID) (*workspace.Workspace, error)
 }
 
+type stateGetter interface {
+	Get(workspaceID resource.ID) (*state.State, error)
+	CreateReloadTask(workspaceID resource.ID) (*task.Task, error)
+}
+
 func NewService(opts ServiceOptions) *Service {
 	broker := pubsub.NewBroker[*plan](opts.Logger)
 	return &Service{
@@ -55,13 +76,16 @@ func NewService(opts ServiceOptions) *Service {
 		workspaces: opts.Workspaces,
 		states:     opts.States,
 		logger:     opts.Logger,
+		retention:  opts.Retention,
+		savePlans:  opts.SavePlans,
 		factory: &factory{
-			dataDir:    opts.DataDir,
-			workdir:    opts.Workdir,
-			modules:    opts.Modules,
-			workspaces: opts.Workspaces,
-			broker:     broker,
-			terragrunt: opts.Terragrunt,
+			dataDir:        opts.DataDir,
+			workdir:        opts.Workdir,
+			modules:        opts.Modules,
+			workspaces:     opts.Workspaces,
+			broker:         broker,
+			respectDeps:    opts.Terragrunt || opts.ModuleDependencies,
+			isolateDataDir: opts.IsolateDataDir,
 		},
 	}
 }
@@ -94,6 +118,9 @@ func (s *Service) ReloadAfterApply(sub <-chan resource.Event[*task.Task]) {
 // Plan creates a task spec to create a plan, i.e. `terraform plan -out
 // plan.file`.
 func (s *Service) Plan(workspaceID resource.ID, opts CreateOptions) (task.Spec, error) {
+	if err := s.CheckLock(workspaceID); err != nil {
+		return task.Spec{}, err
+	}
 	opts.planFile = true
 	plan, err := s.newPlan(workspaceID, opts)
 	if err != nil {
@@ -101,18 +128,56 @@ func (s *Service) Plan(workspaceID resource.ID, opts CreateOptions) (task.Spec,
 		return task.Spec{}, err
 	}
 	s.table.Add(plan.ID, plan)
+	s.logger.Debug("created plan", "plan", plan)
+
+	spec := plan.planTaskSpec()
+	s.trackFinish(plan, &spec)
+	if plan.Ephemeral {
+		afterFinish := spec.AfterFinish
+		spec.AfterFinish = func(t *task.Task) {
+			afterFinish(t)
+			go s.discardLeftPreview(plan, t.ID)
+		}
+	}
+	// Destroy plans and previews are never automatically applied.
+	if plan.AutoApply && !plan.Destroy && !plan.Ephemeral {
+		spec.AfterExited = func(t *task.Task) {
+			go s.autoApply(plan, t.ID)
+		}
+	}
+	return spec, nil
+}
 
-	return plan.planTaskSpec(), nil
+// autoApply applies the plan created by the given task, provided the plan has
+// changes to apply.
+func (s *Service) autoApply(plan *plan, taskID resource.ID) {
+	if !plan.HasChanges {
+		return
+	}
+	spec, err := s.ApplyPlan(taskID)
+	if err != nil {
+		s.logger.Error("auto-applying plan", "error", err, "plan", plan)
+		return
+	}
+	if _, err := s.tasks.Create(spec); err != nil {
+		s.logger.Error("auto-applying plan", "error", err, "plan", plan)
+		return
+	}
+	s.logger.Info("auto-applying plan", "plan", plan)
 }
 
 // Apply creates a task spec to auto-apply a plan, i.e. `terraform apply`. To
 // apply an existing plan, see ApplyPlan.
 func (s *Service) Apply(workspaceID resource.ID, opts CreateOptions) (task.Spec, error) {
+	if err := s.CheckLock(workspaceID); err != nil {
+		return task.Spec{}, err
+	}
 	plan, err := s.newPlan(workspaceID, opts)
 	if err != nil {
 		return task.Spec{}, err
 	}
-	return plan.applyTaskSpec()
+	s.logger.Debug("created apply", "plan", plan)
+	return s.applyTaskSpec(plan)
 }
 
 // ApplyPlan creates a task spec to apply an existing plan, i.e. `terraform
@@ -130,13 +195,109 @@ func (s *Service) ApplyPlan(taskID resource.ID) (task.Spec, error) {
 	if err != nil {
 		return task.Spec{}, err
 	}
-	return plan.applyTaskSpec()
+	if err := s.CheckLock(plan.WorkspaceID); err != nil {
+		return task.Spec{}, err
+	}
+	if _, err := os.Stat(plan.planPath()); errors.Is(err, os.ErrNotExist) {
+		return task.Spec{}, ErrPlanFileRemoved
+	}
+	if stale, err := plan.stale(); err != nil {
+		return task.Spec{}, fmt.Errorf("checking whether plan is stale: %w", err)
+	} else if stale {
+		return task.Spec{}, ErrStalePlan
+	}
+	spec, err := s.applyTaskSpec(plan)
+	if err != nil {
+		return task.Spec{}, err
+	}
+	if plan.Ephemeral {
+		// Applying a preview keeps it, lest it and its plan file be
+		// discarded whilst it is being applied.
+		afterCreate := spec.AfterCreate
+		spec.AfterCreate = func(t *task.Task) {
+			if err := s.Keep(taskID); err != nil {
+				s.logger.Error("keeping applied preview plan", "error", err, "plan", plan)
+			}
+			if afterCreate != nil {
+				afterCreate(t)
+			}
+		}
+	}
+	return spec, nil
+}
+
+// Variables retrieves the variable inputs of the plan created by the task with
+// the given ID, noting which variables the module declares sensitive.
+func (s *Service) Variables(taskID resource.ID) (Variables, error) {
+	plan, err := s.getByTaskID(taskID)
+	if err != nil {
+		return Variables{}, err
+	}
+	return plan.sensitiveVariables(), nil
+}
+
+// IsDestroy reports whether the task with the given ID created a plan to
+// destroy resources.
+func (s *Service) IsDestroy(taskID resource.ID) bool {
+	plan, err := s.getByTaskID(taskID)
+	if err != nil {
+		return false
+	}
+	return plan.Destroy
+}
+
+// Replan creates a task spec to create a new plan with the same options as an
+// existing plan. The taskID is the ID of the existing plan's task.
+func (s *Service) Replan(taskID resource.ID) (task.Spec, error) {
+	plan, err := s.getByTaskID(taskID)
+	if err != nil {
+		return task.Spec{}, err
+	}
+	return s.Plan(plan.WorkspaceID, CreateOptions{
+		Destroy:      plan.Destroy,
+		TargetAddrs:  plan.TargetAddrs,
+		ReplaceAddrs: plan.ReplaceAddrs,
+		VarFiles:     plan.VarFiles,
+		Vars:         plan.Vars,
+		Env:          plan.Env,
+	})
+}
+
+// applyTaskSpec creates an apply task spec for the plan, running the module's
+// post-hooks once the apply has successfully finished. A failed post-hook is
+// reported but does not undo the apply.
+func (s *Service) applyTaskSpec(plan *plan) (task.Spec, error) {
+	spec, err := plan.applyTaskSpec()
+	if err != nil {
+		return task.Spec{}, err
+	}
+	s.trackFinish(plan, &spec)
+	if len(plan.postHooks) > 0 {
+		spec.AfterExited = func(*task.Task) {
+			go func() {
+				if err := runHooks(s.tasks, plan.postHookSpecs()...); err != nil {
+					s.logger.Error("running post-hooks", "error", err, "workspace", plan.WorkspaceID)
+				}
+			}()
+		}
+	}
+	return spec, nil
 }
 
 func (s *Service) Get(runID resource.ID) (*plan, error) {
 	return s.table.Get(runID)
 }
 
+// ResourceChanges returns the changes to resources proposed by the plan
+// created by the given task.
+func (s *Service) ResourceChanges(taskID resource.ID) ([]ResourceChange, error) {
+	plan, err := s.getByTaskID(taskID)
+	if err != nil {
+		return nil, err
+	}
+	return plan.ResourceChanges, nil
+}
+
 func (s *Service) getByTaskID(taskID resource.ID) (*plan, error) {
 	for _, plan := range s.List() {
 		if plan.taskID != nil && *plan.taskID == taskID {
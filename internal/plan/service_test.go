@@ -0,0 +1,105 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/leg100/pug/internal/logging"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/task"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Batch(t *testing.T) {
+	f, _, ws := setupTest(t)
+	svc := &Service{
+		table:   resource.NewTable(&fakePublisher[*plan]{}),
+		tasks:   task.NewService(task.ServiceOptions{Logger: logging.Discard}),
+		logger:  logging.Discard,
+		factory: f,
+	}
+
+	t.Run("plan-only batch", func(t *testing.T) {
+		fn := svc.Batch(PlanOnly, CreateOptions{})
+		var tasks []*task.Task
+		for range 2 {
+			spec, err := fn(ws.ID)
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{"plan"}, spec.Execution.TerraformCommand)
+			assert.Contains(t, spec.Execution.Args, "-out")
+
+			tasks = append(tasks, &task.Task{Identifier: spec.Identifier, Args: spec.Execution.Args})
+		}
+		// Expect both plans to be retained so that they can be applied later.
+		assert.Len(t, svc.List(), 2)
+
+		mode, ok := BatchModeOf(tasks)
+		require.True(t, ok)
+		assert.Equal(t, PlanOnly, mode)
+	})
+
+	t.Run("plan-and-apply batch", func(t *testing.T) {
+		spec, err := svc.Batch(PlanAndApply, CreateOptions{})(ws.ID)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"apply"}, spec.Execution.TerraformCommand)
+		assert.Contains(t, spec.Execution.Args, "-auto-approve")
+
+		mode, ok := BatchModeOf([]*task.Task{{Identifier: spec.Identifier, Args: spec.Execution.Args}})
+		require.True(t, ok)
+		assert.Equal(t, PlanAndApply, mode)
+	})
+
+	t.Run("not a batch of runs", func(t *testing.T) {
+		// Applying existing plans, or mixing modes, is not a batch of runs.
+		_, ok := BatchModeOf([]*task.Task{{Identifier: ApplyTask, Args: []string{"plan.file"}}})
+		assert.False(t, ok)
+		_, ok = BatchModeOf([]*task.Task{
+			{Identifier: PlanTask},
+			{Identifier: ApplyTask, Args: []string{"-auto-approve"}},
+		})
+		assert.False(t, ok)
+		_, ok = BatchModeOf(nil)
+		assert.False(t, ok)
+	})
+}
+
+func TestService_Plan_AutoApply(t *testing.T) {
+	f, _, ws := setupTest(t)
+	svc := &Service{
+		table:   resource.NewTable(&fakePublisher[*plan]{}),
+		tasks:   task.NewService(task.ServiceOptions{Logger: logging.Discard}),
+		logger:  logging.Discard,
+		factory: f,
+	}
+
+	spec, err := svc.Plan(ws.ID, CreateOptions{})
+	require.NoError(t, err)
+	assert.Nil(t, spec.AfterExited)
+
+	ws.AutoApply = true
+
+	spec, err = svc.Plan(ws.ID, CreateOptions{})
+	require.NoError(t, err)
+	assert.NotNil(t, spec.AfterExited)
+
+	// Destroy plans are never automatically applied.
+	spec, err = svc.Plan(ws.ID, CreateOptions{Destroy: true})
+	require.NoError(t, err)
+	assert.Nil(t, spec.AfterExited)
+
+	// Plans keep the setting they were created with.
+	ws.AutoApply = false
+	var enabled int
+	for _, p := range svc.List() {
+		if p.AutoApply {
+			enabled++
+		}
+	}
+	assert.Equal(t, 2, enabled)
+}
+
+type fakePublisher[T any] struct{}
+
+func (f *fakePublisher[T]) Publish(resource.EventType, T) {}
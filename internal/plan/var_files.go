@@ -0,0 +1,21 @@
+package plan
+
+import (
+	"path/filepath"
+	"slices"
+)
+
+// FindVarFiles returns the names of the variable files, i.e. files with a
+// .tfvars extension, in the given module directory, sorted by name.
+func FindVarFiles(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.tfvars"))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(paths))
+	for i, path := range paths {
+		names[i] = filepath.Base(path)
+	}
+	slices.Sort(names)
+	return names, nil
+}
@@ -0,0 +1,162 @@
+package plan
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"golang.org/x/exp/maps"
+)
+
+// Variables are the variable inputs of a plan, which are passed to terraform
+// when creating the plan and again when applying it, unless it is applied from
+// a plan file.
+type Variables struct {
+	// VarsFile is the workspace's variables file, relative to the module
+	// directory. Empty if the workspace has no variables file.
+	VarsFile string
+	// VarFiles are the user-specified variable files.
+	VarFiles []string
+	// Vars are the user-specified variables, which take precedence over those
+	// in files.
+	Vars map[string]string
+	// Sensitive are the names of those user-specified variables that are
+	// declared sensitive, whose values are masked when rendered.
+	Sensitive []string
+	// InPlanFile is true if the plan is applied from a plan file, into which
+	// the variables are baked, taking precedence over any passed to the
+	// apply.
+	InPlanFile bool
+}
+
+// Empty is true if there are no variable inputs.
+func (v Variables) Empty() bool {
+	return v.VarsFile == "" && len(v.VarFiles) == 0 && len(v.Vars) == 0
+}
+
+// String renders the variable inputs as the flags passed to terraform, masking
+// the values of sensitive variables.
+func (v Variables) String() string {
+	v.Vars = v.redactedVars()
+	return strings.Join(v.args(), " ")
+}
+
+// args returns the flags to pass to terraform: the workspace's variables file,
+// if any, followed by the user-specified variable files, and lastly the
+// user-specified variables, sorted by name.
+func (v Variables) args() []string {
+	var args []string
+	if v.VarsFile != "" {
+		args = append(args, fmt.Sprintf("-var-file=%s", v.VarsFile))
+	}
+	for _, fname := range v.VarFiles {
+		args = append(args, fmt.Sprintf("-var-file=%s", fname))
+	}
+	// Arguments are passed directly to the process rather than via a shell,
+	// so values need no quoting or escaping.
+	for _, name := range v.names() {
+		args = append(args, varArg(name, v.Vars[name]))
+	}
+	return args
+}
+
+func varArg(name, value string) string {
+	return fmt.Sprintf("-var=%s=%s", name, value)
+}
+
+// redactedVars returns the user-specified variables with the values of
+// sensitive variables masked.
+func (v Variables) redactedVars() map[string]string {
+	if len(v.Sensitive) == 0 {
+		return v.Vars
+	}
+	redacted := maps.Clone(v.Vars)
+	for _, name := range v.Sensitive {
+		if _, ok := redacted[name]; ok {
+			redacted[name] = "***"
+		}
+	}
+	return redacted
+}
+
+// redactedArgs maps the flags of sensitive variables to the form in which
+// they are shown and recorded instead.
+func (v Variables) redactedArgs() map[string]string {
+	redacted := make(map[string]string, len(v.Sensitive))
+	for _, name := range v.Sensitive {
+		if value, ok := v.Vars[name]; ok {
+			redacted[varArg(name, value)] = varArg(name, "***")
+		}
+	}
+	return redacted
+}
+
+// names returns the names of the user-specified variables, sorted.
+func (v Variables) names() []string {
+	names := maps.Keys(v.Vars)
+	slices.Sort(names)
+	return names
+}
+
+type variablesConfig struct {
+	Variables []variableBlock `hcl:"variable,block"`
+	Remain    hcl.Body        `hcl:",remain"`
+}
+
+type variableBlock struct {
+	Name      string   `hcl:"name,label"`
+	Sensitive *bool    `hcl:"sensitive,attr"`
+	Remain    hcl.Body `hcl:",remain"`
+}
+
+// sensitiveVariables returns the names of those of the given variables that
+// are declared sensitive by the module in the given directory, sorted. If the
+// module's configuration cannot be parsed then all of the variables are
+// assumed to be sensitive.
+func sensitiveVariables(dir string, vars map[string]string) []string {
+	if len(vars) == 0 {
+		return nil
+	}
+	all := maps.Keys(vars)
+	slices.Sort(all)
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return all
+	}
+	jsonPaths, err := filepath.Glob(filepath.Join(dir, "*.tf.json"))
+	if err != nil {
+		return all
+	}
+	parser := hclparse.NewParser()
+	var sensitive []string
+	for _, path := range append(paths, jsonPaths...) {
+		var (
+			f     *hcl.File
+			diags hcl.Diagnostics
+		)
+		if strings.HasSuffix(path, ".json") {
+			f, diags = parser.ParseJSONFile(path)
+		} else {
+			f, diags = parser.ParseHCLFile(path)
+		}
+		if diags.HasErrors() {
+			return all
+		}
+		var config variablesConfig
+		if diags := gohcl.DecodeBody(f.Body, nil, &config); diags.HasErrors() {
+			return all
+		}
+		for _, v := range config.Variables {
+			if _, ok := vars[v.Name]; ok && v.Sensitive != nil && *v.Sensitive {
+				sensitive = append(sensitive, v.Name)
+			}
+		}
+	}
+	slices.Sort(sensitive)
+	return sensitive
+}
@@ -0,0 +1,80 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariables_String(t *testing.T) {
+	tests := []struct {
+		name string
+		vars Variables
+		want string
+	}{
+		{"empty", Variables{}, ""},
+		{"vars file", Variables{VarsFile: "dev.tfvars"}, "-var-file=dev.tfvars"},
+		{
+			"all",
+			Variables{
+				VarsFile: "dev.tfvars",
+				VarFiles: []string{"common.tfvars"},
+				Vars:     map[string]string{"b": "2", "a": "1"},
+			},
+			"-var-file=dev.tfvars -var-file=common.tfvars -var=a=1 -var=b=2",
+		},
+		{
+			"sensitive",
+			Variables{
+				Vars:      map[string]string{"token": "secret", "region": "eu-west-1"},
+				Sensitive: []string{"token"},
+			},
+			"-var=region=eu-west-1 -var=token=***",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.vars.String())
+			assert.Equal(t, tt.want == "", tt.vars.Empty())
+		})
+	}
+}
+
+func TestSensitiveVariables(t *testing.T) {
+	vars := map[string]string{"token": "secret", "password": "secret", "region": "eu-west-1"}
+
+	t.Run("declared sensitive", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "variables.tf", `
+variable "token" {
+  type      = string
+  sensitive = true
+}
+
+variable "region" {
+  sensitive = false
+}
+
+variable "unused" {
+  sensitive = true
+}
+
+resource "null_resource" "foo" {}
+`)
+		writeFile(t, dir, "variables.tf.json", `{"variable": {"password": {"sensitive": true}}}`)
+
+		assert.Equal(t, []string{"password", "token"}, sensitiveVariables(dir, vars))
+	})
+
+	t.Run("unparseable configuration", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile(t, dir, "main.tf", `variable "token" {`)
+
+		// All variables are assumed to be sensitive.
+		assert.Equal(t, []string{"password", "region", "token"}, sensitiveVariables(dir, vars))
+	})
+
+	t.Run("no variables", func(t *testing.T) {
+		assert.Empty(t, sensitiveVariables(t.TempDir(), nil))
+	})
+}
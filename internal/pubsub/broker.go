@@ -95,9 +95,13 @@ func (b *Broker[T]) Publish(t resource.EventType, payload T) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
+	event := resource.Event[T]{Type: t, Payload: payload}
+	if r, ok := any(payload).(resource.StatusResource); ok {
+		event.Status = r.GetStatus()
+	}
 	for sub := range b.subs {
 		select {
-		case sub <- resource.Event[T]{Type: t, Payload: payload}:
+		case sub <- event:
 		case <-b.done:
 			return
 		}
@@ -14,6 +14,10 @@ type (
 	Event[T Resource] struct {
 		Type    EventType
 		Payload T
+		// Status is the status of the payload at the time of the event. The
+		// payload is typically shared and may have changed by the time the
+		// event is received. Empty if the payload has no status.
+		Status string
 	}
 
 	Publisher[T any] interface {
@@ -13,6 +13,8 @@ const (
 	LogAttr
 	State
 	StateResource
+	StateDiff
+	Output
 )
 
 func (k Kind) String() string {
@@ -27,5 +29,7 @@ func (k Kind) String() string {
 		"attr",
 		"state",
 		"res",
+		"diff",
+		"output",
 	}[k]
 }
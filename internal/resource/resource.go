@@ -10,3 +10,9 @@ type Resource interface {
 	// unique across pug.
 	String() string
 }
+
+// StatusResource is a resource with a status that changes over its lifetime.
+type StatusResource interface {
+	// GetStatus retrieves the current status of the resource.
+	GetStatus() string
+}
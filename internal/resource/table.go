@@ -57,6 +57,14 @@ func (t *Table[T]) Delete(id ID) {
 	t.pub.Publish(DeletedEvent, row)
 }
 
+// Evict removes a row without emitting an event.
+func (t *Table[T]) Evict(id ID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.rows, id)
+}
+
 func (t *Table[T]) Get(id ID) (T, error) {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -0,0 +1,159 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+
+	"github.com/leg100/pug/internal/resource"
+	"golang.org/x/exp/maps"
+)
+
+// maxDiffs is the maximum number of comparisons of states kept in memory,
+// beyond which the oldest comparisons are removed.
+const maxDiffs = 10
+
+// DiffType describes how a resource differs between two states.
+type DiffType string
+
+const (
+	// DiffAdded indicates the resource is only in the second state.
+	DiffAdded DiffType = "added"
+	// DiffRemoved indicates the resource is only in the first state.
+	DiffRemoved DiffType = "removed"
+	// DiffChanged indicates the resource is in both states but its attributes
+	// differ.
+	DiffChanged DiffType = "changed"
+)
+
+// Diff is a comparison of the states of two workspaces.
+type Diff struct {
+	resource.ID
+
+	// From is the ID of the first workspace.
+	From resource.ID
+	// To is the ID of the second workspace.
+	To resource.ID
+	// Resources are the differences, sorted by address. Resources that are
+	// identical in both states are omitted.
+	Resources []ResourceDiff
+}
+
+// ResourceDiff describes how a resource differs between two states.
+type ResourceDiff struct {
+	Address ResourceAddress
+	Type    DiffType
+	// Attributes are the names of the attributes that differ, sorted
+	// alphabetically. Only populated for a changed resource.
+	Attributes []string
+}
+
+func (d *Diff) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Any("from", d.From),
+		slog.Any("to", d.To),
+		slog.Int("differences", len(d.Resources)),
+	)
+}
+
+// Diff compares the states of two workspaces. An error is returned if either
+// workspace has no state.
+func (s *Service) Diff(from, to resource.ID) (*Diff, error) {
+	fromState, err := s.getForDiff(from)
+	if err != nil {
+		return nil, err
+	}
+	toState, err := s.getForDiff(to)
+	if err != nil {
+		return nil, err
+	}
+	diff := &Diff{
+		ID:        resource.NewID(resource.StateDiff),
+		From:      from,
+		To:        to,
+		Resources: diffStates(fromState, toState),
+	}
+	s.diffs.Add(diff.ID, diff)
+	s.evictDiffs(diff.ID)
+	s.logger.Debug("compared states", "diff", diff)
+	return diff, nil
+}
+
+// GetDiff retrieves a comparison of two workspaces' states.
+func (s *Service) GetDiff(id resource.ID) (*Diff, error) {
+	return s.diffs.Get(id)
+}
+
+// evictDiffs records the addition of a comparison, removing the oldest
+// comparisons once there are more than the maximum.
+func (s *Service) evictDiffs(added resource.ID) {
+	s.diffsMu.Lock()
+	defer s.diffsMu.Unlock()
+
+	s.retainedDiffs = append(s.retainedDiffs, added)
+	for len(s.retainedDiffs) > maxDiffs {
+		s.diffs.Evict(s.retainedDiffs[0])
+		s.retainedDiffs = s.retainedDiffs[1:]
+	}
+}
+
+func (s *Service) getForDiff(workspaceID resource.ID) (*State, error) {
+	ws, err := s.workspaces.Get(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	state, err := s.cache.Get(workspaceID)
+	if errors.Is(err, resource.ErrNotFound) {
+		return nil, fmt.Errorf("workspace %s has no state: check its module has been initialized", ws)
+	} else if err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func diffStates(from, to *State) []ResourceDiff {
+	var diffs []ResourceDiff
+	for addr, fromRes := range from.Resources {
+		toRes, ok := to.Resources[addr]
+		if !ok {
+			diffs = append(diffs, ResourceDiff{Address: addr, Type: DiffRemoved})
+			continue
+		}
+		if attrs := diffAttributes(fromRes.Attributes, toRes.Attributes); len(attrs) > 0 {
+			diffs = append(diffs, ResourceDiff{Address: addr, Type: DiffChanged, Attributes: attrs})
+		}
+	}
+	for addr := range to.Resources {
+		if _, ok := from.Resources[addr]; !ok {
+			diffs = append(diffs, ResourceDiff{Address: addr, Type: DiffAdded})
+		}
+	}
+	slices.SortFunc(diffs, func(i, j ResourceDiff) int {
+		if i.Address < j.Address {
+			return -1
+		}
+		return 1
+	})
+	return diffs
+}
+
+// diffAttributes returns the sorted names of attributes that differ between
+// two sets of attributes.
+func diffAttributes(from, to map[string]any) []string {
+	names := make(map[string]struct{})
+	for name, v := range from {
+		if other, ok := to[name]; !ok || !reflect.DeepEqual(v, other) {
+			names[name] = struct{}{}
+		}
+	}
+	for name := range to {
+		if _, ok := from[name]; !ok {
+			names[name] = struct{}{}
+		}
+	}
+	sorted := maps.Keys(names)
+	slices.Sort(sorted)
+	return sorted
+}
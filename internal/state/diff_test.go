@@ -0,0 +1,66 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/leg100/pug/internal/logging"
+	"github.com/leg100/pug/internal/pubsub"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffStates(t *testing.T) {
+	from := &State{
+		Resources: map[ResourceAddress]*Resource{
+			"random_pet.removed":   {Attributes: map[string]any{"id": "a"}},
+			"random_pet.same":      {Attributes: map[string]any{"id": "a", "length": float64(2)}},
+			"random_pet.changed":   {Attributes: map[string]any{"id": "a", "length": float64(2), "prefix": nil}},
+			"random_integer.moved": {Attributes: map[string]any{"result": float64(7)}},
+		},
+	}
+	to := &State{
+		Resources: map[ResourceAddress]*Resource{
+			"random_pet.added":          {Attributes: map[string]any{"id": "b"}},
+			"random_pet.same":           {Attributes: map[string]any{"id": "a", "length": float64(2)}},
+			"random_pet.changed":        {Attributes: map[string]any{"id": "b", "length": float64(2), "separator": "-"}},
+			"module.a.random_integer.x": {Attributes: map[string]any{"result": float64(7)}},
+		},
+	}
+
+	got := diffStates(from, to)
+
+	want := []ResourceDiff{
+		{Address: "module.a.random_integer.x", Type: DiffAdded},
+		{Address: "random_integer.moved", Type: DiffRemoved},
+		{Address: "random_pet.added", Type: DiffAdded},
+		{Address: "random_pet.changed", Type: DiffChanged, Attributes: []string{"id", "prefix", "separator"}},
+		{Address: "random_pet.removed", Type: DiffRemoved},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestDiffStates_Identical(t *testing.T) {
+	state := &State{
+		Resources: map[ResourceAddress]*Resource{
+			"random_pet.pet": {Attributes: map[string]any{"id": "a"}},
+		},
+	}
+	assert.Empty(t, diffStates(state, state))
+	assert.Empty(t, diffStates(&State{}, &State{}))
+}
+
+func TestService_EvictDiffs(t *testing.T) {
+	svc := &Service{diffs: resource.NewTable(pubsub.NewBroker[*Diff](logging.Discard))}
+
+	ids := make([]resource.ID, maxDiffs+1)
+	for i := range ids {
+		ids[i] = resource.NewID(resource.StateDiff)
+		svc.diffs.Add(ids[i], &Diff{ID: ids[i]})
+		svc.evictDiffs(ids[i])
+	}
+
+	// The oldest comparison is removed to make way for the newest.
+	_, err := svc.GetDiff(ids[0])
+	assert.ErrorIs(t, err, resource.ErrNotFound)
+	assert.Len(t, svc.diffs.List(), maxDiffs)
+}
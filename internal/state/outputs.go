@@ -0,0 +1,85 @@
+package state
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/task"
+)
+
+// Output is a terraform output.
+type Output struct {
+	resource.ID
+
+	Name string
+	// Value is the JSON encoding of the output's value.
+	Value     string
+	Sensitive bool
+}
+
+func (o Output) String() string {
+	return o.Name
+}
+
+// Outputs summarises the outputs retrieved by an output task.
+type Outputs []Output
+
+func (o Outputs) String() string {
+	return fmt.Sprintf("%d outputs", len(o))
+}
+
+// Outputs creates a task to retrieve the outputs of a workspace. Once the task
+// has finished, the outputs are available from its summary.
+func (s *Service) Outputs(workspaceID resource.ID) (task.Spec, error) {
+	return s.createTaskSpec(workspaceID, task.Spec{
+		Execution: task.Execution{
+			TerraformCommand: []string{"output"},
+			Args:             []string{"-json"},
+		},
+		JSON: true,
+		BeforeExited: func(t *task.Task) (task.Summary, error) {
+			outputs, err := parseOutputs(t.NewReader(false))
+			if err != nil {
+				return nil, err
+			}
+			return outputs, nil
+		},
+	})
+}
+
+// parseOutputs parses the output of `terraform output -json`, returning the
+// outputs sorted by name.
+func parseOutputs(r io.Reader) (Outputs, error) {
+	var raw map[string]struct {
+		Sensitive bool            `json:"sensitive"`
+		Value     json.RawMessage `json:"value"`
+	}
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		if err == io.EOF {
+			return Outputs{}, nil
+		}
+		return nil, fmt.Errorf("parsing outputs: %w", err)
+	}
+	outputs := make(Outputs, 0, len(raw))
+	for name, out := range raw {
+		var value bytes.Buffer
+		if err := json.Compact(&value, out.Value); err != nil {
+			return nil, fmt.Errorf("parsing output %s: %w", name, err)
+		}
+		outputs = append(outputs, Output{
+			ID:        resource.NewID(resource.Output),
+			Name:      name,
+			Value:     value.String(),
+			Sensitive: out.Sensitive,
+		})
+	}
+	slices.SortFunc(outputs, func(a, b Output) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return outputs, nil
+}
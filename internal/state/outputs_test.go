@@ -0,0 +1,40 @@
+package state
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOutputs(t *testing.T) {
+	t.Run("outputs", func(t *testing.T) {
+		got, err := parseOutputs(strings.NewReader(`{
+  "password": {"sensitive": true, "type": "string", "value": "secret"},
+  "ids": {"sensitive": false, "type": ["list", "number"], "value": [1, 2]},
+  "name": {"sensitive": false, "type": "string", "value": "web"}
+}`))
+		require.NoError(t, err)
+
+		require.Len(t, got, 3)
+		assert.Equal(t, "ids", got[0].Name)
+		assert.Equal(t, "[1,2]", got[0].Value)
+		assert.False(t, got[0].Sensitive)
+		assert.Equal(t, "name", got[1].Name)
+		assert.Equal(t, `"web"`, got[1].Value)
+		assert.Equal(t, "password", got[2].Name)
+		assert.True(t, got[2].Sensitive)
+	})
+
+	t.Run("no outputs", func(t *testing.T) {
+		got, err := parseOutputs(strings.NewReader("{}\n"))
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := parseOutputs(strings.NewReader("not json"))
+		assert.Error(t, err)
+	})
+}
@@ -1,6 +1,8 @@
 package state
 
 import (
+	"sync"
+
 	"github.com/leg100/pug/internal/logging"
 	"github.com/leg100/pug/internal/module"
 	"github.com/leg100/pug/internal/pubsub"
@@ -17,6 +19,12 @@ type Service struct {
 
 	// Table mapping workspace IDs to states
 	cache *resource.Table[*State]
+	// Table of comparisons of states
+	diffs *resource.Table[*Diff]
+	// retainedDiffs are the IDs of the comparisons kept in the table, oldest
+	// first.
+	retainedDiffs []resource.ID
+	diffsMu       sync.Mutex
 
 	*pubsub.Broker[*State]
 	*reloader
@@ -36,6 +44,7 @@ func NewService(opts ServiceOptions) *Service {
 		workspaces: opts.Workspaces,
 		tasks:      opts.Tasks,
 		cache:      resource.NewTable(broker),
+		diffs:      resource.NewTable(pubsub.NewBroker[*Diff](opts.Logger)),
 		Broker:     broker,
 		logger:     opts.Logger,
 	}
@@ -124,7 +124,7 @@ func (e *enqueuer) enqueueDependentTask(t *Task) bool {
 		switch dependency.State {
 		case Exited:
 			// Is enqueuable if all dependencies have exited successfully.
-		case Canceled, Errored:
+		case Canceled, Errored, Discarded:
 			// Dependency failed so mark task as failed too by cancelling it
 			// along with a reason why it was canceled.
 			t.stdout.Write([]byte("task dependency failed"))
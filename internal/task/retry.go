@@ -0,0 +1,88 @@
+package task
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+)
+
+// DefaultRetryPatterns are regular expressions matching the output of tasks
+// that have failed for transient reasons, e.g. provider rate limits and
+// network errors.
+var DefaultRetryPatterns = []string{
+	`(?i)rate ?exceeded`,
+	`(?i)throttl(ed|ing)`,
+	`(?i)too many requests`,
+	`(?i)connection reset by peer`,
+	`(?i)i/o timeout`,
+	`(?i)TLS handshake timeout`,
+	`(?i)temporary failure in name resolution`,
+	`(?i)503 Service Unavailable`,
+}
+
+// RetryOptions configures the automatic retry of tasks that fail for
+// transient reasons.
+type RetryOptions struct {
+	// MaxAttempts is the maximum number of times a task is attempted,
+	// including the first attempt. Retries are disabled if less than two.
+	MaxAttempts int
+	// Backoff is the delay before the first retry. The delay doubles with
+	// each subsequent retry.
+	Backoff time.Duration
+	// Patterns match the output of tasks that have failed for transient
+	// reasons. Tasks failing with output that matches none of the patterns
+	// are not retried.
+	Patterns []*regexp.Regexp
+}
+
+// CompileRetryPatterns compiles regular expressions for use in RetryOptions.
+func CompileRetryPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, len(patterns))
+	for i, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("compiling retry pattern: %w", err)
+		}
+		compiled[i] = re
+	}
+	return compiled, nil
+}
+
+// backoff returns the delay before retrying a task that failed on the given
+// attempt.
+func (o RetryOptions) backoff(attempt int) time.Duration {
+	return o.Backoff << (attempt - 1)
+}
+
+// transient determines whether task output indicates a transient failure.
+func (o RetryOptions) transient(output []byte) bool {
+	for _, re := range o.Patterns {
+		if re.Match(output) {
+			return true
+		}
+	}
+	return false
+}
+
+// retry determines whether a finished task should be retried, returning the
+// spec for the next attempt and the delay before it should be created.
+func (o RetryOptions) retry(t *Task) (Spec, time.Duration, bool) {
+	if t.State != Errored {
+		// Only failed tasks are retried; canceled tasks are not.
+		return Spec{}, 0, false
+	}
+	if t.Attempt >= o.MaxAttempts {
+		return Spec{}, 0, false
+	}
+	output, err := io.ReadAll(t.NewReader(true))
+	if err != nil || !o.transient(output) {
+		return Spec{}, 0, false
+	}
+	spec := t.Spec
+	spec.attempt = t.Attempt + 1
+	// The first attempt may have been waited upon, but subsequent attempts
+	// cannot be.
+	spec.Wait = false
+	return spec, o.backoff(t.Attempt), true
+}
@@ -0,0 +1,129 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leg100/pug/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryOptions_retry(t *testing.T) {
+	t.Parallel()
+
+	patterns, err := CompileRetryPatterns(DefaultRetryPatterns)
+	require.NoError(t, err)
+	opts := RetryOptions{
+		MaxAttempts: 3,
+		Backoff:     time.Second,
+		Patterns:    patterns,
+	}
+
+	tests := []struct {
+		name        string
+		state       Status
+		attempt     int
+		output      string
+		wantRetry   bool
+		wantAttempt int
+		wantBackoff time.Duration
+	}{
+		{
+			name:        "transient error on first attempt",
+			state:       Errored,
+			attempt:     1,
+			output:      "Error: ThrottlingException: Rate exceeded",
+			wantRetry:   true,
+			wantAttempt: 2,
+			wantBackoff: time.Second,
+		},
+		{
+			name:        "transient error on second attempt",
+			state:       Errored,
+			attempt:     2,
+			output:      "dial tcp: lookup registry.terraform.io: i/o timeout",
+			wantRetry:   true,
+			wantAttempt: 3,
+			wantBackoff: 2 * time.Second,
+		},
+		{
+			name:    "transient error on last attempt",
+			state:   Errored,
+			attempt: 3,
+			output:  "Error: Too Many Requests",
+		},
+		{
+			name:    "syntax error",
+			state:   Errored,
+			attempt: 1,
+			output:  "Error: Argument or block definition required",
+		},
+		{
+			name:    "canceled",
+			state:   Canceled,
+			attempt: 1,
+			output:  "Error: ThrottlingException: Rate exceeded",
+		},
+		{
+			name:    "exited",
+			state:   Exited,
+			attempt: 1,
+			output:  "Rate exceeded, but succeeded anyway",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := factory{counter: internal.Int(0)}
+			task, err := f.newTask(Spec{
+				Execution: Execution{TerraformCommand: []string{"plan"}},
+				attempt:   tt.attempt,
+				Wait:      true,
+			})
+			require.NoError(t, err)
+			task.State = tt.state
+			_, err = task.combined.Write([]byte(tt.output))
+			require.NoError(t, err)
+
+			spec, backoff, ok := opts.retry(task)
+			require.Equal(t, tt.wantRetry, ok)
+			if !ok {
+				return
+			}
+			assert.Equal(t, tt.wantAttempt, spec.attempt)
+			assert.Equal(t, tt.wantBackoff, backoff)
+			assert.False(t, spec.Wait)
+
+			// The next attempt is distinguished from previous attempts.
+			next, err := f.newTask(spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAttempt, next.Attempt)
+			assert.Contains(t, next.Description, "(attempt")
+		})
+	}
+}
+
+func TestRetryOptions_disabled(t *testing.T) {
+	t.Parallel()
+
+	patterns, err := CompileRetryPatterns(DefaultRetryPatterns)
+	require.NoError(t, err)
+	opts := RetryOptions{MaxAttempts: 1, Patterns: patterns}
+
+	f := factory{counter: internal.Int(0)}
+	task, err := f.newTask(Spec{})
+	require.NoError(t, err)
+	task.State = Errored
+	_, err = task.combined.Write([]byte("Rate exceeded"))
+	require.NoError(t, err)
+
+	_, _, ok := opts.retry(task)
+	assert.False(t, ok)
+}
+
+func TestCompileRetryPatterns_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := CompileRetryPatterns([]string{"(unclosed"})
+	assert.Error(t, err)
+}
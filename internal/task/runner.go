@@ -20,15 +20,24 @@ type runner struct {
 func StartRunner(ctx context.Context, logger logging.Interface, tasks *Service, maxTasks int) func() {
 	sub := tasks.TaskBroker.Subscribe(context.Background())
 	r := &runner{
-		max:   maxTasks,
 		tasks: tasks,
 	}
+	tasks.SetMaxTasks(maxTasks)
 	g := sync.WaitGroup{}
 
-	// On each task event, get a list of tasks to be run, start them, and wait
-	// for them to complete in the background.
+	// On each task event, and whenever the maximum number of tasks is changed,
+	// get a list of tasks to be run, start them, and wait for them to complete
+	// in the background.
 	go func() {
-		for range sub {
+		for {
+			select {
+			case _, ok := <-sub:
+				if !ok {
+					return
+				}
+			case <-tasks.maxTasksChanged:
+			}
+			r.max = tasks.MaxTasks()
 			for _, task := range r.runnable() {
 				waitfn, err := task.start(ctx)
 				if err != nil {
@@ -1,7 +1,10 @@
 package task
 
 import (
+	"errors"
 	"slices"
+	"sync/atomic"
+	"time"
 
 	"github.com/leg100/pug/internal"
 	"github.com/leg100/pug/internal/logging"
@@ -14,6 +17,14 @@ type Service struct {
 	groups  *resource.Table[*Group]
 	counter *int
 	logger  logging.Interface
+	// failed is the number of tasks that have errored or been canceled.
+	failed atomic.Int64
+	retry  RetryOptions
+	// maxTasks is the maximum number of tasks permitted to run concurrently.
+	maxTasks atomic.Int64
+	// maxTasksChanged is sent a value whenever maxTasks is changed, so that
+	// the runner can start further tasks if the limit has been raised.
+	maxTasksChanged chan struct{}
 
 	TaskBroker  *pubsub.Broker[*Task]
 	GroupBroker *pubsub.Broker[*Group]
@@ -27,6 +38,7 @@ type ServiceOptions struct {
 	UserEnvs   []string
 	UserArgs   []string
 	Terragrunt bool
+	Retry      RetryOptions
 }
 
 func NewService(opts ServiceOptions) *Service {
@@ -53,7 +65,30 @@ func NewService(opts ServiceOptions) *Service {
 		factory:     factory,
 		counter:     &counter,
 		logger:      opts.Logger,
+		retry:       opts.Retry,
+
+		maxTasksChanged: make(chan struct{}, 1),
+	}
+}
+
+// MaxTasks returns the maximum number of tasks permitted to run concurrently.
+func (s *Service) MaxTasks() int {
+	return int(s.maxTasks.Load())
+}
+
+// SetMaxTasks sets the maximum number of tasks permitted to run concurrently.
+// The maximum cannot be less than one. Lowering the maximum does not affect
+// tasks already running. The new maximum is returned.
+func (s *Service) SetMaxTasks(n int) int {
+	n = max(1, n)
+	s.maxTasks.Store(int64(n))
+	// Notify runner without blocking; a notification already pending is
+	// sufficient.
+	select {
+	case s.maxTasksChanged <- struct{}{}:
+	default:
 	}
+	return n
 }
 
 // Create a task. The task is placed into a pending state and requires enqueuing
@@ -78,9 +113,14 @@ func (s *Service) Create(spec Spec) (*Task, error) {
 	wait := make(chan error, 1)
 	go func() {
 		err := task.Wait()
+		switch task.State {
+		case Errored, Canceled:
+			s.failed.Add(1)
+		}
 		wait <- err
 		if err != nil {
 			s.logger.Error("task failed", "error", err, "task", task)
+			s.retryAfterFailure(task)
 			return
 		}
 		s.logger.Info("completed task", "task", task)
@@ -91,6 +131,21 @@ func (s *Service) Create(spec Spec) (*Task, error) {
 	return task, nil
 }
 
+// retryAfterFailure creates another attempt at a failed task, after a delay,
+// if the task failed for a transient reason.
+func (s *Service) retryAfterFailure(task *Task) {
+	spec, backoff, ok := s.retry.retry(task)
+	if !ok {
+		return
+	}
+	s.logger.Info("retrying task", "task", task, "attempt", spec.attempt, "backoff", backoff)
+	time.AfterFunc(backoff, func() {
+		if _, err := s.Create(spec); err != nil {
+			s.logger.Error("retrying task", "error", err, "task", task)
+		}
+	})
+}
+
 // Create a task group from one or more task specs. An error is returned if zero
 // specs are provided, or if it fails to create at least one task.
 func (s *Service) CreateGroup(specs ...Spec) (*Group, error) {
@@ -207,6 +262,11 @@ func (s *Service) Cancel(taskID resource.ID) (*Task, error) {
 		}
 		return task, task.cancel()
 	}()
+	if errors.Is(err, ErrFinished) {
+		// Canceling a finished task is a no-op.
+		s.logger.Debug("skipped canceling finished task", "task", task)
+		return task, err
+	}
 	if err != nil {
 		s.logger.Error("canceling task", "id", taskID, "error", err)
 		return nil, err
@@ -216,6 +276,26 @@ func (s *Service) Cancel(taskID resource.ID) (*Task, error) {
 	return task, nil
 }
 
+// Discard drops a task that is yet to start running, freeing its place in the
+// queue. A task that has started running cannot be discarded, and must be
+// canceled instead.
+func (s *Service) Discard(taskID resource.ID) (*Task, error) {
+	task, err := s.tasks.Get(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := task.discard(); errors.Is(err, ErrFinished) {
+		// Discarding a finished task is a no-op.
+		s.logger.Debug("skipped discarding finished task", "task", task)
+		return task, err
+	} else if err != nil {
+		s.logger.Error("discarding task", "task", task, "error", err)
+		return task, err
+	}
+	s.logger.Info("discarded task", "task", task)
+	return task, nil
+}
+
 func (s *Service) Delete(taskID resource.ID) error {
 	// TODO: only allow deleting task if in finished state (error message should
 	// instruct user to cancel task first).
@@ -226,3 +306,8 @@ func (s *Service) Delete(taskID resource.ID) error {
 func (s *Service) Counter() int {
 	return *s.counter
 }
+
+// Failed returns the number of tasks that have errored or been canceled.
+func (s *Service) Failed() int {
+	return int(s.failed.Load())
+}
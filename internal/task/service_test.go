@@ -3,6 +3,7 @@ package task
 import (
 	"testing"
 
+	"github.com/leg100/pug/internal/logging"
 	"github.com/leg100/pug/internal/resource"
 	"github.com/stretchr/testify/assert"
 )
@@ -75,3 +76,20 @@ func TestService_List(t *testing.T) {
 		})
 	}
 }
+
+func TestService_SetMaxTasks(t *testing.T) {
+	svc := NewService(ServiceOptions{Logger: logging.Discard})
+
+	assert.Equal(t, 3, svc.SetMaxTasks(3))
+	assert.Equal(t, 3, svc.MaxTasks())
+
+	// Runner is notified of the change.
+	assert.Len(t, svc.maxTasksChanged, 1)
+
+	// Maximum cannot be less than one.
+	assert.Equal(t, 1, svc.SetMaxTasks(0))
+	assert.Equal(t, 1, svc.MaxTasks())
+
+	// A pending notification suffices.
+	assert.Len(t, svc.maxTasksChanged, 1)
+}
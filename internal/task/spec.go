@@ -15,12 +15,23 @@ type Spec struct {
 	// AdditionalExecution specifies the execution of another program. The
 	// program is only executed if the first program exits successfully.
 	AdditionalExecution *Execution
+	// PreExecutions specify programs to execute in order before the task's
+	// program, e.g. hooks. Each must exit successfully before the next program
+	// is executed. Their output is written to the combined stream but not to
+	// stdout, which is reserved for the task's program.
+	PreExecutions []Execution
 	// Identifier uniquely identifies the type of task.
 	Identifier Identifier
 	// Path relative to the pug working directory in which to run the command.
 	Path string
 	// Environment variables.
 	Env []string
+	// OverrideEnv are environment variables that take precedence over those
+	// inherited from pug's environment.
+	OverrideEnv []string
+	// RedactedArgs maps args with sensitive values to the form in which they
+	// are shown and recorded instead, e.g. in the task's info and in logs.
+	RedactedArgs map[string]string
 	// A blocking task blocks other tasks from running on the module or
 	// workspace.
 	Blocking bool
@@ -43,7 +54,8 @@ type Spec struct {
 	AfterExited func(*Task)
 	// Call this function after the task is enqueued.
 	AfterQueued func(*Task)
-	// Call this function after the task starts running.
+	// Call this function after the task starts running, or if there are
+	// pre-executions, just before the task's program starts.
 	AfterRunning func(*Task)
 	// Call this function after the task fails with an error
 	AfterError func(*Task)
@@ -62,6 +74,9 @@ type Spec struct {
 	// task can be enqueued. If any of the other tasks are canceled or error
 	// then the task will be canceled.
 	dependsOn []resource.ID
+	// attempt is the number of the attempt at carrying out the task, starting
+	// at one. Set when a task is automatically retried.
+	attempt int
 }
 
 // SpecFunc is a function that creates a spec.
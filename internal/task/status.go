@@ -12,14 +12,17 @@ const (
 	Exited   Status = "exited"
 	Errored  Status = "errored"
 	Canceled Status = "canceled"
+	// Discarded is the state of a task that was dropped before it started
+	// running.
+	Discarded Status = "discarded"
 
-	MaxStatusLen = len(Canceled)
+	MaxStatusLen = len(Discarded)
 )
 
 // IsFinal returns true if the state is a final state.
 func (s Status) IsFinal() bool {
 	switch s {
-	case Errored, Exited, Canceled:
+	case Errored, Exited, Canceled, Discarded:
 		return true
 	default:
 		return false
@@ -9,6 +9,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -36,14 +37,24 @@ type Task struct {
 	JSON                bool
 	Immediate           bool
 	AdditionalEnv       []string
+	OverrideEnv         []string
 	DependsOn           []resource.ID
+	// PreExecutions are executed before the task's program.
+	PreExecutions []Execution
 	// Summary summarises the outcome of a task to the end-user.
 	Summary     Summary
 	Description string
+	// Attempt is the number of the attempt at carrying out the task, starting
+	// at one. Greater than one if the task is an automatic retry of a failed
+	// task.
+	Attempt int
 
 	exclusive bool
 	// terragrunt is true if terragrunt is in use.
 	terragrunt bool
+	// redactedArgs maps args with sensitive values to the form in which they
+	// are shown and recorded instead.
+	redactedArgs map[string]string
 
 	// Nil until task has started
 	proc *os.Process
@@ -64,6 +75,9 @@ type Task struct {
 
 	// this channel is closed once the task is finished
 	finished chan struct{}
+	// finishedAt is the time at which the task finished. It is set before
+	// finished is closed and is not written thereafter.
+	finishedAt time.Time
 
 	// timestamps records the time at which the task transitioned into a status
 	// and out of a status.
@@ -124,12 +138,15 @@ func (f *factory) newTask(spec Spec) (*Task, error) {
 		Path:                filepath.Join(f.workdir.String(), spec.Path),
 		AdditionalExecution: spec.AdditionalExecution,
 		AdditionalEnv:       append(f.userEnvs, spec.Env...),
+		OverrideEnv:         spec.OverrideEnv,
 		JSON:                spec.JSON,
 		Blocking:            spec.Blocking,
 		DependsOn:           spec.dependsOn,
 		Immediate:           spec.Immediate,
 		exclusive:           spec.Exclusive,
 		Description:         spec.Description,
+		redactedArgs:        spec.RedactedArgs,
+		Attempt:             max(spec.attempt, 1),
 		Spec:                spec,
 		AfterCreate:         spec.AfterCreate,
 		AfterRunning:        spec.AfterRunning,
@@ -169,6 +186,17 @@ func (f *factory) newTask(spec Spec) (*Task, error) {
 	task.Args = append(task.Args, f.userArgs...)
 	task.Args = append(task.Args, spec.Execution.Args...)
 
+	for _, pre := range spec.PreExecutions {
+		if pre.Program == "" {
+			// Is terraform execution
+			pre = Execution{
+				Program: f.program,
+				Args:    append(slices.Clone(pre.TerraformCommand), pre.Args...),
+			}
+		}
+		task.PreExecutions = append(task.PreExecutions, pre)
+	}
+
 	// If description is not explicitly set then set it using provided terraform
 	// commands or - if this is not a terraform execution - then using the
 	// provided program.
@@ -179,6 +207,9 @@ func (f *factory) newTask(spec Spec) (*Task, error) {
 			task.Description = spec.Execution.Program
 		}
 	}
+	if task.Attempt > 1 {
+		task.Description = fmt.Sprintf("%s (attempt %d)", task.Description, task.Attempt)
+	}
 
 	// In terragrunt mode add default terragrunt flags
 	//
@@ -194,6 +225,28 @@ func (t *Task) String() string {
 	return t.Description
 }
 
+// RedactedArgs returns the task's args with those with sensitive values
+// redacted, for showing to the user or recording.
+func (t *Task) RedactedArgs() []string {
+	if len(t.redactedArgs) == 0 {
+		return t.Args
+	}
+	args := make([]string, len(t.Args))
+	for i, arg := range t.Args {
+		if redacted, ok := t.redactedArgs[arg]; ok {
+			arg = redacted
+		}
+		args[i] = arg
+	}
+	return args
+}
+
+// GetStatus retrieves the state of the task, allowing events to record the
+// state at the time they are published.
+func (t *Task) GetStatus() string {
+	return string(t.State)
+}
+
 // NewReader returns a reader which contains what has been written thus far to
 // the task buffer.
 // Set combined to true to receieve stderr as well as stdout.
@@ -228,6 +281,17 @@ func (t *Task) Elapsed(s Status) time.Duration {
 	return st.Elapsed()
 }
 
+// Duration returns the length of time since the task was created. Once the
+// task has finished, the duration is fixed at the time at which it finished.
+func (t *Task) Duration(now time.Time) time.Duration {
+	select {
+	case <-t.finished:
+		return t.finishedAt.Sub(t.Created)
+	default:
+		return now.Sub(t.Created)
+	}
+}
+
 // Wait for task to complete successfully. If the task completes unsuccessfully
 // then the returned error is non-nil.
 func (t *Task) Wait() error {
@@ -242,7 +306,7 @@ func (t *Task) LogValue() slog.Value {
 	attrs := []slog.Attr{
 		slog.String("id", t.ID.String()),
 		slog.Any("program", t.Program),
-		slog.Any("args", t.Args),
+		slog.Any("args", t.RedactedArgs()),
 	}
 	if t.terragrunt {
 		attrs = append(attrs, slog.Any("deps", t.DependsOn))
@@ -265,6 +329,9 @@ func (t *Task) LogValue() slog.Value {
 	return slog.GroupValue(attrs...)
 }
 
+// ErrFinished is returned when canceling a task that has already finished.
+var ErrFinished = errors.New("task has already finished")
+
 // cancel the task - if it is queued it'll skip the running state and enter the
 // exited state
 func (t *Task) cancel() error {
@@ -274,8 +341,8 @@ func (t *Task) cancel() error {
 	defer t.mu.Unlock()
 
 	switch t.State {
-	case Exited, Errored, Canceled:
-		return errors.New("task has already finished")
+	case Exited, Errored, Canceled, Discarded:
+		return ErrFinished
 	case Pending, Queued:
 		t.updateState(Canceled)
 		return nil
@@ -284,8 +351,37 @@ func (t *Task) cancel() error {
 	}
 }
 
+// ErrStarted is returned when discarding a task that has already started
+// running.
+var ErrStarted = errors.New("task has already started; cancel it instead")
+
+// discard drops a task that has yet to start running.
+func (t *Task) discard() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch t.State {
+	case Exited, Errored, Canceled, Discarded:
+		return ErrFinished
+	case Pending, Queued:
+		t.updateState(Discarded)
+		return nil
+	default: // running
+		return ErrStarted
+	}
+}
+
 func (t *Task) start(ctx context.Context) (func(), error) {
-	cmd := t.execute(ctx, t.Program, t.Args)
+	// Pre-executions are executed in order before the task's program.
+	cmds := make([]*exec.Cmd, 0, len(t.PreExecutions)+1)
+	for _, pre := range t.PreExecutions {
+		cmd := t.execute(ctx, pre.Program, pre.Args)
+		// Keep stdout for the task's program, whose output may be parsed.
+		cmd.Stdout = t.combined
+		cmds = append(cmds, cmd)
+	}
+	cmds = append(cmds, t.execute(ctx, t.Program, t.Args))
+	cmd := cmds[0]
 
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -305,7 +401,16 @@ func (t *Task) start(ctx context.Context) (func(), error) {
 
 	wait := func() {
 		state := Exited
-		if err := cmd.Wait(); err != nil {
+		err := cmd.Wait()
+		for i, next := range cmds[1:] {
+			if err != nil {
+				break
+			}
+			if err = t.startNext(next, i == len(cmds)-2); err == nil {
+				err = next.Wait()
+			}
+		}
+		if err != nil {
 			state = Errored
 			t.Err = fmt.Errorf("task failed: %w", err)
 		} else if t.AdditionalExecution != nil {
@@ -324,6 +429,24 @@ func (t *Task) start(ctx context.Context) (func(), error) {
 	return wait, nil
 }
 
+// startNext starts the next program of a running task once the previous
+// program has exited successfully. If it is the task's own program, i.e. the
+// last, then AfterRunning is first called.
+func (t *Task) startNext(cmd *exec.Cmd, last bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if last && t.AfterRunning != nil {
+		t.AfterRunning(t)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	// save reference to process so that it can be cancelled via cancel()
+	t.proc = cmd.Process
+	return nil
+}
+
 func (t *Task) execute(ctx context.Context, program string, args []string) *exec.Cmd {
 	// Use the provided context to kill the program if the context becomes done,
 	// but also to prevent the program from starting if the context becomes done.
@@ -336,6 +459,8 @@ func (t *Task) execute(ctx context.Context, program string, args []string) *exec
 	cmd.Stdout = io.MultiWriter(t.stdout, t.combined)
 	cmd.Stderr = t.combined
 	cmd.Env = append(t.AdditionalEnv, os.Environ()...)
+	// Where a variable is set more than once the last value is used.
+	cmd.Env = append(cmd.Env, t.OverrideEnv...)
 	return cmd
 }
 
@@ -381,6 +506,7 @@ func (t *Task) updateState(state Status) {
 
 	if t.State.IsFinal() {
 		t.recordStatusEndTime(now)
+		t.finishedAt = now
 		close(t.finished)
 		if t.afterFinish != nil {
 			t.afterFinish(t)
@@ -396,7 +522,9 @@ func (t *Task) updateState(state Status) {
 			t.AfterQueued(t)
 		}
 	case Running:
-		if t.AfterRunning != nil {
+		// If there are pre-executions then AfterRunning is called once they
+		// have finished.
+		if t.AfterRunning != nil && len(t.PreExecutions) == 0 {
 			t.AfterRunning(t)
 		}
 	case Canceled:
@@ -3,7 +3,9 @@ package task
 import (
 	"context"
 	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/leg100/pug/internal"
 	"github.com/stretchr/testify/assert"
@@ -45,6 +47,62 @@ func TestTask_NewReader(t *testing.T) {
 	assert.Contains(t, string(got), "err")
 }
 
+func TestTask_PreExecutions(t *testing.T) {
+	t.Parallel()
+
+	f := factory{
+		counter:   internal.Int(0),
+		program:   "./testdata/task",
+		publisher: &fakePublisher[*Task]{},
+	}
+
+	t.Run("output of pre-executions only written to combined stream", func(t *testing.T) {
+		var running int
+		task, err := f.newTask(Spec{
+			PreExecutions: []Execution{
+				{Program: "echo", Args: []string{"hook"}},
+			},
+			AfterRunning: func(*Task) { running++ },
+		})
+		require.NoError(t, err)
+		task.updateState(Queued)
+		waitfn, err := task.start(context.Background())
+		require.NoError(t, err)
+		waitfn()
+
+		assert.Equal(t, Exited, task.State)
+		assert.Equal(t, 1, running)
+
+		got, err := io.ReadAll(task.NewReader(false))
+		require.NoError(t, err)
+		assert.Equal(t, "foo\nbar\nbaz\nbye\n", string(got))
+
+		got, err = io.ReadAll(task.NewReader(true))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(got), "hook\n"))
+	})
+
+	t.Run("failed pre-execution skips program", func(t *testing.T) {
+		var running int
+		task, err := f.newTask(Spec{
+			PreExecutions: []Execution{{Program: "false"}},
+			AfterRunning:  func(*Task) { running++ },
+		})
+		require.NoError(t, err)
+		task.updateState(Queued)
+		waitfn, err := task.start(context.Background())
+		require.NoError(t, err)
+		waitfn()
+
+		assert.Equal(t, Errored, task.State)
+		assert.Equal(t, 0, running)
+
+		got, err := io.ReadAll(task.NewReader(false))
+		require.NoError(t, err)
+		assert.Empty(t, string(got))
+	})
+}
+
 func TestTask_cancel(t *testing.T) {
 	t.Parallel()
 
@@ -73,6 +131,32 @@ func TestTask_cancel(t *testing.T) {
 	assert.Equal(t, Exited, task.State)
 }
 
+func TestTask_cancelPending(t *testing.T) {
+	f := factory{
+		counter:   internal.Int(0),
+		publisher: &fakePublisher[*Task]{},
+	}
+	task, err := f.newTask(Spec{})
+	require.NoError(t, err)
+
+	require.NoError(t, task.cancel())
+	assert.Equal(t, Canceled, task.State)
+}
+
+func TestTask_cancelFinished(t *testing.T) {
+	f := factory{
+		counter:   internal.Int(0),
+		publisher: &fakePublisher[*Task]{},
+	}
+	task, err := f.newTask(Spec{})
+	require.NoError(t, err)
+	task.updateState(Exited)
+
+	// Canceling a finished task leaves it as it is.
+	assert.ErrorIs(t, task.cancel(), ErrFinished)
+	assert.Equal(t, Exited, task.State)
+}
+
 // func TestTask_WaitFor_immediateExit(t *testing.T) {
 // 	f := factory{program: "../testdata/task"}
 // 	task, err := f.newTask(".")
@@ -106,3 +190,113 @@ func TestTask_cancel(t *testing.T) {
 // 	// verify task exits
 // 	require.True(t, <-got)
 // }
+
+func TestTask_discard(t *testing.T) {
+	f := factory{
+		counter:   internal.Int(0),
+		publisher: &fakePublisher[*Task]{},
+	}
+
+	tests := []struct {
+		name  string
+		state Status
+		err   error
+		want  Status
+	}{
+		{"pending", Pending, nil, Discarded},
+		{"queued", Queued, nil, Discarded},
+		{"running", Running, ErrStarted, Running},
+		{"finished", Exited, ErrFinished, Exited},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, err := f.newTask(Spec{})
+			require.NoError(t, err)
+			task.State = tt.state
+
+			err = task.discard()
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, task.State)
+		})
+	}
+}
+
+func TestTask_Duration(t *testing.T) {
+	f := factory{
+		counter:   internal.Int(0),
+		publisher: &fakePublisher[*Task]{},
+	}
+	task, err := f.newTask(Spec{})
+	require.NoError(t, err)
+
+	// Unfinished task's duration is measured up until now.
+	now := task.Created.Add(time.Minute)
+	assert.Equal(t, time.Minute, task.Duration(now))
+
+	// Finished task's duration is measured up until it finished.
+	task.updateState(Exited)
+	finished := task.Duration(now.Add(time.Hour))
+	assert.Equal(t, task.Updated.Sub(task.Created), finished)
+	assert.Equal(t, finished, task.Duration(now.Add(2*time.Hour)))
+}
+
+func TestTask_Env(t *testing.T) {
+	t.Setenv("PUG_TEST_ENV", "inherited")
+
+	f := factory{
+		counter:   internal.Int(0),
+		publisher: &fakePublisher[*Task]{},
+		userEnvs:  []string{"PUG_TEST_USER=user"},
+	}
+
+	t.Run("inherited takes precedence over additional env", func(t *testing.T) {
+		task, err := f.newTask(Spec{Env: []string{"PUG_TEST_ENV=additional"}})
+		require.NoError(t, err)
+
+		cmd := task.execute(context.Background(), "env", nil)
+		assert.Equal(t, "inherited", lookupEnv(cmd.Environ(), "PUG_TEST_ENV"))
+		assert.Equal(t, "user", lookupEnv(cmd.Environ(), "PUG_TEST_USER"))
+	})
+
+	t.Run("override env takes precedence over inherited", func(t *testing.T) {
+		task, err := f.newTask(Spec{OverrideEnv: []string{"PUG_TEST_ENV=override"}})
+		require.NoError(t, err)
+
+		cmd := task.execute(context.Background(), "env", nil)
+		assert.Equal(t, "override", lookupEnv(cmd.Environ(), "PUG_TEST_ENV"))
+	})
+}
+
+func TestTask_RedactedArgs(t *testing.T) {
+	f := factory{
+		counter:   internal.Int(0),
+		publisher: &fakePublisher[*Task]{},
+	}
+	task, err := f.newTask(Spec{
+		Execution: Execution{
+			TerraformCommand: []string{"plan"},
+			Args:             []string{"-var=region=eu-west-1", "-var=token=secret"},
+		},
+		RedactedArgs: map[string]string{"-var=token=secret": "-var=token=***"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"plan", "-var=region=eu-west-1", "-var=token=secret"}, task.Args)
+	assert.Equal(t, []string{"plan", "-var=region=eu-west-1", "-var=token=***"}, task.RedactedArgs())
+	assert.NotContains(t, task.LogValue().String(), "secret")
+}
+
+// lookupEnv returns the value of the last occurrence of the named variable,
+// which is the value a process is given.
+func lookupEnv(env []string, name string) (value string) {
+	for _, kv := range env {
+		if k, v, ok := strings.Cut(kv, "="); ok && k == name {
+			value = v
+		}
+	}
+	return value
+}
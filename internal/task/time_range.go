@@ -0,0 +1,29 @@
+package task
+
+import "time"
+
+// TimeRange is a window of time. A zero Since or Until leaves the respective
+// end of the window open.
+type TimeRange struct {
+	// Since is the inclusive start of the window.
+	Since time.Time
+	// Until is the exclusive end of the window.
+	Until time.Time
+}
+
+// Contains returns true if t falls within the window.
+func (r TimeRange) Contains(t time.Time) bool {
+	if !r.Since.IsZero() && t.Before(r.Since) {
+		return false
+	}
+	if !r.Until.IsZero() && !t.Before(r.Until) {
+		return false
+	}
+	return true
+}
+
+// IsZero returns true if the window is open at both ends, i.e. it contains all
+// times.
+func (r TimeRange) IsZero() bool {
+	return r.Since.IsZero() && r.Until.IsZero()
+}
@@ -0,0 +1,54 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeRange_Contains(t *testing.T) {
+	now := time.Now()
+	hourAgo := now.Add(-time.Hour)
+
+	before := hourAgo.Add(-time.Nanosecond)
+	within := now.Add(-time.Minute)
+
+	tests := []struct {
+		name string
+		rng  TimeRange
+		want []time.Time
+	}{
+		{
+			"open range",
+			TimeRange{},
+			[]time.Time{before, hourAgo, within, now},
+		},
+		{
+			"since is inclusive",
+			TimeRange{Since: hourAgo},
+			[]time.Time{hourAgo, within, now},
+		},
+		{
+			"until is exclusive",
+			TimeRange{Until: now},
+			[]time.Time{before, hourAgo, within},
+		},
+		{
+			"bounded range",
+			TimeRange{Since: hourAgo, Until: now},
+			[]time.Time{hourAgo, within},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []time.Time
+			for _, ts := range []time.Time{before, hourAgo, within, now} {
+				if tt.rng.Contains(ts) {
+					got = append(got, ts)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
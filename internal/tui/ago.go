@@ -34,3 +34,35 @@ func Ago(now, t time.Time) string {
 	}
 	return fmt.Sprintf("%d%s ago", n, suffix)
 }
+
+// relativeTimeThreshold is the age beyond which RelativeTime renders an
+// absolute time instead.
+const relativeTimeThreshold = 24 * time.Hour
+
+// RelativeTime renders the time relative to now, e.g. "just now" or "3m ago",
+// unless it is older than a day, in which case it is rendered in the given
+// layout.
+func RelativeTime(now, t time.Time, layout string) string {
+	switch diff := now.Sub(t); {
+	case diff < time.Second:
+		return "just now"
+	case diff < relativeTimeThreshold:
+		return Ago(now, t)
+	default:
+		return t.Format(layout)
+	}
+}
+
+// HumanDuration renders a duration to the nearest second, omitting the
+// smaller units once they become insignificant, e.g. 9s, 3m07s, or 2h05m.
+func HumanDuration(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
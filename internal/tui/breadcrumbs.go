@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+)
+
+// ellipsis replaces the breadcrumbs in the middle of breadcrumbs that are too
+// wide to fit.
+var ellipsis = Breadcrumb{Text: Title.Render("…")}
+
+// Breadcrumb is a segment of the title of a page.
+type Breadcrumb struct {
+	// Text is the rendered segment.
+	Text string
+	// Page is the page to navigate to when the segment is clicked. Nil if
+	// the segment is not clickable.
+	Page *Page
+}
+
+// Breadcrumbs make up the title of a page: the name of the page, followed by
+// the resource to which the page belongs and then each of the resource's
+// ancestors, e.g. task, workspace, module.
+type Breadcrumbs []Breadcrumb
+
+// Render renders the breadcrumbs within the given width.
+func (b Breadcrumbs) Render(width int) string {
+	var sb strings.Builder
+	for _, crumb := range b.fit(width) {
+		sb.WriteString(crumb.Text)
+	}
+	return sb.String()
+}
+
+// At returns the breadcrumb at position x of the breadcrumbs rendered within
+// the given width.
+func (b Breadcrumbs) At(x, width int) (Breadcrumb, bool) {
+	var left int
+	for _, crumb := range b.fit(width) {
+		right := left + Width(crumb.Text)
+		if x >= left && x < right {
+			return crumb, true
+		}
+		left = right
+	}
+	return Breadcrumb{}, false
+}
+
+// fit returns the breadcrumbs that fit within the given width. If they are too
+// wide then breadcrumbs in the middle are replaced with an ellipsis, starting
+// with those nearest the end. The first and last breadcrumbs are always
+// retained.
+func (b Breadcrumbs) fit(width int) Breadcrumbs {
+	if b.width() <= width || len(b) <= 2 {
+		return b
+	}
+	last := b[len(b)-1]
+	for i := len(b) - 2; i > 0; i-- {
+		fitted := append(slices.Clone(b[:i]), ellipsis, last)
+		if fitted.width() <= width || i == 1 {
+			return fitted
+		}
+	}
+	return b
+}
+
+func (b Breadcrumbs) width() (width int) {
+	for _, crumb := range b {
+		width += Width(crumb.Text)
+	}
+	return width
+}
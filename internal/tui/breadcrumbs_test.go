@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/leg100/pug/internal"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreadcrumbs(t *testing.T) {
+	taskID := resource.NewID(resource.Task)
+	crumbs := Breadcrumbs{
+		{Text: "Task"},
+		{Text: "[plan]", Page: &Page{Kind: TaskKind, ID: taskID}},
+		{Text: "[dev]", Page: &Page{Kind: ResourceListKind}},
+		{Text: "[modules/a]", Page: &Page{Kind: ModuleListKind}},
+	}
+
+	t.Run("render", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			width int
+			want  string
+		}{
+			{"fits", 30, "Task[plan][dev][modules/a]"},
+			{"replace one in the middle", 24, "Task[plan] … [modules/a]"},
+			{"replace all but first and last", 20, "Task … [modules/a]"},
+			{"too narrow", 5, "Task … [modules/a]"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got := internal.StripAnsi(crumbs.Render(tt.width))
+				assert.Equal(t, tt.want, got)
+			})
+		}
+	})
+
+	t.Run("at", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			x      int
+			width  int
+			want   *Page
+			wantOK bool
+		}{
+			{"title", 0, 30, nil, true},
+			{"task", 4, 30, &Page{Kind: TaskKind, ID: taskID}, true},
+			{"module", 20, 30, &Page{Kind: ModuleListKind}, true},
+			{"beyond breadcrumbs", 26, 30, nil, false},
+			{"ellipsis", 10, 24, nil, true},
+			{"module after ellipsis", 13, 24, &Page{Kind: ModuleListKind}, true},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, ok := crumbs.At(tt.x, tt.width)
+				assert.Equal(t, tt.wantOK, ok)
+				assert.Equal(t, tt.want, got.Page)
+			})
+		}
+	})
+}
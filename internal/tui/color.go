@@ -26,35 +26,50 @@ const (
 	OffWhite        = lipgloss.Color("#a8a7a5")
 )
 
+// Colors that can be overridden by a theme.
 var (
-	DebugLogLevel = Blue
-	InfoLogLevel  = lipgloss.AdaptiveColor{Dark: string(LightGreen), Light: string(Green)}
-	ErrorLogLevel = Red
-	WarnLogLevel  = Yellow
+	DebugLogLevel lipgloss.TerminalColor = Blue
+	InfoLogLevel  lipgloss.TerminalColor = lipgloss.AdaptiveColor{Dark: string(LightGreen), Light: string(Green)}
+	ErrorLogLevel lipgloss.TerminalColor = Red
+	WarnLogLevel  lipgloss.TerminalColor = Yellow
 
-	LogRecordAttributeKey = lipgloss.AdaptiveColor{Dark: string(LightGrey), Light: string(LightGrey)}
+	LogRecordAttributeKey lipgloss.TerminalColor = lipgloss.AdaptiveColor{Dark: string(LightGrey), Light: string(LightGrey)}
 
-	HelpKey = lipgloss.AdaptiveColor{
+	HelpKey lipgloss.TerminalColor = lipgloss.AdaptiveColor{
 		Dark:  "ff",
 		Light: "",
 	}
-	HelpDesc = lipgloss.AdaptiveColor{
+	HelpDesc lipgloss.TerminalColor = lipgloss.AdaptiveColor{
 		Dark:  "248",
 		Light: "246",
 	}
 
-	InactivePreviewBorder = lipgloss.AdaptiveColor{
+	InactivePreviewBorder lipgloss.TerminalColor = lipgloss.AdaptiveColor{
 		Dark:  "244",
 		Light: "250",
 	}
 
-	CurrentBackground            = Grey
-	CurrentForeground            = White
-	SelectedBackground           = lipgloss.Color("110")
-	SelectedForeground           = Black
-	CurrentAndSelectedBackground = lipgloss.Color("117")
-	CurrentAndSelectedForeground = Black
+	CurrentBackground            lipgloss.TerminalColor = Grey
+	CurrentForeground            lipgloss.TerminalColor = White
+	SelectedBackground           lipgloss.TerminalColor = lipgloss.Color("110")
+	SelectedForeground           lipgloss.TerminalColor = Black
+	CurrentAndSelectedBackground lipgloss.TerminalColor = lipgloss.Color("117")
+	CurrentAndSelectedForeground lipgloss.TerminalColor = Black
 
+	GroupReportBackgroundColor lipgloss.TerminalColor = EvenLighterGrey
+	TaskSummaryBackgroundColor lipgloss.TerminalColor = EvenLighterGrey
+
+	TaskPendingColor   lipgloss.TerminalColor = Grey
+	TaskQueuedColor    lipgloss.TerminalColor = Orange
+	TaskRunningColor   lipgloss.TerminalColor = Yellow
+	TaskSucceededColor lipgloss.TerminalColor = Green
+	TaskNoChangesColor lipgloss.TerminalColor = LightGrey
+	TaskErroredColor   lipgloss.TerminalColor = Red
+	TaskCanceledColor  lipgloss.TerminalColor = Purple
+	TaskDiscardedColor lipgloss.TerminalColor = LighterGrey
+)
+
+var (
 	TitleColor = lipgloss.AdaptiveColor{
 		Dark:  "",
 		Light: "",
@@ -65,9 +80,6 @@ var (
 		Light: string(Grey),
 	}
 
-	GroupReportBackgroundColor = EvenLighterGrey
-	TaskSummaryBackgroundColor = EvenLighterGrey
-
 	ScrollPercentageBackground = lipgloss.AdaptiveColor{
 		Dark:  string(DarkGrey),
 		Light: string(EvenLighterGrey),
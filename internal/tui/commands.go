@@ -1,7 +1,6 @@
 package tui
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -19,14 +18,21 @@ func ReportInfo(msg string, args ...any) tea.Cmd {
 	return CmdHandler(InfoMsg(fmt.Sprintf(msg, args...)))
 }
 
+const (
+	// defaultEditor is the editor used when EDITOR is unset.
+	defaultEditor = "vi"
+	// defaultShell is the shell used when SHELL is unset.
+	defaultShell = "/bin/sh"
+)
+
+// OpenEditor suspends pug and opens the directory in the user's editor, from
+// within the directory, resuming pug once the editor exits.
 func OpenEditor(path string) tea.Cmd {
 	// TODO: check for side effects of exec blocking the tui - do
 	// messages get queued up?
-	editor, ok := os.LookupEnv("EDITOR")
-	if !ok {
-		return ReportError(errors.New("cannot open editor: environment variable EDITOR not set"))
-	}
-	cmd := exec.Command(editor, path)
+	editor := getenv("EDITOR", defaultEditor)
+	cmd := exec.Command(editor, ".")
+	cmd.Dir = path
 	return tea.ExecProcess(cmd, func(err error) tea.Msg {
 		if err != nil {
 			return ReportError(fmt.Errorf("opening %s in editor: %w", path, err))()
@@ -34,3 +40,26 @@ func OpenEditor(path string) tea.Cmd {
 		return nil
 	})
 }
+
+// OpenShell suspends pug and starts the user's shell in the directory dir,
+// resuming pug once the shell exits.
+func OpenShell(dir string) tea.Cmd {
+	shell := getenv("SHELL", defaultShell)
+	cmd := exec.Command(shell)
+	cmd.Dir = dir
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		if err != nil {
+			return ReportError(fmt.Errorf("starting shell in %s: %w", dir, err))()
+		}
+		return nil
+	})
+}
+
+// getenv returns the value of the environment variable, or def if the variable
+// is unset or empty.
+func getenv(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
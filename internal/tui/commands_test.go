@@ -0,0 +1,16 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetenv(t *testing.T) {
+	t.Setenv("PUG_TEST_SET", "nano")
+	t.Setenv("PUG_TEST_EMPTY", "")
+
+	assert.Equal(t, "nano", getenv("PUG_TEST_SET", defaultEditor))
+	assert.Equal(t, defaultEditor, getenv("PUG_TEST_EMPTY", defaultEditor))
+	assert.Equal(t, defaultShell, getenv("PUG_TEST_UNSET", defaultShell))
+}
@@ -3,12 +3,15 @@ package tui
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/leg100/pug/internal"
 	"github.com/leg100/pug/internal/logging"
 	"github.com/leg100/pug/internal/module"
 	"github.com/leg100/pug/internal/plan"
@@ -31,6 +34,46 @@ type Helpers struct {
 	Tasks      *task.Service
 	States     *state.Service
 	Logger     logging.Interface
+	Workdir    internal.Workdir
+	// ColumnOrder is the keys of table columns to display first, in order.
+	ColumnOrder []string
+	// FollowNew moves the cursor to newly created items in tables.
+	FollowNew bool
+	// PinFirstColumn keeps the first column of tables in view when scrolling
+	// horizontally.
+	PinFirstColumn bool
+	// HeaderRule draws a horizontal rule beneath the header of tables.
+	HeaderRule bool
+	// Paginate is the names of lists whose rows are divided into pages rather
+	// than scrolled continuously.
+	Paginate []string
+	// DefaultFilters are filter expressions to apply to lists when they are
+	// opened, each in the form <list>=<expression>.
+	DefaultFilters []string
+	// Spinner is shown whilst tables load their initial items.
+	Spinner *spinner.Model
+	// ReadOnly disables actions that change infrastructure, state, or files.
+	ReadOnly bool
+	// SkipApplyConfirm applies without first prompting the user for
+	// confirmation. Destroys are always confirmed.
+	SkipApplyConfirm bool
+}
+
+// Paginated returns true if the rows of the named list are to be divided into
+// pages.
+func (h *Helpers) Paginated(list string) bool {
+	return slices.Contains(h.Paginate, list)
+}
+
+// DefaultFilter returns the filter expression to apply to the named list when
+// it is opened. Empty if the list is not filtered by default.
+func (h *Helpers) DefaultFilter(list string) string {
+	for _, filter := range h.DefaultFilters {
+		if name, expr, ok := strings.Cut(filter, "="); ok && name == list {
+			return expr
+		}
+	}
+	return ""
 }
 
 func (h *Helpers) ModuleCurrentWorkspace(mod *module.Module) *workspace.Workspace {
@@ -166,27 +209,43 @@ func (h *Helpers) TaskWorkspaceOrCurrentWorkspace(t *task.Task) *workspace.Works
 	return nil
 }
 
-// TaskStatus provides a rendered colored task status.
+// TaskStatusWidth is the width of a task status rendered by TaskStatus.
+const TaskStatusWidth = 2 + task.MaxStatusLen
+
+// TaskStatus provides a rendered colored task status, prefixed with an icon
+// that distinguishes each status without relying on color. A plan that exited
+// without any changes is distinguished from other tasks that exited.
 func (h *Helpers) TaskStatus(t *task.Task, background bool) string {
-	var color lipgloss.Color
+	var (
+		icon  string
+		color lipgloss.TerminalColor
+	)
 
 	switch t.State {
 	case task.Pending:
-		color = Grey
+		icon, color = "○", TaskPendingColor
 	case task.Queued:
-		color = Orange
+		icon, color = "◷", TaskQueuedColor
 	case task.Running:
-		color = Blue
+		icon, color = "●", TaskRunningColor
 	case task.Exited:
-		color = GreenBlue
+		icon, color = "✔", TaskSucceededColor
+		if report, ok := t.Summary.(plan.Report); ok && t.Identifier == plan.PlanTask && !report.HasChanges() {
+			icon, color = "–", TaskNoChangesColor
+		}
 	case task.Errored:
-		color = Red
+		icon, color = "✘", TaskErroredColor
+	case task.Canceled:
+		icon, color = "⊘", TaskCanceledColor
+	case task.Discarded:
+		icon, color = "⊖", TaskDiscardedColor
 	}
+	status := icon + " " + string(t.State)
 
 	if background {
-		return Padded.Background(color).Foreground(White).Render(string(t.State))
+		return Padded.Background(color).Foreground(White).Render(status)
 	} else {
-		return Regular.Foreground(color).Render(string(t.State))
+		return Regular.Foreground(color).Render(status)
 	}
 }
 
@@ -258,7 +317,9 @@ func (h *Helpers) CostSummary(report workspace.CostSummary, inherit lipgloss.Sty
 	return Regular.Foreground(Green).Inherit(inherit).Render(report.String())
 }
 
-// GroupReport renders a colored summary of a task group's task statuses.
+// GroupReport renders a colored summary of a task group's task statuses,
+// preceded by the mode of the runs if the group is a batch of runs and the
+// summary is not for a table.
 func (h *Helpers) GroupReport(group *task.Group, table bool) string {
 	var inherit lipgloss.Style
 	if !table {
@@ -276,6 +337,10 @@ func (h *Helpers) GroupReport(group *task.Group, table bool) string {
 	if table {
 		return s
 	}
+	if mode, ok := plan.BatchModeOf(group.Tasks); ok {
+		// Show the mode chosen for a batch of runs.
+		s = Regular.Inherit(inherit).Render(string(mode)+" ") + s
+	}
 	return Padded.Background(GroupReportBackgroundColor).Render(s)
 }
 
@@ -335,7 +400,10 @@ func (h *Helpers) createTaskGroup(specs ...task.Spec) tea.Msg {
 	if err != nil {
 		return ReportError(fmt.Errorf("creating task group: %w", err))
 	}
-	return NewNavigationMsg(TaskGroupKind, WithParent(group.ID))
+	return tea.BatchMsg{
+		CmdHandler(TaskGroupCreatedMsg{Group: group}),
+		CmdHandler(NewNavigationMsg(TaskGroupKind, WithParent(group.ID))),
+	}
 }
 
 func (h *Helpers) Move(workspaceID resource.ID, from state.ResourceAddress) tea.Cmd {
@@ -347,60 +415,237 @@ func (h *Helpers) Move(workspaceID resource.ID, from state.ResourceAddress) tea.
 				return nil
 			}
 			fn := func(workspaceID resource.ID) (task.Spec, error) {
+				// Refuse to alter state whilst a plan or apply is in progress.
+				if err := h.Plans.CheckLock(workspaceID); err != nil {
+					return task.Spec{}, err
+				}
 				return h.States.Move(workspaceID, from, state.ResourceAddress(v))
 			}
-			return h.CreateTasks(fn, workspaceID)
+			return YesNoPrompt(
+				fmt.Sprintf("Move %s to %s?", from, v),
+				h.CreateTasks(fn, workspaceID),
+			)
+		},
+		Key:    key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "confirm")),
+		Cancel: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+	})
+}
+
+// ConfirmApply prompts the user to confirm an apply before invoking the
+// action, unless confirmation has been disabled. A destroy is always
+// confirmed.
+func (h *Helpers) ConfirmApply(prompt string, destroy bool, action tea.Cmd) tea.Cmd {
+	if h.SkipApplyConfirm && !destroy {
+		return action
+	}
+	return YesNoPrompt(prompt, action)
+}
+
+// ApplyPrompt prefixes the prompt to confirm applying a plan with the plan's
+// variable inputs, so that they can be verified before the plan is applied.
+func ApplyPrompt(prompt string, vars plan.Variables) string {
+	switch {
+	case vars.Empty():
+		return prompt
+	case vars.InPlanFile:
+		return fmt.Sprintf("Variables (baked into plan file): %s. %s", vars, prompt)
+	default:
+		return fmt.Sprintf("Variables: %s. %s", vars, prompt)
+	}
+}
+
+// TargetedPlan prompts the user for the addresses of resources to target, and
+// creates a plan for each of the given workspaces targeting those resources.
+func (h *Helpers) TargetedPlan(workspaceIDs ...resource.ID) tea.Cmd {
+	return CmdHandler(PromptMsg{
+		Prompt:      "Enter target addresses: ",
+		Placeholder: "space-separated resource addresses",
+		Action: func(v string) tea.Cmd {
+			fields := strings.Fields(v)
+			if len(fields) == 0 {
+				return nil
+			}
+			var opts plan.CreateOptions
+			for _, addr := range fields {
+				opts.TargetAddrs = append(opts.TargetAddrs, state.ResourceAddress(addr))
+			}
+			fn := func(workspaceID resource.ID) (task.Spec, error) {
+				return h.Plans.Plan(workspaceID, opts)
+			}
+			return h.CreateTasks(fn, workspaceIDs...)
+		},
+		Key:    key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "confirm")),
+		Cancel: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+	})
+}
+
+// VarFilesPlan prompts the user for variable files, suggesting those found in
+// the module of the first of the given workspaces, and creates a plan for each
+// of the given workspaces using those files.
+func (h *Helpers) VarFilesPlan(workspaceIDs ...resource.ID) tea.Cmd {
+	if len(workspaceIDs) == 0 {
+		return nil
+	}
+	ws, err := h.Workspaces.Get(workspaceIDs[0])
+	if err != nil {
+		return ReportError(err)
+	}
+	found, err := plan.FindVarFiles(h.Workdir.Join(ws.ModulePath))
+	if err != nil {
+		return ReportError(fmt.Errorf("finding variable files: %w", err))
+	}
+	placeholder := "no .tfvars files found in module"
+	if len(found) > 0 {
+		placeholder = strings.Join(found, " ")
+	}
+	var initial string
+	if len(found) == 1 {
+		initial = found[0]
+	}
+	return CmdHandler(PromptMsg{
+		Prompt:       "Enter variable files: ",
+		InitialValue: initial,
+		Placeholder:  placeholder,
+		Action: func(v string) tea.Cmd {
+			fields := strings.Fields(v)
+			if len(fields) == 0 {
+				return nil
+			}
+			opts := plan.CreateOptions{VarFiles: fields}
+			fn := func(workspaceID resource.ID) (task.Spec, error) {
+				return h.Plans.Plan(workspaceID, opts)
+			}
+			return h.CreateTasks(fn, workspaceIDs...)
 		},
 		Key:    key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "confirm")),
 		Cancel: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
 	})
 }
 
-func (h *Helpers) Breadcrumbs(title string, res resource.Resource, crumbs ...string) string {
+// VarsPlan prompts the user for variables, one key=value pair at a time, and
+// creates a plan for each of the given workspaces with those variables.
+// Entering a blank value finishes the entry of variables.
+func (h *Helpers) VarsPlan(workspaceIDs ...resource.ID) tea.Cmd {
+	return h.promptPair("variable", map[string]string{}, func(vars map[string]string) plan.CreateOptions {
+		return plan.CreateOptions{Vars: vars}
+	}, workspaceIDs...)
+}
+
+// EnvPlan prompts the user for environment variables, one key=value pair at a
+// time, and creates a plan for each of the given workspaces with those
+// environment variables set for its plan and apply tasks. Entering a blank
+// value finishes the entry of environment variables.
+func (h *Helpers) EnvPlan(workspaceIDs ...resource.ID) tea.Cmd {
+	return h.promptPair("environment variable", map[string]string{}, func(env map[string]string) plan.CreateOptions {
+		return plan.CreateOptions{Env: env}
+	}, workspaceIDs...)
+}
+
+// promptPair prompts the user for the next key=value pair, adding it to pairs,
+// until a blank value is entered, whereupon a plan is created for each of the
+// given workspaces with the options returned by opts.
+func (h *Helpers) promptPair(kind string, pairs map[string]string, opts func(map[string]string) plan.CreateOptions, workspaceIDs ...resource.ID) tea.Cmd {
+	return CmdHandler(PromptMsg{
+		Prompt:      fmt.Sprintf("Enter %s %d (blank to finish): ", kind, len(pairs)+1),
+		Placeholder: "key=value",
+		Action: func(v string) tea.Cmd {
+			if strings.TrimSpace(v) == "" {
+				if len(pairs) == 0 {
+					return nil
+				}
+				fn := func(workspaceID resource.ID) (task.Spec, error) {
+					return h.Plans.Plan(workspaceID, opts(pairs))
+				}
+				return h.CreateTasks(fn, workspaceIDs...)
+			}
+			name, value, ok := strings.Cut(v, "=")
+			if !ok || strings.TrimSpace(name) == "" {
+				return ReportError(fmt.Errorf("invalid %s: %q: must be in the format key=value", kind, v))
+			}
+			pairs[strings.TrimSpace(name)] = value
+			return h.promptPair(kind, pairs, opts, workspaceIDs...)
+		},
+		Key:    key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "confirm")),
+		Cancel: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+	})
+}
+
+// Breadcrumbs constructs the title of a page, consisting of the title,
+// followed by any further crumbs, and lastly the resource and its ancestors.
+func (h *Helpers) Breadcrumbs(title string, res resource.Resource, crumbs ...string) Breadcrumbs {
 	// format: title{task command}[workspace name](module path)
+	trail := Breadcrumbs{{Text: Title.Render(title)}}
+	for _, crumb := range crumbs {
+		if crumb != "" {
+			trail = append(trail, Breadcrumb{Text: crumb})
+		}
+	}
+	return append(trail, h.ancestry(res)...)
+}
+
+// ancestry returns breadcrumbs for a resource followed by each of its
+// ancestors, each navigating to the page for its resource.
+func (h *Helpers) ancestry(res resource.Resource) Breadcrumbs {
 	switch res := res.(type) {
 	case *task.Task:
-		cmd := TitleCommand.Render(res.String())
+		trail := Breadcrumbs{{
+			Text: TitleCommand.Render(res.String()),
+			Page: &Page{Kind: TaskKind, ID: res.ID},
+		}}
 		if res.WorkspaceID != nil {
 			ws, err := h.Workspaces.Get(*res.WorkspaceID)
 			if err != nil {
 				h.Logger.Error("rendering breadcrumbs", "error", err)
-				return ""
+				return nil
 			}
-			return h.Breadcrumbs(title, ws, cmd)
+			return append(trail, h.ancestry(ws)...)
 		}
 		if res.ModuleID != nil {
 			mod, err := h.Modules.Get(*res.ModuleID)
 			if err != nil {
 				h.Logger.Error("rendering breadcrumbs", "error", err)
-				return ""
+				return nil
 			}
-			return h.Breadcrumbs(title, mod, cmd)
+			return append(trail, h.ancestry(mod)...)
 		}
 		// Global task
-		return h.Breadcrumbs(title, nil, cmd)
+		return trail
 	case *state.Resource:
-		addr := TitleAddress.Render(res.String())
 		ws, err := h.Workspaces.Get(res.WorkspaceID)
 		if err != nil {
 			h.Logger.Error("rendering breadcrumbs", "error", err)
-			return ""
+			return nil
 		}
-		return h.Breadcrumbs(title, ws, addr)
+		trail := Breadcrumbs{{
+			Text: TitleAddress.Render(res.String()),
+			Page: &Page{Kind: ResourceKind, ID: res.ID},
+		}}
+		return append(trail, h.ancestry(ws)...)
 	case *task.Group:
-		cmd := TitleCommand.Render(res.String())
-		id := TitleID.Render(res.GetID().String())
-		return h.Breadcrumbs(title, nil, cmd, id)
+		page := &Page{Kind: TaskGroupKind, ID: res.ID}
+		return Breadcrumbs{
+			{Text: TitleCommand.Render(res.String()), Page: page},
+			{Text: TitleID.Render(res.GetID().String()), Page: page},
+		}
 	case *workspace.Workspace:
-		name := TitleWorkspace.Render(res.String())
 		mod, err := h.Modules.Get(res.ModuleID)
 		if err != nil {
 			h.Logger.Error("rendering breadcrumbs", "error", err)
-			return ""
+			return nil
 		}
-		return h.Breadcrumbs(title, mod, append(crumbs, name)...)
+		// A workspace's page lists the resources in its state.
+		trail := Breadcrumbs{{
+			Text: TitleWorkspace.Render(res.String()),
+			Page: &Page{Kind: ResourceListKind, ID: res.ID},
+		}}
+		return append(trail, h.ancestry(mod)...)
 	case *module.Module:
-		crumbs = append(crumbs, TitlePath.Render(res.String()))
+		// Modules have no page of their own, so navigate to the list of
+		// modules.
+		return Breadcrumbs{{
+			Text: TitlePath.Render(res.String()),
+			Page: &Page{Kind: ModuleListKind},
+		}}
 	}
-	return fmt.Sprintf("%s%s", Title.Render(title), strings.Join(crumbs, ""))
+	return nil
 }
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/leg100/pug/internal"
+	"github.com/leg100/pug/internal/plan"
+	"github.com/leg100/pug/internal/task"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHelpers_TaskStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		task *task.Task
+		want string
+	}{
+		{"pending", &task.Task{State: task.Pending}, "○ pending"},
+		{"queued", &task.Task{State: task.Queued}, "◷ queued"},
+		{"running", &task.Task{State: task.Running}, "● running"},
+		{"applied", &task.Task{State: task.Exited, Identifier: plan.ApplyTask, Summary: plan.Report{}}, "✔ exited"},
+		{"planned with changes", &task.Task{State: task.Exited, Identifier: plan.PlanTask, Summary: plan.Report{Additions: 1}}, "✔ exited"},
+		{"planned without changes", &task.Task{State: task.Exited, Identifier: plan.PlanTask, Summary: plan.Report{}}, "– exited"},
+		{"errored", &task.Task{State: task.Errored}, "✘ errored"},
+		{"canceled", &task.Task{State: task.Canceled}, "⊘ canceled"},
+		{"discarded", &task.Task{State: task.Discarded}, "⊖ discarded"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := internal.StripAnsi((&Helpers{}).TaskStatus(tt.task, false))
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, Width(got), TaskStatusWidth)
+		})
+	}
+}
+
+func TestHelpers_DefaultFilter(t *testing.T) {
+	h := &Helpers{DefaultFilters: []string{"tasks=status:running", "modules=prod"}}
+
+	assert.Equal(t, "status:running", h.DefaultFilter("tasks"))
+	assert.Equal(t, "prod", h.DefaultFilter("modules"))
+	assert.Equal(t, "", h.DefaultFilter("workspaces"))
+}
+
+func TestApplyPrompt(t *testing.T) {
+	tests := []struct {
+		name string
+		vars plan.Variables
+		want string
+	}{
+		{"no variables", plan.Variables{}, "Apply plan?"},
+		{"variables", plan.Variables{VarsFile: "dev.tfvars"}, "Variables: -var-file=dev.tfvars. Apply plan?"},
+		{
+			"variables baked into plan file",
+			plan.Variables{Vars: map[string]string{"foo": "bar"}, InPlanFile: true},
+			"Variables (baked into plan file): -var=foo=bar. Apply plan?",
+		},
+		{
+			"sensitive variables",
+			plan.Variables{Vars: map[string]string{"token": "secret"}, Sensitive: []string{"token"}},
+			"Variables: -var=token=***. Apply plan?",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ApplyPrompt("Apply plan?", tt.vars))
+		})
+	}
+}
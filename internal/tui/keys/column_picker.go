@@ -0,0 +1,33 @@
+package keys
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type columnPicker struct {
+	Up     key.Binding
+	Down   key.Binding
+	Toggle key.Binding
+	Exit   key.Binding
+}
+
+// ColumnPicker is a key map of keys available whilst the column picker is
+// open.
+var ColumnPicker = columnPicker{
+	Up: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "down"),
+	),
+	Toggle: key.NewBinding(
+		key.WithKeys(" ", "x"),
+		key.WithHelp("space/x", "show/hide column"),
+	),
+	Exit: key.NewBinding(
+		key.WithKeys("enter", "esc"),
+		key.WithHelp("enter/esc", "close column picker"),
+	),
+}
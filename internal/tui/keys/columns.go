@@ -0,0 +1,58 @@
+package keys
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type columns struct {
+	PrevColumn  key.Binding
+	NextColumn  key.Binding
+	MoveLeft    key.Binding
+	MoveRight   key.Binding
+	ScrollLeft  key.Binding
+	ScrollRight key.Binding
+	Sort        key.Binding
+	Reverse     key.Binding
+	Pick        key.Binding
+}
+
+// Columns returns key bindings for selecting, reordering, scrolling, sorting
+// and hiding table columns.
+var Columns = columns{
+	PrevColumn: key.NewBinding(
+		key.WithKeys("<"),
+		key.WithHelp("<", "select previous column"),
+	),
+	NextColumn: key.NewBinding(
+		key.WithKeys(">"),
+		key.WithHelp(">", "select next column"),
+	),
+	MoveLeft: key.NewBinding(
+		key.WithKeys("{"),
+		key.WithHelp("{", "move column left"),
+	),
+	MoveRight: key.NewBinding(
+		key.WithKeys("}"),
+		key.WithHelp("}", "move column right"),
+	),
+	ScrollLeft: key.NewBinding(
+		key.WithKeys("left"),
+		key.WithHelp("←", "scroll columns left"),
+	),
+	ScrollRight: key.NewBinding(
+		key.WithKeys("right"),
+		key.WithHelp("→", "scroll columns right"),
+	),
+	Sort: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "cycle sort column"),
+	),
+	Reverse: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "reverse sort order"),
+	),
+	Pick: key.NewBinding(
+		key.WithKeys("alt+c"),
+		key.WithHelp("alt+c", "show/hide columns"),
+	),
+}
@@ -15,11 +15,13 @@ type common struct {
 	Module      key.Binding
 	Workspace   key.Binding
 	Edit        key.Binding
+	Shell       key.Binding
 	Init        key.Binding
 	InitUpgrade key.Binding
 	Validate    key.Binding
 	Format      key.Binding
 	Cost        key.Binding
+	Time        key.Binding
 }
 
 // Keys shared by several models.
@@ -72,6 +74,10 @@ var Common = common{
 		key.WithKeys("e"),
 		key.WithHelp("e", "edit"),
 	),
+	Shell: key.NewBinding(
+		key.WithKeys("!"),
+		key.WithHelp("!", "shell"),
+	),
 	Init: key.NewBinding(
 		key.WithKeys("i"),
 		key.WithHelp("i", "init"),
@@ -92,4 +98,24 @@ var Common = common{
 		key.WithKeys("$"),
 		key.WithHelp("$", "cost"),
 	),
+	Time: key.NewBinding(
+		key.WithKeys("alt+t"),
+		key.WithHelp("alt+t", "toggle relative time"),
+	),
+}
+
+// Mutating are the common keys that change infrastructure, state, or files,
+// and which are disabled in read-only mode.
+var Mutating = []key.Binding{
+	Common.Plan,
+	Common.PlanDestroy,
+	Common.Apply,
+	Common.Destroy,
+	Common.Delete,
+	Common.Retry,
+	Common.Edit,
+	Common.Shell,
+	Common.Init,
+	Common.InitUpgrade,
+	Common.Format,
 }
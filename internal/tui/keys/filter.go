@@ -7,6 +7,8 @@ import (
 type filter struct {
 	Blur  key.Binding
 	Close key.Binding
+	Fuzzy key.Binding
+	Regex key.Binding
 }
 
 // Filter is a key map of keys available in filter mode.
@@ -19,4 +21,12 @@ var Filter = filter{
 		key.WithKeys("esc"),
 		key.WithHelp("esc", "clear filter"),
 	),
+	Fuzzy: key.NewBinding(
+		key.WithKeys("ctrl+t"),
+		key.WithHelp("ctrl+t", "toggle fuzzy filter"),
+	),
+	Regex: key.NewBinding(
+		key.WithKeys("ctrl+r"),
+		key.WithHelp("ctrl+r", "toggle regex filter"),
+	),
 }
@@ -5,21 +5,28 @@ import (
 )
 
 type global struct {
-	Modules     key.Binding
-	Workspaces  key.Binding
-	Tasks       key.Binding
-	TaskGroups  key.Binding
-	Logs        key.Binding
-	Back        key.Binding
-	Select      key.Binding
-	SelectAll   key.Binding
-	SelectClear key.Binding
-	SelectRange key.Binding
-	Filter      key.Binding
-	Autoscroll  key.Binding
-	Quit        key.Binding
-	Suspend     key.Binding
-	Help        key.Binding
+	Modules          key.Binding
+	Workspaces       key.Binding
+	Tasks            key.Binding
+	TaskGroups       key.Binding
+	Logs             key.Binding
+	Back             key.Binding
+	Select           key.Binding
+	SelectAll        key.Binding
+	SelectClear      key.Binding
+	SelectRange      key.Binding
+	SelectInvert     key.Binding
+	Copy             key.Binding
+	Export           key.Binding
+	Filter           key.Binding
+	Jump             key.Binding
+	Notifications    key.Binding
+	Autoscroll       key.Binding
+	MaxTasksIncrease key.Binding
+	MaxTasksDecrease key.Binding
+	Quit             key.Binding
+	Suspend          key.Binding
+	Help             key.Binding
 }
 
 var Global = global{
@@ -53,7 +60,7 @@ var Global = global{
 	),
 	SelectAll: key.NewBinding(
 		key.WithKeys("ctrl+a"),
-		key.WithHelp("ctrl+a", "select all"),
+		key.WithHelp("ctrl+a", "select all matching filter"),
 	),
 	SelectClear: key.NewBinding(
 		key.WithKeys(`ctrl+\`),
@@ -63,14 +70,42 @@ var Global = global{
 		key.WithKeys(`ctrl+@`),
 		key.WithHelp(`ctrl+<space>`, "select range"),
 	),
+	SelectInvert: key.NewBinding(
+		key.WithKeys("~"),
+		key.WithHelp("~", "invert selection"),
+	),
+	Copy: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "copy to clipboard"),
+	),
+	Export: key.NewBinding(
+		key.WithKeys("E"),
+		key.WithHelp("E", "export table to CSV"),
+	),
 	Filter: key.NewBinding(
 		key.WithKeys("/"),
 		key.WithHelp(`/`, "filter"),
 	),
+	Jump: key.NewBinding(
+		key.WithKeys("'"),
+		key.WithHelp("'", "jump to row"),
+	),
+	Notifications: key.NewBinding(
+		key.WithKeys("ctrl+n"),
+		key.WithHelp("ctrl+n", "notifications"),
+	),
 	Autoscroll: key.NewBinding(
 		key.WithKeys("ctrl+s"),
 		key.WithHelp("ctrl+s", "toggle autoscroll"),
 	),
+	MaxTasksIncrease: key.NewBinding(
+		key.WithKeys(")"),
+		key.WithHelp(")", "increase max parallel tasks"),
+	),
+	MaxTasksDecrease: key.NewBinding(
+		key.WithKeys("("),
+		key.WithHelp("(", "decrease max parallel tasks"),
+	),
 	Quit: key.NewBinding(
 		key.WithKeys("ctrl+c"),
 		key.WithHelp("ctrl+c", "exit"),
@@ -0,0 +1,22 @@
+package keys
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type help struct {
+	ScrollUp   key.Binding
+	ScrollDown key.Binding
+}
+
+// Help is a key map of keys available whilst the help widget is visible.
+var Help = help{
+	ScrollUp: key.NewBinding(
+		key.WithKeys("shift+up"),
+		key.WithHelp("shift+↑", "scroll help up"),
+	),
+	ScrollDown: key.NewBinding(
+		key.WithKeys("shift+down"),
+		key.WithHelp("shift+↓", "scroll help down"),
+	),
+}
@@ -0,0 +1,22 @@
+package keys
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type jump struct {
+	Exit      key.Binding
+	Backspace key.Binding
+}
+
+// Jump is a key map of keys available in jump mode.
+var Jump = jump{
+	Exit: key.NewBinding(
+		key.WithKeys("enter", "esc"),
+		key.WithHelp("enter/esc", "exit jump"),
+	),
+	Backspace: key.NewBinding(
+		key.WithKeys("backspace"),
+		key.WithHelp("backspace", "delete last character"),
+	),
+}
@@ -0,0 +1,23 @@
+package keys
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type minimap struct {
+	NextMark key.Binding
+	PrevMark key.Binding
+}
+
+// Minimap returns key bindings for jumping between lines marked on the
+// mini-map.
+var Minimap = minimap{
+	NextMark: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "next error/warning"),
+	),
+	PrevMark: key.NewBinding(
+		key.WithKeys("["),
+		key.WithHelp("[", "previous error/warning"),
+	),
+}
@@ -15,4 +15,9 @@ const (
 	ResourceKind
 	LogListKind
 	LogKind
+	StateDiffKind
+	TaskGroupReportKind
+	PlanChangesKind
+	OutputsKind
+	TaskErrorKind
 )
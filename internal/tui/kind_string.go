@@ -18,11 +18,16 @@ func _() {
 	_ = x[ResourceKind-7]
 	_ = x[LogListKind-8]
 	_ = x[LogKind-9]
+	_ = x[StateDiffKind-10]
+	_ = x[TaskGroupReportKind-11]
+	_ = x[PlanChangesKind-12]
+	_ = x[OutputsKind-13]
+	_ = x[TaskErrorKind-14]
 }
 
-const _Kind_name = "ModuleListKindWorkspaceListKindTaskListKindTaskKindTaskGroupListKindTaskGroupKindResourceListKindResourceKindLogListKindLogKind"
+const _Kind_name = "ModuleListKindWorkspaceListKindTaskListKindTaskKindTaskGroupListKindTaskGroupKindResourceListKindResourceKindLogListKindLogKindStateDiffKindTaskGroupReportKindPlanChangesKindOutputsKindTaskErrorKind"
 
-var _Kind_index = [...]uint8{0, 14, 31, 43, 51, 68, 81, 97, 109, 120, 127}
+var _Kind_index = [...]uint8{0, 14, 31, 43, 51, 68, 81, 97, 109, 120, 127, 140, 159, 174, 185, 198}
 
 func (i Kind) String() string {
 	if i < 0 || i >= Kind(len(_Kind_index)-1) {
@@ -12,3 +12,34 @@ var localKeys = keyMap{
 		key.WithHelp("enter", "view message"),
 	),
 }
+
+type listKeyMap struct {
+	Level     key.Binding
+	Order     key.Binding
+	Search    key.Binding
+	NextMatch key.Binding
+	PrevMatch key.Binding
+}
+
+var listKeys = listKeyMap{
+	Level: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "cycle min level"),
+	),
+	Order: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "toggle oldest first"),
+	),
+	Search: key.NewBinding(
+		key.WithKeys("ctrl+f"),
+		key.WithHelp("ctrl+f", "search"),
+	),
+	NextMatch: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next match"),
+	),
+	PrevMatch: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "previous match"),
+	),
+}
@@ -1,13 +1,19 @@
 package logs
 
 import (
+	"io"
+	"log/slog"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/leg100/pug/internal/logging"
 	"github.com/leg100/pug/internal/resource"
 	"github.com/leg100/pug/internal/tui"
+	"github.com/leg100/pug/internal/tui/keys"
 	"github.com/leg100/pug/internal/tui/table"
 )
 
@@ -31,6 +37,15 @@ var (
 	}
 )
 
+// minLevels are the minimum levels through which the user can cycle, starting
+// with the lowest, which shows all messages.
+var minLevels = []slog.Level{
+	slog.LevelDebug,
+	slog.LevelInfo,
+	slog.LevelWarn,
+	slog.LevelError,
+}
+
 type ListMaker struct {
 	Logger  *logging.Logger
 	Helpers *tui.Helpers
@@ -42,38 +57,64 @@ func (m *ListMaker) Make(_ resource.ID, width, height int) (tea.Model, error) {
 		levelColumn,
 		msgColumn,
 	}
+	search := &search{}
+	relativeTime := new(bool)
 	renderer := func(msg logging.Message) table.RenderedRow {
 		// combine message and attributes, separated by spaces, with each
-		// attribute key/value joined with a '='
+		// attribute key/value joined with a '=', and highlighting any
+		// matches of the search term.
 		var b strings.Builder
-		b.WriteString(msg.Message)
+		b.WriteString(search.highlight(msg.Message, tui.Regular))
 		b.WriteRune(' ')
 		for _, attr := range msg.Attributes {
 			b.WriteString(tui.Regular.Foreground(tui.LogRecordAttributeKey).Render(attr.Key + "="))
-			b.WriteString(tui.Regular.Render(attr.Value + " "))
+			b.WriteString(search.highlight(attr.Value, tui.Regular))
+			b.WriteRune(' ')
 		}
 
+		t := msg.Time.Format(timeFormat)
+		if *relativeTime {
+			t = tui.RelativeTime(time.Now(), msg.Time, timeFormat)
+		}
 		return table.RenderedRow{
-			timeColumn.Key:  msg.Time.Format(timeFormat),
+			timeColumn.Key:  t,
 			levelColumn.Key: coloredLogLevel(msg.Level),
 			msgColumn.Key:   tui.Regular.Render(b.String()),
 		}
 	}
 	table := table.New(columns, renderer, width, height,
 		table.WithSortFunc(logging.BySerialDesc),
-		table.WithSelectable[logging.Message](false),
+		table.WithColumnOrder[logging.Message](m.Helpers.ColumnOrder...),
+		table.WithPinnedColumn[logging.Message](m.Helpers.PinFirstColumn),
+		table.WithHeaderRule[logging.Message](m.Helpers.HeaderRule),
+		table.WithPagination[logging.Message](m.Helpers.Paginated("logs")),
+		table.WithDefaultFilter[logging.Message](m.Helpers.DefaultFilter("logs")),
+		table.WithLoading[logging.Message](m.Helpers.Spinner),
+		table.WithCopyFunc(func(msg logging.Message) string { return msg.String() }),
 	)
 
 	return list{
-		logger:  m.Logger,
-		table:   table,
-		Helpers: m.Helpers,
+		logger:       m.Logger,
+		table:        table,
+		minLevel:     minLevels[0],
+		search:       search,
+		relativeTime: relativeTime,
+		Helpers:      m.Helpers,
 	}, nil
 }
 
 type list struct {
 	logger *logging.Logger
 	table  table.Model[logging.Message]
+	// minLevel is the minimum level of messages to list.
+	minLevel slog.Level
+	// oldestFirst lists the oldest messages first rather than the newest.
+	oldestFirst bool
+	// search is the current search of messages.
+	search *search
+	// relativeTime renders the times of messages relative to now rather than
+	// as absolute times.
+	relativeTime *bool
 
 	*tui.Helpers
 }
@@ -97,7 +138,41 @@ func (m list) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if row, ok := m.table.CurrentRow(); ok {
 				return m, tui.NavigateTo(tui.LogKind, tui.WithParent(row.ID))
 			}
+		case key.Matches(msg, listKeys.Level):
+			m.cycleMinLevel()
+			return m, nil
+		case key.Matches(msg, listKeys.Order):
+			m.toggleOrder()
+			return m, nil
+		case key.Matches(msg, keys.Common.Time):
+			*m.relativeTime = !*m.relativeTime
+			m.table.RerenderAll()
+			return m, nil
+		case key.Matches(msg, listKeys.Search):
+			return m, tui.CmdHandler(tui.PromptMsg{
+				Prompt:       "Search: ",
+				InitialValue: m.search.term,
+				Action: func(v string) tea.Cmd {
+					return tui.CmdHandler(searchMsg(v))
+				},
+				Key:    key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "search")),
+				Cancel: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+			})
+		case key.Matches(msg, listKeys.NextMatch):
+			return m, m.findMatch(false)
+		case key.Matches(msg, listKeys.PrevMatch):
+			return m, m.findMatch(true)
+		}
+	case spinner.TickMsg:
+		// Keep relative times up to date.
+		if *m.relativeTime {
+			m.table.Rerender(func(logging.Message) bool { return true })
 		}
+	case searchMsg:
+		m.search.set(string(msg))
+		// Re-render messages to highlight matches.
+		m.table.SetItems(m.logger.List()...)
+		return m, m.findMatch(false)
 	}
 
 	// Handle keyboard and mouse events in the table widget
@@ -107,8 +182,80 @@ func (m list) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
-func (m list) Title() string {
-	return m.Breadcrumbs("Logs", nil)
+// cycleMinLevel raises the minimum level of messages to list, wrapping round
+// to the lowest level, which lists all messages.
+func (m *list) cycleMinLevel() {
+	i := (slices.Index(minLevels, m.minLevel) + 1) % len(minLevels)
+	m.setMinLevel(minLevels[i])
+}
+
+// setMinLevel only lists those messages at or above the given level.
+func (m *list) setMinLevel(level slog.Level) {
+	m.minLevel = level
+	if level == minLevels[0] {
+		m.table.SetPredicate(nil)
+		return
+	}
+	m.table.SetPredicate(func(msg logging.Message) bool {
+		return msg.SlogLevel() >= level
+	})
+}
+
+// toggleOrder toggles between listing the newest messages first and the
+// oldest messages first, re-sorting the messages already listed.
+func (m *list) toggleOrder() {
+	m.oldestFirst = !m.oldestFirst
+	if m.oldestFirst {
+		m.table.SetSortFunc(logging.BySerial)
+	} else {
+		m.table.SetSortFunc(logging.BySerialDesc)
+	}
+}
+
+// findMatch makes the next message matching the search the current row. Set
+// reverse to find the previous message instead.
+func (m *list) findMatch(reverse bool) tea.Cmd {
+	if m.search.term == "" {
+		return nil
+	}
+	if !m.table.FindNext(m.search.matches, reverse) {
+		return tui.ReportInfo("no messages match %q", m.search.term)
+	}
+	return nil
+}
+
+func (m list) Title() tui.Breadcrumbs {
+	var crumbs []string
+	if m.minLevel != minLevels[0] {
+		crumbs = append(crumbs, tui.TitleTimeRange.Render(m.minLevel.String()+"+"))
+	}
+	if m.oldestFirst {
+		crumbs = append(crumbs, tui.TitleTimeRange.Render("oldest first"))
+	}
+	if m.search.term != "" {
+		crumbs = append(crumbs, tui.TitleSearch.Render(m.search.term))
+	}
+	return m.Breadcrumbs("Logs", nil, crumbs...)
+}
+
+// ExportCSV writes the table in CSV format.
+func (m list) ExportCSV(w io.Writer) error {
+	return m.table.ExportCSV(w)
+}
+
+// FilterValue returns the value of the table's filter.
+func (m list) FilterValue() string {
+	return m.table.FilterValue()
+}
+
+// Loading returns true if the table is yet to load its items.
+func (m list) Loading() bool {
+	return m.table.Loading()
+}
+
+// Pagination describes the page of the table currently visible.
+func (m list) Pagination() string {
+	return m.table.Pagination()
 }
 
 func (m list) View() string {
@@ -116,5 +263,6 @@ func (m list) View() string {
 }
 
 func (m list) HelpBindings() []key.Binding {
-	return []key.Binding{localKeys.Enter}
+	bindings := append([]key.Binding{localKeys.Enter}, keys.KeyMapToSlice(listKeys)...)
+	return append(bindings, keys.Common.Time)
 }
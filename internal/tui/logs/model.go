@@ -2,6 +2,7 @@ package logs
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -98,11 +99,21 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-func (m model) Title() string {
+func (m model) Title() tui.Breadcrumbs {
 	serial := tui.TitleSerial.Render(fmt.Sprintf("#%d", m.msg.Serial))
 	return m.Breadcrumbs("LogMessage", nil, serial)
 }
 
+// ExportCSV writes the table in CSV format.
+func (m model) ExportCSV(w io.Writer) error {
+	return m.table.ExportCSV(w)
+}
+
+// FilterValue returns the value of the table's filter.
+func (m model) FilterValue() string {
+	return m.table.FilterValue()
+}
+
 func (m model) View() string {
 	return m.table.View()
 }
@@ -0,0 +1,66 @@
+package logs
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/leg100/pug/internal/logging"
+	"github.com/leg100/pug/internal/tui"
+)
+
+// searchMsg sets the term to search for in log messages.
+type searchMsg string
+
+// search is a case-insensitive search of log messages. It is shared between
+// the list model and its row renderer, so that matches are highlighted in
+// messages arriving after the search started too.
+type search struct {
+	term string
+	re   *regexp.Regexp
+}
+
+func (s *search) set(term string) {
+	s.term = term
+	if term == "" {
+		s.re = nil
+		return
+	}
+	s.re = regexp.MustCompile("(?i)" + regexp.QuoteMeta(term))
+}
+
+// matches determines whether the text or any of the attribute values of a
+// message match the search term.
+func (s *search) matches(msg logging.Message) bool {
+	if s.re == nil {
+		return false
+	}
+	if s.re.MatchString(msg.Message) {
+		return true
+	}
+	for _, attr := range msg.Attributes {
+		if s.re.MatchString(attr.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// highlight renders text with the given style, highlighting any matches of
+// the search term.
+func (s *search) highlight(text string, style lipgloss.Style) string {
+	if s.re == nil {
+		return style.Render(text)
+	}
+	var (
+		b    strings.Builder
+		last int
+	)
+	for _, loc := range s.re.FindAllStringIndex(text, -1) {
+		b.WriteString(style.Render(text[last:loc[0]]))
+		b.WriteString(tui.SearchMatch.Render(text[loc[0]:loc[1]]))
+		last = loc[1]
+	}
+	b.WriteString(style.Render(text[last:]))
+	return b.String()
+}
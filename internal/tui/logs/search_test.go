@@ -0,0 +1,41 @@
+package logs
+
+import (
+	"testing"
+
+	"github.com/leg100/pug/internal"
+	"github.com/leg100/pug/internal/logging"
+	"github.com/leg100/pug/internal/tui"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch_Matches(t *testing.T) {
+	s := &search{}
+	msg := logging.Message{
+		Message:    "created plan",
+		Attributes: []logging.Attr{{Key: "path", Value: "modules/VPC"}},
+	}
+
+	assert.False(t, s.matches(msg), "empty search matches nothing")
+
+	s.set("PLAN")
+	assert.True(t, s.matches(msg))
+
+	s.set("vpc")
+	assert.True(t, s.matches(msg), "attribute values are searched")
+
+	s.set("path")
+	assert.False(t, s.matches(msg), "attribute keys are not searched")
+}
+
+func TestSearch_Highlight(t *testing.T) {
+	s := &search{}
+	s.set("an")
+
+	got := s.highlight("plan and apply", tui.Regular)
+	assert.Equal(t, "plan and apply", internal.StripAnsi(got))
+	assert.Equal(t,
+		"pl"+tui.SearchMatch.Render("an")+" "+tui.SearchMatch.Render("an")+"d apply",
+		got,
+	)
+}
@@ -3,6 +3,7 @@ package tui
 import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/task"
 )
 
 // NavigationMsg is an instruction to navigate to a page.
@@ -29,6 +30,12 @@ func WithParent(parent resource.ID) NavigateOption {
 
 type InfoMsg string
 
+// TaskGroupCreatedMsg is sent when a task group is created, in order to track
+// the progress of its tasks.
+type TaskGroupCreatedMsg struct {
+	Group *task.Group
+}
+
 // FilterFocusReqMsg is a request to focus the filter widget.
 type FilterFocusReqMsg struct{}
 
@@ -40,5 +47,31 @@ type FilterBlurMsg struct{}
 // acknowledged.
 type FilterCloseMsg struct{}
 
+// FilterSetMsg is a request to set the value of the filter widget, without
+// focusing it. It is not acknowledged.
+type FilterSetMsg string
+
 // FilterKeyMsg is a key entered by the user into the filter widget
 type FilterKeyMsg tea.KeyMsg
+
+// JumpReqMsg is a request to start jumping to rows by typing the beginning of
+// their first column.
+type JumpReqMsg struct{}
+
+// JumpCloseMsg is a request to stop jumping. It is not acknowledged.
+type JumpCloseMsg struct{}
+
+// JumpKeyMsg is a key entered by the user whilst jumping.
+type JumpKeyMsg tea.KeyMsg
+
+// ColumnPickerReqMsg is a request to open the column picker, for showing and
+// hiding table columns.
+type ColumnPickerReqMsg struct{}
+
+// ColumnPickerCloseMsg is a request to close the column picker. It is not
+// acknowledged.
+type ColumnPickerCloseMsg struct{}
+
+// ColumnPickerKeyMsg is a key entered by the user whilst the column picker is
+// open.
+type ColumnPickerKeyMsg tea.KeyMsg
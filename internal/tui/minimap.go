@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/leg100/pug/internal"
+)
+
+const (
+	MinimapWidth = 1
+
+	minimapMark = "▐"
+)
+
+// severity classifies a line of output.
+type severity int
+
+const (
+	severityNone severity = iota
+	severityWarning
+	severityError
+)
+
+var (
+	errorPattern   = regexp.MustCompile(`(?i)\berror\b`)
+	warningPattern = regexp.MustCompile(`(?i)\bwarning\b`)
+)
+
+// classifyLines classifies each line in the content by severity.
+func classifyLines(content string) []severity {
+	lines := strings.Split(content, "\n")
+	severities := make([]severity, len(lines))
+	for i, line := range lines {
+		stripped := internal.StripAnsi(line)
+		switch {
+		case errorPattern.MatchString(stripped):
+			severities[i] = severityError
+		case warningPattern.MatchString(stripped):
+			severities[i] = severityWarning
+		}
+	}
+	return severities
+}
+
+// Minimap renders a gutter of the given height, marking where errors and
+// warnings occur amongst the lines of content. Where a gutter row covers more
+// than one line, the most severe line determines the mark.
+func Minimap(height int, severities []severity) string {
+	total := len(severities)
+	height = max(0, height)
+	rows := make([]string, height)
+	for i := range height {
+		// Determine the range of lines covered by the gutter row.
+		from := i * total / height
+		to := max(from+1, (i+1)*total/height)
+		worst := severityNone
+		for j := from; j < min(to, total); j++ {
+			worst = max(worst, severities[j])
+		}
+		switch worst {
+		case severityError:
+			rows[i] = Regular.Foreground(ErrorLogLevel).Render(minimapMark)
+		case severityWarning:
+			rows[i] = Regular.Foreground(WarnLogLevel).Render(minimapMark)
+		default:
+			rows[i] = " "
+		}
+	}
+	return strings.Join(rows, "\n")
+}
+
+// nextMark returns the index of the next line after the given line with a
+// severity, and false if there is no such line. If reverse is true then the
+// previous such line is returned instead.
+func nextMark(severities []severity, line int, reverse bool) (int, bool) {
+	if reverse {
+		for i := min(line, len(severities)) - 1; i >= 0; i-- {
+			if severities[i] != severityNone {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+	for i := max(line+1, 0); i < len(severities); i++ {
+		if severities[i] != severityNone {
+			return i, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyLines(t *testing.T) {
+	content := strings.Join([]string{
+		"Initializing the backend...",
+		"\x1b[31mError: \x1b[0mInvalid reference",
+		"Warning: Deprecated attribute",
+		"terror is not an error word boundary",
+		"errors happen",
+	}, "\n")
+
+	got := classifyLines(content)
+
+	want := []severity{
+		severityNone,
+		severityError,
+		severityWarning,
+		severityError,
+		severityNone,
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestMinimap(t *testing.T) {
+	severities := []severity{
+		severityNone,
+		severityNone,
+		severityWarning,
+		severityError,
+	}
+
+	t.Run("one line per row", func(t *testing.T) {
+		got := strings.Split(Minimap(4, severities), "\n")
+		assert.Len(t, got, 4)
+		assert.Equal(t, " ", got[0])
+		assert.Equal(t, " ", got[1])
+		assert.Contains(t, got[2], minimapMark)
+		assert.Contains(t, got[3], minimapMark)
+	})
+
+	t.Run("several lines per row", func(t *testing.T) {
+		got := strings.Split(Minimap(2, severities), "\n")
+		assert.Len(t, got, 2)
+		assert.Equal(t, " ", got[0])
+		assert.Contains(t, got[1], minimapMark)
+	})
+
+	t.Run("negative height", func(t *testing.T) {
+		assert.Empty(t, Minimap(-1, severities))
+	})
+}
+
+func TestNextMark(t *testing.T) {
+	severities := []severity{
+		severityError,
+		severityNone,
+		severityWarning,
+		severityNone,
+	}
+
+	tests := []struct {
+		name    string
+		line    int
+		reverse bool
+		want    int
+		found   bool
+	}{
+		{"next from top", 0, false, 2, true},
+		{"next from last mark", 2, false, 0, false},
+		{"previous from bottom", 3, true, 2, true},
+		{"previous from mark", 2, true, 0, true},
+		{"previous from top", 0, true, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := nextMark(severities, tt.line, tt.reverse)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -28,7 +28,36 @@ type ModelStatus interface {
 
 // ModelTitle is implemented by models that show a title
 type ModelTitle interface {
-	Title() string
+	Title() Breadcrumbs
+}
+
+// ModelPagination is implemented by models that divide their content into
+// pages.
+type ModelPagination interface {
+	Pagination() string
+}
+
+// ModelLoading is implemented by models that load their content
+// asynchronously.
+type ModelLoading interface {
+	Loading() bool
+}
+
+// ModelFilter is implemented by models with a filter.
+type ModelFilter interface {
+	FilterValue() string
+}
+
+// ModelLeave is implemented by models that act upon the user navigating away
+// from them to another page.
+type ModelLeave interface {
+	Leave(to Page) tea.Cmd
+}
+
+// ModelVisit is implemented by models that act upon the user navigating to
+// them, whether the model is newly created or was visited before.
+type ModelVisit interface {
+	Visit() tea.Cmd
 }
 
 // ModelHelpBindings is implemented by models that surface further help bindings
@@ -1,7 +1,10 @@
 package module
 
 import (
+	"slices"
+
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/leg100/pug/internal/tui/keys"
 )
 
 type keyMap struct {
@@ -29,3 +32,8 @@ var localKeys = keyMap{
 		key.WithHelp("x", "execute program"),
 	),
 }
+
+// mutatingKeys are disabled in read-only mode.
+var mutatingKeys = slices.Concat(keys.Mutating, []key.Binding{
+	localKeys.Execute,
+})
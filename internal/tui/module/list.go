@@ -3,6 +3,7 @@ package module
 import (
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -46,14 +47,18 @@ type ListMaker struct {
 	Workdir    internal.Workdir
 	Helpers    *tui.Helpers
 	Terragrunt bool
+	// ModuleDependencies is true if dependencies between modules have been
+	// declared in a dependencies file.
+	ModuleDependencies bool
 }
 
 func (m *ListMaker) Make(_ resource.ID, width, height int) (tea.Model, error) {
 	columns := []table.Column{
 		table.ModuleColumn,
 	}
-	// Only include dependencies column if using terragrunt
-	if m.Terragrunt {
+	// Only include dependencies column if using terragrunt or a dependencies
+	// file.
+	if m.Terragrunt || m.ModuleDependencies {
 		columns = append(columns, dependencies)
 	}
 	columns = append(columns,
@@ -84,6 +89,13 @@ func (m *ListMaker) Make(_ resource.ID, width, height int) (tea.Model, error) {
 	}
 	table := table.New(columns, renderer, width, height,
 		table.WithSortFunc(module.ByPath),
+		table.WithColumnOrder[*module.Module](m.Helpers.ColumnOrder...),
+		table.WithPinnedColumn[*module.Module](m.Helpers.PinFirstColumn),
+		table.WithHeaderRule[*module.Module](m.Helpers.HeaderRule),
+		table.WithPagination[*module.Module](m.Helpers.Paginated("modules")),
+		table.WithDefaultFilter[*module.Module](m.Helpers.DefaultFilter("modules")),
+		table.WithLoading[*module.Module](m.Helpers.Spinner),
+		table.WithCopyFunc(func(mod *module.Module) string { return mod.Path }),
 	)
 
 	return list{
@@ -137,6 +149,9 @@ func (m list) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.table.AddItems(mod)
 		}
 	case tea.KeyMsg:
+		if cmd := m.RefuseMutation(msg, mutatingKeys...); cmd != nil {
+			return m, cmd
+		}
 		switch {
 		case key.Matches(msg, localKeys.ReloadModules):
 			return m, ReloadModules(false, m.Modules)
@@ -145,6 +160,10 @@ func (m list) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				path := m.workdir.Join(row.Value.Path)
 				return m, tui.OpenEditor(path)
 			}
+		case key.Matches(msg, keys.Common.Shell):
+			if row, ok := m.table.CurrentRow(); ok {
+				return m, tui.OpenShell(m.workdir.Join(row.Value.Path))
+			}
 		case key.Matches(msg, keys.Common.InitUpgrade):
 			upgrade = true
 			fallthrough
@@ -183,7 +202,7 @@ func (m list) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if workspaceID := mod.CurrentWorkspaceID; workspaceID == nil {
 					return task.Spec{}, fmt.Errorf("module %s does not have a current workspace", mod)
 				} else {
-					return m.Plans.Plan(*workspaceID, createPlanOpts)
+					return m.Plans.Batch(plan.PlanOnly, createPlanOpts)(*workspaceID)
 				}
 			})
 			if err != nil {
@@ -203,7 +222,7 @@ func (m list) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if workspaceID := mod.CurrentWorkspaceID; workspaceID == nil {
 					return task.Spec{}, fmt.Errorf("module %s does not have a current workspace", mod)
 				} else {
-					return m.Plans.Apply(*workspaceID, createPlanOpts)
+					return m.Plans.Batch(plan.PlanAndApply, createPlanOpts)(*workspaceID)
 				}
 			})
 			if err != nil {
@@ -211,8 +230,9 @@ func (m list) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// another opportunity to apply any remaining modules.
 				return m, tui.ReportError(err)
 			}
-			return m, tui.YesNoPrompt(
+			return m, m.ConfirmApply(
 				fmt.Sprintf(applyPrompt, len(specs)),
+				createPlanOpts.Destroy,
 				m.CreateTasksWithSpecs(specs...),
 			)
 		case key.Matches(msg, localKeys.Execute):
@@ -222,11 +242,11 @@ func (m list) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				Prompt:      fmt.Sprintf("Execute program in %d module directories: ", len(ids)),
 				Placeholder: "terraform version",
 				Action: func(v string) tea.Cmd {
-					if v == "" {
+					// split value into program and any args
+					parts := strings.Fields(v)
+					if len(parts) == 0 {
 						return nil
 					}
-					// split value into program and any args
-					parts := strings.Split(v, " ")
 					prog := parts[0]
 					args := parts[1:]
 					fn := func(moduleID resource.ID) (task.Spec, error) {
@@ -245,16 +265,36 @@ func (m list) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
-func (m list) Title() string {
+func (m list) Title() tui.Breadcrumbs {
 	return m.Breadcrumbs("Modules", nil)
 }
 
+// ExportCSV writes the table in CSV format.
+func (m list) ExportCSV(w io.Writer) error {
+	return m.table.ExportCSV(w)
+}
+
+// FilterValue returns the value of the table's filter.
+func (m list) FilterValue() string {
+	return m.table.FilterValue()
+}
+
+// Loading returns true if the table is yet to load its items.
+func (m list) Loading() bool {
+	return m.table.Loading()
+}
+
+// Pagination describes the page of the table currently visible.
+func (m list) Pagination() string {
+	return m.table.Pagination()
+}
+
 func (m list) View() string {
 	return m.table.View()
 }
 
 func (m list) HelpBindings() (bindings []key.Binding) {
-	return []key.Binding{
+	bindings = []key.Binding{
 		keys.Common.Init,
 		keys.Common.InitUpgrade,
 		keys.Common.Format,
@@ -264,9 +304,11 @@ func (m list) HelpBindings() (bindings []key.Binding) {
 		keys.Common.Apply,
 		keys.Common.Destroy,
 		keys.Common.Edit,
+		keys.Common.Shell,
 		localKeys.Execute,
 		localKeys.ReloadModules,
 		localKeys.ReloadWorkspaces,
 		keys.Common.State,
 	}
+	return m.HideMutations(bindings, mutatingKeys...)
 }
@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ErrReadOnly is reported when the user attempts a mutating action in
+// read-only mode.
+var ErrReadOnly = errors.New("action disabled in read-only mode")
+
+// RefuseMutation returns a command reporting ErrReadOnly if read-only mode is
+// enabled and the key matches one of the given mutating bindings; otherwise
+// nil is returned.
+func (h *Helpers) RefuseMutation(msg tea.KeyMsg, mutating ...key.Binding) tea.Cmd {
+	if !h.ReadOnly {
+		return nil
+	}
+	if key.Matches(msg, mutating...) {
+		return ReportError(ErrReadOnly)
+	}
+	return nil
+}
+
+// HideMutations removes the mutating bindings from the given bindings if
+// read-only mode is enabled, so that they are not shown in the help.
+func (h *Helpers) HideMutations(bindings []key.Binding, mutating ...key.Binding) []key.Binding {
+	if !h.ReadOnly {
+		return bindings
+	}
+	return slices.DeleteFunc(bindings, func(b key.Binding) bool {
+		return slices.ContainsFunc(mutating, func(m key.Binding) bool {
+			return slices.Equal(b.Keys(), m.Keys())
+		})
+	})
+}
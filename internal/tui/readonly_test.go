@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHelpers_RefuseMutation(t *testing.T) {
+	apply := key.NewBinding(key.WithKeys("a"))
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")}
+
+	t.Run("read-only", func(t *testing.T) {
+		h := &Helpers{ReadOnly: true}
+
+		cmd := h.RefuseMutation(msg, apply)
+		require.NotNil(t, cmd)
+		assert.Equal(t, ErrorMsg(ErrReadOnly), cmd())
+	})
+
+	t.Run("read-only with non-mutating key", func(t *testing.T) {
+		h := &Helpers{ReadOnly: true}
+
+		other := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")}
+		assert.Nil(t, h.RefuseMutation(other, apply))
+	})
+
+	t.Run("read-write", func(t *testing.T) {
+		h := &Helpers{}
+
+		assert.Nil(t, h.RefuseMutation(msg, apply))
+	})
+}
+
+func TestHelpers_HideMutations(t *testing.T) {
+	apply := key.NewBinding(key.WithKeys("a"))
+	state := key.NewBinding(key.WithKeys("s"))
+
+	h := &Helpers{ReadOnly: true}
+	got := h.HideMutations([]key.Binding{apply, state}, apply)
+	if assert.Len(t, got, 1) {
+		assert.Equal(t, []string{"s"}, got[0].Keys())
+	}
+
+	h = &Helpers{}
+	got = h.HideMutations([]key.Binding{apply, state}, apply)
+	assert.Len(t, got, 2)
+}
+
+func TestHelpers_ConfirmApply(t *testing.T) {
+	type applied struct{}
+	action := func() tea.Msg { return applied{} }
+
+	t.Run("confirm", func(t *testing.T) {
+		h := &Helpers{}
+
+		got, ok := h.ConfirmApply("Apply plan?", false, action)().(PromptMsg)
+		require.True(t, ok)
+		assert.Equal(t, "Apply plan? (y/N): ", got.Prompt)
+	})
+
+	t.Run("skip confirmation", func(t *testing.T) {
+		h := &Helpers{SkipApplyConfirm: true}
+
+		assert.Equal(t, applied{}, h.ConfirmApply("Apply plan?", false, action)())
+	})
+
+	t.Run("always confirm destroy", func(t *testing.T) {
+		h := &Helpers{SkipApplyConfirm: true}
+
+		_, ok := h.ConfirmApply("Destroy resources?", true, action)().(PromptMsg)
+		assert.True(t, ok)
+	})
+}
@@ -5,34 +5,42 @@ import (
 )
 
 func SanitizeColors(b []byte) []byte {
-	var (
-		ansi         bool
-		buf          = new(bytes.Buffer)
-		lastcolorseq = new(bytes.Buffer)
-	)
+	var s sanitizer
+	return s.sanitize(b)
+}
+
+// sanitizer ensures color sequences do not bleed across lines, by resetting
+// any color before each new line and re-starting it afterwards. It retains
+// state between calls so that content can be sanitized in successive chunks.
+type sanitizer struct {
+	ansi         bool
+	lastcolorseq []byte
+}
+
+func (s *sanitizer) sanitize(b []byte) []byte {
+	buf := new(bytes.Buffer)
 	for _, c := range b {
 		if c == '\x1B' {
-			ansi = true
-			lastcolorseq.Reset()
-			_ = lastcolorseq.WriteByte(c)
-		} else if ansi {
-			_ = lastcolorseq.WriteByte(c)
+			s.ansi = true
+			s.lastcolorseq = append(s.lastcolorseq[:0], c)
+		} else if s.ansi {
+			s.lastcolorseq = append(s.lastcolorseq, c)
 			if isTerminator(c) {
-				ansi = false
-				if bytes.HasSuffix(lastcolorseq.Bytes(), []byte("[0m")) {
+				s.ansi = false
+				if bytes.HasSuffix(s.lastcolorseq, []byte("[0m")) {
 					// reset sequence
-					lastcolorseq.Reset()
+					s.lastcolorseq = s.lastcolorseq[:0]
 				} else if c != 'm' {
 					// not a color code sequence
-					lastcolorseq.Reset()
+					s.lastcolorseq = s.lastcolorseq[:0]
 				}
 			}
-		} else if c == '\n' && lastcolorseq.Len() > 0 {
+		} else if c == '\n' && len(s.lastcolorseq) > 0 {
 			// reset color sequence before adding new line
 			buf.Write([]byte{'\x1B', '[', '0', 'm'})
 			buf.WriteByte(c)
 			// re-start color sequence on new line
-			buf.Write(lastcolorseq.Bytes())
+			buf.Write(s.lastcolorseq)
 			continue
 		}
 
@@ -41,6 +49,11 @@ func SanitizeColors(b []byte) []byte {
 	return buf.Bytes()
 }
 
+// clone returns a copy of the sanitizer that can be advanced independently.
+func (s *sanitizer) clone() *sanitizer {
+	return &sanitizer{ansi: s.ansi, lastcolorseq: bytes.Clone(s.lastcolorseq)}
+}
+
 func isTerminator(c byte) bool {
 	return (c >= 0x40 && c <= 0x5a) || (c >= 0x61 && c <= 0x7a)
 }
@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"golang.org/x/exp/maps"
+)
+
+var spinnerStyles = map[string]spinner.Spinner{
+	"line":      spinner.Line,
+	"dot":       spinner.Dot,
+	"minidot":   spinner.MiniDot,
+	"jump":      spinner.Jump,
+	"pulse":     spinner.Pulse,
+	"points":    spinner.Points,
+	"globe":     spinner.Globe,
+	"moon":      spinner.Moon,
+	"monkey":    spinner.Monkey,
+	"meter":     spinner.Meter,
+	"hamburger": spinner.Hamburger,
+	"ellipsis":  spinner.Ellipsis,
+}
+
+// NewSpinner constructs a spinner with the named style. If interval is
+// non-zero then it overrides the interval between frames of the style.
+func NewSpinner(style string, interval time.Duration) (spinner.Model, error) {
+	s, ok := spinnerStyles[style]
+	if !ok {
+		return spinner.Model{}, fmt.Errorf("invalid spinner style, must be one of: %v", maps.Keys(spinnerStyles))
+	}
+	if interval > 0 {
+		s.FPS = interval
+	}
+	return spinner.New(spinner.WithSpinner(s)), nil
+}
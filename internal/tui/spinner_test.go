@@ -0,0 +1,34 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSpinner(t *testing.T) {
+	t.Run("default interval", func(t *testing.T) {
+		got, err := NewSpinner("dot", 0)
+		require.NoError(t, err)
+
+		assert.Equal(t, spinner.Dot, got.Spinner)
+	})
+
+	t.Run("override interval", func(t *testing.T) {
+		got, err := NewSpinner("line", time.Second)
+		require.NoError(t, err)
+
+		assert.Equal(t, spinner.Line.Frames, got.Spinner.Frames)
+		assert.Equal(t, time.Second, got.Spinner.FPS)
+		// The style itself is left untouched.
+		assert.NotEqual(t, time.Second, spinner.Line.FPS)
+	})
+
+	t.Run("invalid style", func(t *testing.T) {
+		_, err := NewSpinner("bogus", 0)
+		assert.Error(t, err)
+	})
+}
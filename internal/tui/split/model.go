@@ -2,6 +2,7 @@ package split
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -117,6 +118,21 @@ func (m Model[R]) Update(msg tea.Msg) (Model[R], tea.Cmd) {
 			m.Table, cmd = m.Table.Update(msg)
 			cmds = append(cmds, cmd)
 		}
+	case tea.MouseMsg:
+		if msg.Y < m.listHeight() {
+			m.Table, cmd = m.Table.Update(msg)
+			cmds = append(cmds, cmd)
+		} else if m.previewVisible {
+			// Send mouse event to the preview model, with its position made
+			// relative to the preview pane, inside its top border.
+			row, ok := m.Table.CurrentRow()
+			if !ok {
+				break
+			}
+			msg.Y -= m.listHeight() + 1
+			cmd := m.cache.Update(row.ID, msg)
+			cmds = append(cmds, cmd)
+		}
 	case tea.WindowSizeMsg:
 		m.height = msg.Height
 		m.width = msg.Width
@@ -211,6 +227,26 @@ func (m *Model[R]) setBorderStyles() {
 	}
 }
 
+// ExportCSV writes the table in CSV format.
+func (m Model[R]) ExportCSV(w io.Writer) error {
+	return m.Table.ExportCSV(w)
+}
+
+// FilterValue returns the value of the table's filter.
+func (m Model[R]) FilterValue() string {
+	return m.Table.FilterValue()
+}
+
+// Loading returns true if the table is yet to load its items.
+func (m Model[R]) Loading() bool {
+	return m.Table.Loading()
+}
+
+// Pagination describes the page of the table currently visible.
+func (m Model[R]) Pagination() string {
+	return m.Table.Pagination()
+}
+
 func (m Model[R]) View() string {
 	components := []string{m.Table.View()}
 	// When preview pane is visible and there is a model cached for the
@@ -21,4 +21,8 @@ var (
 	TitleAddress   = Padded.Foreground(White).Background(Blue)
 	TitleSerial    = Padded.Foreground(Black).Background(Orange)
 	TitleTainted   = Padded.Foreground(White).Background(Red)
+	TitleTimeRange = Padded.Foreground(Black).Background(Orange)
+	TitleSearch    = Padded.Foreground(Black).Background(Yellow)
+
+	SearchMatch = Regular.Foreground(Black).Background(Yellow)
 )
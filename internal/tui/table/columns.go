@@ -6,6 +6,7 @@ var (
 		Title:          "MODULE",
 		TruncationFunc: TruncateLeft,
 		FlexFactor:     2,
+		MinWidth:       20,
 	}
 	WorkspaceColumn = Column{
 		Key:        "workspace",
@@ -0,0 +1,70 @@
+package table
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/tui"
+)
+
+// CopyFunc returns the string to copy to the clipboard for an item.
+type CopyFunc[V any] func(V) string
+
+// writeClipboard writes to the system clipboard. Overridden in tests.
+var writeClipboard = clipboard.WriteAll
+
+// WithCopyFunc permits the user to copy a string representation of the
+// selected rows, or the current row if none are selected, to the clipboard,
+// using the given func to produce the string for each row.
+func WithCopyFunc[V resource.Resource](fn CopyFunc[V]) Option[V] {
+	return func(m *Model[V]) {
+		m.copyFunc = fn
+	}
+}
+
+// copyRows copies the string representation of the selected rows, or the
+// current row if none are selected, to the clipboard, one row per line,
+// reporting what was copied.
+func (m Model[V]) copyRows() tea.Cmd {
+	if m.copyFunc == nil {
+		return nil
+	}
+	rows := m.SelectedOrCurrent()
+	if len(rows) == 0 {
+		return nil
+	}
+	// Copy rows in the order in which they're listed, with any selected rows
+	// hidden by the filter last.
+	order := make(map[resource.ID]int, len(m.rows))
+	for i, row := range m.rows {
+		order[row.ID] = i
+	}
+	position := func(row Row[V]) int {
+		if i, ok := order[row.ID]; ok {
+			return i
+		}
+		return len(m.rows)
+	}
+	slices.SortStableFunc(rows, func(i, j Row[V]) int {
+		return cmp.Compare(position(i), position(j))
+	})
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		lines[i] = m.copyFunc(row.Value)
+	}
+	s := strings.Join(lines, "\n")
+	return func() tea.Msg {
+		if err := writeClipboard(s); err != nil {
+			return tui.ErrorMsg(fmt.Errorf("copying to clipboard: %w", err))
+		}
+		if len(rows) > 1 {
+			return tui.InfoMsg(fmt.Sprintf("copied %d rows to clipboard", len(rows)))
+		}
+		return tui.InfoMsg(fmt.Sprintf("copied to clipboard: %s", s))
+	}
+}
@@ -0,0 +1,80 @@
+package table
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/tui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTable_Copy(t *testing.T) {
+	yank := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}}
+	setup := func(opts ...Option[testResource]) Model[testResource] {
+		renderer := func(v testResource) RenderedRow { return nil }
+		opts = append(opts, WithSortFunc(func(i, j testResource) int { return i.n - j.n }))
+		tbl := New(nil, renderer, 100, 20, opts...)
+		tbl.SetItems(resource0, resource1, resource2)
+		tbl.MoveDown(1)
+		return tbl
+	}
+	original := writeClipboard
+	t.Cleanup(func() { writeClipboard = original })
+	copyFunc := WithCopyFunc(func(v testResource) string { return strconv.Itoa(v.n) })
+
+	t.Run("copy current row", func(t *testing.T) {
+		var got string
+		writeClipboard = func(s string) error {
+			got = s
+			return nil
+		}
+		tbl := setup(copyFunc)
+
+		_, cmd := tbl.Update(yank)
+		require.NotNil(t, cmd)
+		assert.Equal(t, tui.InfoMsg("copied to clipboard: 1"), cmd())
+		assert.Equal(t, "1", got)
+	})
+
+	t.Run("clipboard error", func(t *testing.T) {
+		writeClipboard = func(s string) error {
+			return errors.New("no clipboard utility found")
+		}
+		tbl := setup(copyFunc)
+
+		_, cmd := tbl.Update(yank)
+		require.NotNil(t, cmd)
+		err, ok := cmd().(error)
+		require.True(t, ok)
+		assert.ErrorContains(t, err, "copying to clipboard")
+	})
+
+	t.Run("copy selected rows", func(t *testing.T) {
+		var got string
+		writeClipboard = func(s string) error {
+			got = s
+			return nil
+		}
+		tbl := setup(copyFunc)
+		tbl.GotoBottom()
+		tbl.ToggleSelection()
+		tbl.GotoTop()
+		tbl.ToggleSelection()
+
+		_, cmd := tbl.Update(yank)
+		require.NotNil(t, cmd)
+		assert.Equal(t, tui.InfoMsg("copied 2 rows to clipboard"), cmd())
+		// Rows are copied in the order in which they're listed.
+		assert.Equal(t, "0\n2", got)
+	})
+
+	t.Run("no copy func", func(t *testing.T) {
+		tbl := setup()
+
+		_, cmd := tbl.Update(yank)
+		assert.Nil(t, cmd)
+	})
+}
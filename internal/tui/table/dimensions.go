@@ -10,30 +10,76 @@ import "github.com/leg100/pug/internal/tui"
 
 // Update column widths in-place.
 //
-// TODO: unit test
+// The width available to flex columns is shared between them in proportion to
+// their flex factors. A column whose share is less than its minimum width is
+// set to its minimum, and the remaining width is shared between the other flex
+// columns. If the minimum widths of the flex columns exceed the available
+// width then each is shrunk in proportion to its minimum, but to no less than
+// minFlexWidth, beyond which the table is scrolled horizontally instead.
 func (m *Model[V]) setColumnWidths() {
 	var (
 		// total available flex width initialized to total table width minus the
 		// padding on each col (2) and the scrollbar to the right
-		totalFlexWidth  = m.width - tui.ScrollbarWidth - 2*len(m.cols)
-		totalFlexFactor int
-		flexGCD         int
+		totalFlexWidth = m.width - tui.ScrollbarWidth - 2*len(m.cols)
+		totalMinWidth  int
+		flex           []int
 	)
 
-	for _, col := range m.cols {
+	for i, col := range m.cols {
 		if col.FlexFactor == 0 {
 			// Column not using flex so subtract its width from avail width
 			totalFlexWidth -= col.Width
 		} else {
-			totalFlexFactor += col.FlexFactor
-			flexGCD = gcd(flexGCD, col.FlexFactor)
+			totalMinWidth += col.minWidth()
+			flex = append(flex, i)
 		}
 	}
 
-	if totalFlexFactor == 0 {
+	if len(flex) == 0 {
+		return
+	}
+
+	if totalMinWidth > totalFlexWidth {
+		for _, i := range flex {
+			minWidth := m.cols[i].minWidth()
+			m.cols[i].Width = max(minWidth*totalFlexWidth/totalMinWidth, min(minWidth, minFlexWidth))
+		}
 		return
 	}
 
+	for {
+		widths := m.flexWidths(flex, totalFlexWidth)
+		var unclamped []int
+		for j, i := range flex {
+			if widths[j] < m.cols[i].minWidth() {
+				m.cols[i].Width = m.cols[i].minWidth()
+				totalFlexWidth -= m.cols[i].Width
+			} else {
+				unclamped = append(unclamped, i)
+			}
+		}
+		if len(unclamped) == len(flex) {
+			for j, i := range flex {
+				m.cols[i].Width = widths[j]
+			}
+			return
+		}
+		flex = unclamped
+	}
+}
+
+// flexWidths shares the width between the flex columns with the given indices
+// in proportion to their flex factors.
+func (m *Model[V]) flexWidths(flex []int, totalFlexWidth int) []int {
+	var (
+		totalFlexFactor int
+		flexGCD         int
+	)
+	for _, i := range flex {
+		totalFlexFactor += m.cols[i].FlexFactor
+		flexGCD = gcd(flexGCD, m.cols[i].FlexFactor)
+	}
+
 	// We use the GCD here because otherwise very large values won't divide
 	// nicely as ints
 	totalFlexFactor /= flexGCD
@@ -41,25 +87,21 @@ func (m *Model[V]) setColumnWidths() {
 	flexUnit := totalFlexWidth / totalFlexFactor
 	leftoverWidth := totalFlexWidth % totalFlexFactor
 
-	for index := range m.cols {
-		if m.cols[index].FlexFactor == 0 {
-			continue
-		}
-
-		width := flexUnit * (m.cols[index].FlexFactor / flexGCD)
+	widths := make([]int, len(flex))
+	for j, i := range flex {
+		width := flexUnit * (m.cols[i].FlexFactor / flexGCD)
 
 		if leftoverWidth > 0 {
 			width++
 			leftoverWidth--
 		}
 
-		if index == len(m.cols)-1 {
+		if j == len(flex)-1 {
 			width += leftoverWidth
 			leftoverWidth = 0
 		}
 
-		width = max(width, 1)
-
-		m.cols[index].Width = width
+		widths[j] = width
 	}
+	return widths
 }
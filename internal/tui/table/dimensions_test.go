@@ -0,0 +1,46 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable_SetColumnWidths(t *testing.T) {
+	cols := []Column{
+		{Key: "fixed", Title: "FIXED", Width: 5},
+		{Key: "a", Title: "A", FlexFactor: 2, MinWidth: 30},
+		{Key: "b", Title: "B", FlexFactor: 1},
+		{Key: "c", Title: "C", FlexFactor: 1, MinWidth: 4},
+	}
+	// The table is 2 wider than the width available to columns, to
+	// accommodate the borders, and the columns share the width remaining
+	// after the scrollbar, their padding, and the fixed column.
+	available := func(flexWidth int) int {
+		return flexWidth + 2 + 1 + 2*len(cols) + 5
+	}
+
+	tests := []struct {
+		name      string
+		flexWidth int
+		want      []int
+	}{
+		{"proportional", 120, []int{60, 30, 30}},
+		{"leftover width", 122, []int{61, 31, 30}},
+		{"clamp to minimum", 60, []int{30, 15, 15}},
+		{"share remainder after clamping", 50, []int{30, 10, 10}},
+		{"minimums exactly fit", 44, []int{30, 10, 4}},
+		{"shrink minimums proportionally", 22, []int{15, 10, 4}},
+		{"shrink no further than minimum flex width", 0, []int{10, 10, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer := func(v testResource) RenderedRow { return nil }
+			tbl := New(cols, renderer, available(tt.flexWidth), 10)
+
+			got := []int{tbl.cols[1].Width, tbl.cols[2].Width, tbl.cols[3].Width}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, 5, tbl.cols[0].Width)
+		})
+	}
+}
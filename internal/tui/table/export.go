@@ -0,0 +1,36 @@
+package table
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// ExportCSV writes the table in CSV format, with a header row of column
+// titles followed by a line for each row. Only rows that are currently
+// visible are written, i.e. those that match the filter, in the order in
+// which they are displayed. Rows are rendered afresh, because the cached
+// rendering of rows out of view may be stale.
+func (m Model[V]) ExportCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	record := make([]string, len(m.cols))
+	for i, col := range m.cols {
+		record[i] = col.Title
+	}
+	if err := writer.Write(record); err != nil {
+		return err
+	}
+	for _, row := range m.rows {
+		if row.header {
+			continue
+		}
+		cells := stripAnsi(m.rowRenderer(row.Value))
+		for i, col := range m.cols {
+			record[i] = cells[col.Key]
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
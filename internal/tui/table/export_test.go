@@ -0,0 +1,72 @@
+package table
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTable_ExportCSV(t *testing.T) {
+	cols := []Column{
+		{Key: "n", Title: "NUMBER"},
+		{Key: "parity", Title: "PARITY"},
+	}
+	renderer := func(v testResource) RenderedRow {
+		parity := "even"
+		if v.n%2 == 1 {
+			parity = "odd"
+		}
+		return RenderedRow{
+			"n":      strconv.Itoa(v.n),
+			"parity": lipgloss.NewStyle().Bold(true).Render(parity),
+		}
+	}
+	tbl := New(cols, renderer, 100, 20,
+		// Sort in descending order
+		WithSortFunc(func(i, j testResource) int { return j.n - i.n }),
+		WithDefaultFilter[testResource]("odd"),
+	)
+	tbl.SetItems(resource0, resource1, resource2, resource3, resource4, resource5)
+
+	var buf bytes.Buffer
+	require.NoError(t, tbl.ExportCSV(&buf))
+
+	want := `NUMBER,PARITY
+5,odd
+3,odd
+1,odd
+`
+	assert.Equal(t, want, buf.String())
+}
+
+func TestTable_ExportCSV_RowsOutOfView(t *testing.T) {
+	cols := []Column{{Key: "age", Title: "AGE"}}
+	age := "1s"
+	renderer := func(v testResource) RenderedRow {
+		return RenderedRow{"age": age}
+	}
+	items := make([]testResource, 20)
+	for i := range items {
+		items[i] = testResource{n: i, ID: resource.NewID(resource.Workspace)}
+	}
+	tbl := New(cols, renderer, 100, 5)
+	tbl.SetItems(items...)
+	require.Less(t, tbl.visibleRows(), len(items))
+
+	// Rows out of view are not re-rendered, leaving their cached content
+	// stale, but the export renders every row afresh.
+	age = "2s"
+	tbl.Rerender(func(testResource) bool { return true })
+
+	var buf bytes.Buffer
+	require.NoError(t, tbl.ExportCSV(&buf))
+
+	want := "AGE\n" + strings.Repeat("2s\n", len(items))
+	assert.Equal(t, want, buf.String())
+}
@@ -0,0 +1,171 @@
+package table
+
+import (
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/resource"
+	"golang.org/x/exp/maps"
+)
+
+// filterDebounce is how long after the last key is typed into the filter that
+// rows are filtered, so that rows are filtered once rather than for each key
+// typed in quick succession.
+const filterDebounce = 100 * time.Millisecond
+
+// filterSeq uniquely identifies each key typed into the filter, so that rows are
+// only filtered if no further key has been typed since, and only the rows of
+// the table that scheduled it.
+var filterSeq atomic.Int64
+
+// filterMsg filters rows once the filter has stopped being typed into.
+type filterMsg struct {
+	seq int64
+}
+
+// debounceFilter schedules filtering rows with the current value of the
+// filter.
+func (m *Model[V]) debounceFilter() tea.Cmd {
+	seq := filterSeq.Add(1)
+	m.filterSeq = seq
+	return tea.Tick(filterDebounce, func(time.Time) tea.Msg {
+		return filterMsg{seq: seq}
+	})
+}
+
+// applyFilter filters rows with the current value of the filter, cancelling
+// any scheduled filtering.
+func (m *Model[V]) applyFilter() {
+	m.filterSeq = 0
+	m.setRows(maps.Values(m.items)...)
+}
+
+// filterTerm is a space-separated term in the filter value. A term of the form
+// <column>:<value> matches only the cell of that column, where <column> is
+// either the key or the title of the column, e.g. status:errored. Any other
+// term matches any cell.
+type filterTerm struct {
+	// column is the key of the column to which matching is restricted. Empty
+	// if the term matches any cell.
+	column ColumnKey
+	value  string
+	// re, if non-nil, is matched against cells instead of value.
+	re *regexp.Regexp
+}
+
+// FilterValue returns the value of the filter.
+func (m Model[V]) FilterValue() string {
+	return m.filter.Value()
+}
+
+// parseFilter parses a filter value into terms. A term with an unknown column
+// is treated as a term matching any cell, to match the value as it was typed.
+func (m *Model[V]) parseFilter(value string) []filterTerm {
+	if m.regex {
+		return m.parseRegexFilter(value)
+	}
+	fields := strings.Fields(value)
+	terms := make([]filterTerm, len(fields))
+	for i, field := range fields {
+		terms[i] = filterTerm{value: field}
+		name, v, ok := strings.Cut(field, ":")
+		if !ok {
+			continue
+		}
+		if column, ok := m.lookupColumn(name); ok {
+			terms[i] = filterTerm{column: column, value: v}
+		}
+	}
+	return terms
+}
+
+// parseRegexFilter parses a filter value into a single term matching a
+// regular expression, which may be prefixed with a column in the same manner
+// as other terms. If the regular expression is invalid, which is common whilst
+// it is being typed, then the last valid term is returned instead, and the
+// filter is marked invalid.
+func (m *Model[V]) parseRegexFilter(value string) []filterTerm {
+	m.filterInvalid = false
+	if value == "" {
+		m.lastRegexTerm = nil
+		return nil
+	}
+	var term filterTerm
+	if name, v, ok := strings.Cut(value, ":"); ok {
+		if column, ok := m.lookupColumn(name); ok {
+			term.column = column
+			value = v
+		}
+	}
+	re, err := regexp.Compile(value)
+	if err != nil {
+		m.filterInvalid = true
+		if m.lastRegexTerm == nil {
+			return nil
+		}
+		return []filterTerm{*m.lastRegexTerm}
+	}
+	term.re = re
+	m.lastRegexTerm = &term
+	return []filterTerm{term}
+}
+
+// lookupColumn returns the key of the column with the given key or title,
+// including hidden columns.
+func (m *Model[V]) lookupColumn(name string) (ColumnKey, bool) {
+	for _, col := range m.allCols {
+		if name == string(col.Key) || strings.EqualFold(name, col.Title) {
+			return col.Key, true
+		}
+	}
+	return "", false
+}
+
+// matchFilter returns true if the item with the given ID matches every term.
+// If fuzzy matching is enabled then a score is also returned, summing the best
+// score for each term.
+func (m *Model[V]) matchFilter(id resource.ID, terms []filterTerm) (int, bool) {
+	var total int
+	for _, term := range terms {
+		score, ok := m.matchTerm(m.filterable[id], term)
+		if !ok {
+			return 0, false
+		}
+		total += score
+	}
+	return total, true
+}
+
+func (m *Model[V]) matchTerm(cells RenderedRow, term filterTerm) (int, bool) {
+	var (
+		best    int
+		matched bool
+	)
+	for key, cell := range cells {
+		if term.column != "" && key != term.column {
+			continue
+		}
+		if term.re != nil {
+			if term.re.MatchString(cell) {
+				return 0, true
+			}
+			continue
+		}
+		if !m.fuzzy {
+			if strings.Contains(cell, term.value) {
+				return 0, true
+			}
+			continue
+		}
+		if score, ok := fuzzyMatch(term.value, cell); ok {
+			if !matched || score > best {
+				best = score
+			}
+			matched = true
+		}
+	}
+	return best, matched
+}
@@ -0,0 +1,122 @@
+package table
+
+import (
+	"strconv"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/tui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
+)
+
+func TestTable_ColumnFilter(t *testing.T) {
+	cols := []Column{
+		{Key: "module", Title: "MODULE"},
+		{Key: "task_status", Title: "STATUS"},
+	}
+	cells := map[resource.ID]RenderedRow{
+		resource0.ID: {"module": "errored", "task_status": "exited"},
+		resource1.ID: {"module": "vpc", "task_status": "errored"},
+		resource2.ID: {"module": "vpc", "task_status": "exited"},
+		resource3.ID: {"module": "eks", "task_status": "errored"},
+	}
+	renderer := func(v testResource) RenderedRow { return cells[v.ID] }
+
+	tests := []struct {
+		name   string
+		filter string
+		want   []testResource
+	}{
+		{"bare term matches any column", "errored", []testResource{resource0, resource1, resource3}},
+		{"column key", "task_status:errored", []testResource{resource1, resource3}},
+		{"column title", "status:errored", []testResource{resource1, resource3}},
+		{"column title is case insensitive", "STATUS:errored", []testResource{resource1, resource3}},
+		{"terms are ANDed", "status:errored vpc", []testResource{resource1}},
+		{"unknown column is a bare term", "foo:errored", nil},
+		{"empty column value", "status:", []testResource{resource0, resource1, resource2, resource3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl := New(cols, renderer, 100, 20,
+				WithSortFunc(func(i, j testResource) int { return i.n - j.n }),
+				WithDefaultFilter[testResource](tt.filter),
+			)
+			tbl.SetItems(resource0, resource1, resource2, resource3)
+
+			var got []testResource
+			for _, row := range tbl.rows {
+				got = append(got, row.Value)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTable_SelectAllMatchingFilter(t *testing.T) {
+	renderer := func(v testResource) RenderedRow {
+		if v.n%2 == 0 {
+			return RenderedRow{"status": "errored"}
+		}
+		return RenderedRow{"status": "exited"}
+	}
+	tbl := New(nil, renderer, 100, 20, WithDefaultFilter[testResource]("errored"))
+	tbl.SetItems(resource0, resource1, resource2, resource3, resource4, resource5)
+
+	tbl.SelectAll()
+	want := []resource.ID{resource0.ID, resource2.ID, resource4.ID}
+	assert.ElementsMatch(t, want, maps.Keys(tbl.selected))
+
+	// Selection survives clearing the filter.
+	tbl, _ = tbl.Update(tui.FilterCloseMsg{})
+	assert.Len(t, tbl.rows, 6)
+	assert.ElementsMatch(t, want, maps.Keys(tbl.selected))
+}
+
+func TestTable_FilterDebounce(t *testing.T) {
+	renderer := func(v testResource) RenderedRow {
+		return RenderedRow{"n": strconv.Itoa(v.n)}
+	}
+	setup := func(t *testing.T) (Model[testResource], []filterMsg) {
+		tbl := New(nil, renderer, 0, 0)
+		tbl.SetItems(resource0, resource1, resource2, resource3, resource4, resource5)
+		tbl, _ = tbl.Update(tui.FilterFocusReqMsg{})
+
+		// Type "3" then "1", collecting the scheduled filter messages.
+		var msgs []filterMsg
+		for _, r := range "31" {
+			var cmd tea.Cmd
+			tbl, cmd = tbl.Update(tui.FilterKeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+			require.NotNil(t, cmd)
+			msgs = append(msgs, filterMsg{seq: tbl.filterSeq})
+		}
+		// The input is updated with each key but rows are yet to be filtered.
+		assert.Equal(t, "31", tbl.FilterValue())
+		assert.Len(t, tbl.rows, 6)
+		return tbl, msgs
+	}
+
+	t.Run("only filter after last key", func(t *testing.T) {
+		tbl, msgs := setup(t)
+
+		tbl, _ = tbl.Update(msgs[0])
+		assert.Len(t, tbl.rows, 6)
+
+		tbl, _ = tbl.Update(msgs[1])
+		assert.Len(t, tbl.rows, 0)
+	})
+
+	t.Run("filter immediately upon blur", func(t *testing.T) {
+		tbl, msgs := setup(t)
+
+		tbl, _ = tbl.Update(tui.FilterBlurMsg{})
+		assert.Len(t, tbl.rows, 0)
+
+		// Scheduled filtering is cancelled.
+		assert.Zero(t, tbl.filterSeq)
+		tbl, _ = tbl.Update(msgs[1])
+		assert.Len(t, tbl.rows, 0)
+	})
+}
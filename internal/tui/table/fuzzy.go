@@ -0,0 +1,77 @@
+package table
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Scores awarded when fuzzy matching, modelled on those of the sahilm/fuzzy
+// package.
+const (
+	firstCharMatchBonus            = 10
+	matchFollowingSeparatorBonus   = 20
+	camelCaseMatchBonus            = 20
+	adjacentMatchBonus             = 5
+	unmatchedLeadingCharPenalty    = -5
+	maxUnmatchedLeadingCharPenalty = -15
+	unmatchedCharPenalty           = -1
+)
+
+// fuzzyMatch determines whether the characters of pattern appear in str in
+// the same order, ignoring case, e.g. "prdnet" matches "prod/networking". If
+// there is a match then a score is returned, with a higher score indicating a
+// better match: matches at the start of words and runs of consecutive matches
+// score higher.
+func fuzzyMatch(pattern, str string) (int, bool) {
+	needle := []rune(strings.ToLower(pattern))
+	if len(needle) == 0 {
+		return 0, true
+	}
+	var (
+		haystack   = []rune(str)
+		score      int
+		matched    int
+		firstMatch = -1
+		prevMatch  bool
+	)
+	for i, r := range haystack {
+		if matched == len(needle) {
+			break
+		}
+		if unicode.ToLower(r) != needle[matched] {
+			prevMatch = false
+			continue
+		}
+		if firstMatch < 0 {
+			firstMatch = i
+		}
+		switch {
+		case i == 0:
+			score += firstCharMatchBonus
+		case isSeparator(haystack[i-1]):
+			score += matchFollowingSeparatorBonus
+		case unicode.IsUpper(r) && unicode.IsLower(haystack[i-1]):
+			score += camelCaseMatchBonus
+		}
+		if prevMatch {
+			score += adjacentMatchBonus
+		}
+		prevMatch = true
+		matched++
+	}
+	if matched < len(needle) {
+		return 0, false
+	}
+	score += max(firstMatch*unmatchedLeadingCharPenalty, maxUnmatchedLeadingCharPenalty)
+	score += (len(haystack) - matched) * unmatchedCharPenalty
+	return score, true
+}
+
+func isSeparator(r rune) bool {
+	switch r {
+	case '/', '-', '_', '.', ' ', ':':
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,46 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		str     string
+		want    bool
+	}{
+		{"empty pattern", "", "prod/networking", true},
+		{"exact", "prod/networking", "prod/networking", true},
+		{"subsequence", "prdnet", "prod/networking", true},
+		{"case insensitive", "PRDNET", "prod/networking", true},
+		{"out of order", "netprd", "prod/networking", false},
+		{"missing character", "prodx", "prod/networking", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, got := fuzzyMatch(tt.pattern, tt.str)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFuzzyMatch_Ranking(t *testing.T) {
+	score := func(pattern, str string) int {
+		t.Helper()
+
+		score, ok := fuzzyMatch(pattern, str)
+		assert.True(t, ok)
+		return score
+	}
+
+	// Matches at the start of words rank higher than matches mid-word.
+	assert.Greater(t, score("pn", "prod/networking"), score("pn", "sharpening"))
+	// Consecutive matches rank higher than scattered matches.
+	assert.Greater(t, score("net", "prod/networking"), score("net", "prod/nested-tables"))
+	// Tighter matches rank higher than matches in longer strings.
+	assert.Greater(t, score("vpc", "vpc"), score("vpc", "vpc/endpoints"))
+}
@@ -0,0 +1,113 @@
+package table
+
+import (
+	"fmt"
+
+	"github.com/leg100/go-runewidth"
+	"github.com/leg100/pug/internal/tui"
+	"golang.org/x/exp/maps"
+)
+
+// GroupFunc returns the name of the group to which an item belongs.
+type GroupFunc[V any] func(V) string
+
+// groupIndent indents the rows beneath a group header.
+const groupIndent = "  "
+
+// SetGroupFunc groups rows by the name fn returns for each item, with a header
+// row heading each group. Groups are listed in the order in which their first
+// row is sorted. Set fn to nil to list rows without grouping them.
+func (m *Model[V]) SetGroupFunc(fn GroupFunc[V]) {
+	m.groupFunc = fn
+	m.collapsed = make(map[string]bool)
+	m.setRows(maps.Values(m.items)...)
+}
+
+// Grouped returns true if rows are grouped.
+func (m Model[V]) Grouped() bool {
+	return m.groupFunc != nil
+}
+
+// ToggleGroup collapses the group of the current row, hiding the rows beneath
+// its header, or expands the group if it is already collapsed.
+func (m *Model[V]) ToggleGroup() {
+	if m.groupFunc == nil || m.currentRowIndex < 0 || m.currentRowIndex >= len(m.rows) {
+		return
+	}
+	group := m.rows[m.currentRowIndex].group
+	m.collapsed[group] = !m.collapsed[group]
+	// Make the group's header the current row, because the current row may be
+	// about to be hidden.
+	m.currentHeader = true
+	m.currentGroup = group
+	m.setRows(maps.Values(m.items)...)
+}
+
+// groupRows groups the sorted rows, inserting a header row above each group,
+// and omitting the rows of collapsed groups.
+func (m *Model[V]) groupRows(rows []Row[V]) []Row[V] {
+	var (
+		names  []string
+		groups = make(map[string][]Row[V])
+	)
+	for _, row := range rows {
+		name := m.groupFunc(row.Value)
+		if _, ok := groups[name]; !ok {
+			names = append(names, name)
+		}
+		row.group = name
+		groups[name] = append(groups[name], row)
+	}
+	grouped := make([]Row[V], 0, len(rows)+len(names))
+	for _, name := range names {
+		grouped = append(grouped, Row[V]{
+			group:  name,
+			header: true,
+			size:   len(groups[name]),
+		})
+		if !m.collapsed[name] {
+			grouped = append(grouped, groups[name]...)
+		}
+	}
+	return grouped
+}
+
+// navigable returns true if the cursor may rest on the row. The cursor skips
+// over the header of an expanded group, but rests on the header of a collapsed
+// group in order that it can be expanded.
+func (m Model[V]) navigable(row Row[V]) bool {
+	return !row.header || m.collapsed[row.group]
+}
+
+// isCurrent returns true if the row is the one last made the current row.
+func (m Model[V]) isCurrent(row Row[V]) bool {
+	if row.header {
+		return m.currentHeader && row.group == m.currentGroup
+	}
+	return !m.currentHeader && row.ID == m.currentRowID
+}
+
+// setCurrentRow makes the row at the given index the current row.
+func (m *Model[V]) setCurrentRow(i int) {
+	row := m.rows[i]
+	m.currentRowIndex = i
+	m.currentRowID = row.ID
+	m.currentHeader = row.header
+	m.currentGroup = row.group
+}
+
+// renderHeader renders the header of a group, along with the number of rows in
+// the group, and an indicator of whether it is collapsed.
+func (m Model[V]) renderHeader(row Row[V], current bool) string {
+	indicator := "▾"
+	if m.collapsed[row.group] {
+		indicator = "▸"
+	}
+	width := max(0, m.width-tui.ScrollbarWidth)
+	content := fmt.Sprintf(" %s %s (%d)", indicator, row.group, row.size)
+	style := tui.Bold.Width(width)
+	if current {
+		style = style.Foreground(tui.CurrentForeground).Background(tui.CurrentBackground)
+	}
+	return style.Render(runewidth.Truncate(content, width, "…"))
+}
@@ -0,0 +1,114 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/leg100/pug/internal/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupGroupTest sets up a table with its rows grouped into even and odd
+// numbered rows.
+func setupGroupTest() Model[testResource] {
+	tbl := setupTest()
+	tbl.SetGroupFunc(func(v testResource) string {
+		if v.n%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	})
+	return tbl
+}
+
+// rowSummary summarises rows, with the name of each header and the number of
+// each item.
+func rowSummary(rows []Row[testResource]) []any {
+	summary := make([]any, len(rows))
+	for i, row := range rows {
+		if row.header {
+			summary[i] = row.group
+		} else {
+			summary[i] = row.Value.n
+		}
+	}
+	return summary
+}
+
+func TestTable_Group(t *testing.T) {
+	tbl := setupGroupTest()
+
+	assert.Equal(t, []any{"even", 0, 2, 4, "odd", 1, 3, 5}, rowSummary(tbl.rows))
+
+	// The cursor starts on the first item rather than the first header.
+	got, ok := tbl.CurrentRow()
+	require.True(t, ok)
+	assert.Equal(t, resource0, got.Value)
+
+	// The cursor skips over headers.
+	tbl.MoveDown(3)
+	got, ok = tbl.CurrentRow()
+	require.True(t, ok)
+	assert.Equal(t, resource1, got.Value)
+
+	tbl.MoveUp(1)
+	got, ok = tbl.CurrentRow()
+	require.True(t, ok)
+	assert.Equal(t, resource4, got.Value)
+
+	tbl.GotoTop()
+	got, ok = tbl.CurrentRow()
+	require.True(t, ok)
+	assert.Equal(t, resource0, got.Value)
+}
+
+func TestTable_GroupSelectAll(t *testing.T) {
+	tbl := setupGroupTest()
+
+	tbl.SelectAll()
+
+	assert.Len(t, tbl.selected, 6)
+	assert.NotContains(t, tbl.selected, resource.ID{})
+}
+
+func TestTable_GroupCollapse(t *testing.T) {
+	tbl := setupGroupTest()
+	tbl.MoveDown(1)
+
+	// Collapsing the group makes its header the current row.
+	tbl.ToggleGroup()
+	assert.Equal(t, []any{"even", "odd", 1, 3, 5}, rowSummary(tbl.rows))
+	assert.Equal(t, 0, tbl.currentRowIndex)
+	_, ok := tbl.CurrentRow()
+	assert.False(t, ok, "header is not an item")
+
+	// The cursor rests on the header of a collapsed group.
+	tbl.MoveDown(1)
+	tbl.MoveUp(1)
+	assert.Equal(t, 0, tbl.currentRowIndex)
+
+	// Expanding the group makes its first item the current row.
+	tbl.ToggleGroup()
+	assert.Equal(t, []any{"even", 0, 2, 4, "odd", 1, 3, 5}, rowSummary(tbl.rows))
+	got, ok := tbl.CurrentRow()
+	require.True(t, ok)
+	assert.Equal(t, resource0, got.Value)
+}
+
+func TestTable_GroupRemoveItem(t *testing.T) {
+	tbl := setupGroupTest()
+
+	tbl.removeItem(resource1)
+	tbl.removeItem(resource3)
+	tbl.removeItem(resource5)
+
+	assert.Equal(t, []any{"even", 0, 2, 4}, rowSummary(tbl.rows))
+}
+
+func TestTable_Ungroup(t *testing.T) {
+	tbl := setupGroupTest()
+
+	tbl.SetGroupFunc(nil)
+
+	assert.Equal(t, []any{0, 1, 2, 3, 4, 5}, rowSummary(tbl.rows))
+}
@@ -0,0 +1,87 @@
+package table
+
+import (
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/tui"
+	"github.com/leg100/pug/internal/tui/keys"
+)
+
+// jumpTimeout is how long after the last key is typed that the jump prefix is
+// reset.
+const jumpTimeout = time.Second
+
+// jumpSeq uniquely identifies each key typed whilst jumping, so that a timeout
+// only resets the prefix if no further key has been typed since, and only
+// resets the prefix of the table that scheduled it.
+var jumpSeq atomic.Int64
+
+// jumpTimeoutMsg resets the jump prefix once the timeout has elapsed.
+type jumpTimeoutMsg struct {
+	seq int64
+}
+
+// handleJump handles jump related messages, returning false if the message is
+// not one of them.
+func (m *Model[V]) handleJump(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case tui.JumpReqMsg:
+		m.jumping = true
+		m.jumpPrefix = ""
+		// Acknowledge the request with a non-nil command.
+		return func() tea.Msg { return nil }, true
+	case tui.JumpCloseMsg:
+		m.jumping = false
+		m.jumpPrefix = ""
+		return nil, true
+	case tui.JumpKeyMsg:
+		kmsg := tea.KeyMsg(msg)
+		switch {
+		case kmsg.Type == tea.KeyRunes:
+			m.jumpPrefix += string(kmsg.Runes)
+		case key.Matches(kmsg, keys.Jump.Backspace):
+			if runes := []rune(m.jumpPrefix); len(runes) > 0 {
+				m.jumpPrefix = string(runes[:len(runes)-1])
+			}
+		default:
+			return nil, true
+		}
+		m.JumpTo(m.jumpPrefix)
+		seq := jumpSeq.Add(1)
+		m.jumpSeq = seq
+		return tea.Tick(jumpTimeout, func(time.Time) tea.Msg {
+			return jumpTimeoutMsg{seq: seq}
+		}), true
+	case jumpTimeoutMsg:
+		if msg.seq == m.jumpSeq {
+			m.jumpPrefix = ""
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
+// JumpTo makes the first row whose first column begins with prefix the current
+// row, ignoring case. Returns false if no row matches, in which case the
+// current row is unchanged.
+func (m *Model[V]) JumpTo(prefix string) bool {
+	if prefix == "" || len(m.cols) == 0 {
+		return false
+	}
+	prefix = strings.ToLower(prefix)
+	for i, row := range m.rows {
+		if row.header {
+			continue
+		}
+		cell := m.filterable[row.ID][m.cols[0].Key]
+		if strings.HasPrefix(strings.ToLower(cell), prefix) {
+			m.moveCurrentRow(i - m.currentRowIndex)
+			return true
+		}
+	}
+	return false
+}
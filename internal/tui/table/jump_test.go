@@ -0,0 +1,94 @@
+package table
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/tui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupJumpTest() Model[testResource] {
+	cols := []Column{
+		{Key: "module", Title: "MODULE"},
+		{Key: "workspace", Title: "WORKSPACE"},
+	}
+	cells := map[resource.ID]RenderedRow{
+		resource0.ID: {"module": "app", "workspace": "vpc"},
+		resource1.ID: {"module": "eks", "workspace": "dev"},
+		resource2.ID: {"module": "VPC", "workspace": "dev"},
+		resource3.ID: {"module": "vpc-peering", "workspace": "dev"},
+	}
+	renderer := func(v testResource) RenderedRow { return cells[v.ID] }
+	tbl := New(cols, renderer, 100, 20,
+		WithSortFunc(func(i, j testResource) int { return i.n - j.n }),
+	)
+	tbl.SetItems(resource0, resource1, resource2, resource3)
+	return tbl
+}
+
+func TestTable_JumpTo(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		want   int
+		found  bool
+	}{
+		{"matches first column only", "e", 1, true},
+		{"first matching row wins ignoring case", "vpc", 2, true},
+		{"longer prefix", "vpc-", 3, true},
+		{"no match leaves cursor", "dev", 0, false},
+		{"empty prefix leaves cursor", "", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl := setupJumpTest()
+
+			assert.Equal(t, tt.found, tbl.JumpTo(tt.prefix))
+			assert.Equal(t, tt.want, tbl.currentRowIndex)
+		})
+	}
+}
+
+func TestTable_Jump(t *testing.T) {
+	tbl := setupJumpTest()
+	typeKey := func(k tea.KeyMsg) tea.Cmd {
+		var cmd tea.Cmd
+		tbl, cmd = tbl.Update(tui.JumpKeyMsg(k))
+		return cmd
+	}
+	runes := func(s string) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+
+	tbl, cmd := tbl.Update(tui.JumpReqMsg{})
+	require.NotNil(t, cmd, "jump request should be acknowledged")
+
+	require.NotNil(t, typeKey(runes("v")))
+	require.NotNil(t, typeKey(runes("p")))
+	require.NotNil(t, typeKey(runes("c")))
+	require.NotNil(t, typeKey(runes("-")))
+	assert.Equal(t, "vpc-", tbl.jumpPrefix)
+	assert.Equal(t, 3, tbl.currentRowIndex)
+
+	// Backspace removes last character and jumps to first row matching the
+	// shorter prefix.
+	typeKey(tea.KeyMsg{Type: tea.KeyBackspace})
+	assert.Equal(t, "vpc", tbl.jumpPrefix)
+	assert.Equal(t, 2, tbl.currentRowIndex)
+
+	// A stale timeout leaves the prefix intact.
+	tbl, _ = tbl.Update(jumpTimeoutMsg{seq: tbl.jumpSeq - 1})
+	assert.Equal(t, "vpc", tbl.jumpPrefix)
+
+	// The latest timeout resets the prefix.
+	tbl, _ = tbl.Update(jumpTimeoutMsg{seq: tbl.jumpSeq})
+	assert.Equal(t, "", tbl.jumpPrefix)
+
+	// Closing stops jumping, leaving the cursor where it is.
+	tbl, _ = tbl.Update(tui.JumpCloseMsg{})
+	assert.False(t, tbl.jumping)
+	assert.Equal(t, 2, tbl.currentRowIndex)
+}
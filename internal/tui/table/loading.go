@@ -0,0 +1,40 @@
+package table
+
+import (
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/tui"
+)
+
+// WithLoading shows a spinner in place of rows until the table receives its
+// initial items via a BulkInsertMsg, to distinguish a table that is loading
+// from a table with no items.
+func WithLoading[V resource.Resource](spinner *spinner.Model) Option[V] {
+	return func(m *Model[V]) {
+		m.loading = true
+		m.spinner = spinner
+	}
+}
+
+// Loading returns true if the table is yet to receive its initial items.
+func (m Model[V]) Loading() bool {
+	return m.loading
+}
+
+// placeholder returns the message shown in place of rows when there are no
+// rows to show, explaining why.
+func (m Model[V]) placeholder() string {
+	var msg string
+	switch {
+	case m.loading:
+		msg = "loading…"
+		if m.spinner != nil {
+			msg = m.spinner.View() + " " + msg
+		}
+	case len(m.items) == 0:
+		msg = "No items"
+	default:
+		msg = "No items match"
+	}
+	return tui.Regular.Foreground(tui.LightGrey).Padding(0, 1).Render(msg)
+}
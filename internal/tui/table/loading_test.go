@@ -0,0 +1,47 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/leg100/pug/internal"
+	"github.com/leg100/pug/internal/tui"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable_Loading(t *testing.T) {
+	s := spinner.New(spinner.WithSpinner(spinner.Line))
+	renderer := func(v testResource) RenderedRow { return nil }
+
+	t.Run("loading", func(t *testing.T) {
+		tbl := New(nil, renderer, 40, 10, WithLoading[testResource](&s))
+
+		assert.True(t, tbl.Loading())
+		assert.Contains(t, internal.StripAnsi(tbl.View()), "| loading…")
+	})
+
+	t.Run("loaded no items", func(t *testing.T) {
+		tbl := New(nil, renderer, 40, 10, WithLoading[testResource](&s))
+		tbl, _ = tbl.Update(BulkInsertMsg[testResource]{})
+
+		assert.False(t, tbl.Loading())
+		assert.Contains(t, internal.StripAnsi(tbl.View()), "No items")
+		assert.NotContains(t, internal.StripAnsi(tbl.View()), "loading")
+	})
+
+	t.Run("loaded items", func(t *testing.T) {
+		tbl := New(nil, renderer, 40, 10, WithLoading[testResource](&s))
+		tbl, _ = tbl.Update(BulkInsertMsg[testResource]{resource0, resource1})
+
+		assert.False(t, tbl.Loading())
+		assert.NotContains(t, internal.StripAnsi(tbl.View()), "No items")
+	})
+
+	t.Run("no items match filter", func(t *testing.T) {
+		tbl := New(nil, renderer, 40, 10, WithLoading[testResource](&s))
+		tbl, _ = tbl.Update(BulkInsertMsg[testResource]{resource0, resource1})
+		tbl, _ = tbl.Update(tui.FilterSetMsg("nothing"))
+
+		assert.Contains(t, internal.StripAnsi(tbl.View()), "No items match")
+	})
+}
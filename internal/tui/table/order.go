@@ -0,0 +1,82 @@
+package table
+
+import (
+	"slices"
+
+	"github.com/leg100/pug/internal/resource"
+)
+
+// WithColumnOrder places the columns with the given keys first, in the given
+// order. The remaining columns retain their relative order. Keys that don't
+// match a column are ignored.
+func WithColumnOrder[V resource.Resource](keys ...string) Option[V] {
+	return func(m *Model[V]) {
+		m.cols = orderColumns(m.cols, keys...)
+	}
+}
+
+func orderColumns(cols []Column, keys ...string) []Column {
+	ordered := make([]Column, 0, len(cols))
+	for _, key := range keys {
+		if i := slices.IndexFunc(cols, func(col Column) bool {
+			return string(col.Key) == key
+		}); i >= 0 && !slices.ContainsFunc(ordered, func(col Column) bool {
+			return string(col.Key) == key
+		}) {
+			ordered = append(ordered, cols[i])
+		}
+	}
+	for _, col := range cols {
+		if !slices.ContainsFunc(ordered, func(ordered Column) bool {
+			return ordered.Key == col.Key
+		}) {
+			ordered = append(ordered, col)
+		}
+	}
+	return ordered
+}
+
+// SelectColumn changes the active column by the given delta, wrapping around
+// either end of the table. If there is no active column then the first
+// column is made active.
+func (m *Model[V]) SelectColumn(delta int) {
+	if len(m.cols) == 0 {
+		return
+	}
+	if m.activeColumn < 0 {
+		m.activeColumn = 0
+	} else {
+		m.activeColumn = (m.activeColumn + delta + len(m.cols)) % len(m.cols)
+	}
+	m.scrollToColumn(m.activeColumn)
+}
+
+// MoveColumn moves the active column by the given delta, swapping it with its
+// neighbouring column. The column cannot be moved beyond either end of the
+// table.
+func (m *Model[V]) MoveColumn(delta int) {
+	if m.activeColumn < 0 {
+		return
+	}
+	to := clamp(m.activeColumn+delta, 0, len(m.cols)-1)
+	if to == m.activeColumn {
+		return
+	}
+	m.cols[m.activeColumn], m.cols[to] = m.cols[to], m.cols[m.activeColumn]
+	m.activeColumn = to
+	m.syncColumnOrder()
+	// Re-calculate widths, as the last flex column receives any leftover
+	// width.
+	m.setColumnWidths()
+	m.scrollToColumn(m.activeColumn)
+}
+
+// ColumnOrder returns the keys of the columns in the order they are
+// displayed.
+func (m Model[V]) ColumnOrder() []string {
+	keys := make([]string, len(m.cols))
+	for i, col := range m.cols {
+		keys[i] = string(col.Key)
+	}
+	return keys
+}
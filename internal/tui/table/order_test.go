@@ -0,0 +1,94 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/leg100/pug/internal/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	colA = Column{Key: "a", Width: 10}
+	colB = Column{Key: "b", FlexFactor: 1}
+	colC = Column{Key: "c", FlexFactor: 2}
+)
+
+func setupOrderTest(opts ...Option[testResource]) Model[testResource] {
+	renderer := func(v testResource) RenderedRow { return nil }
+	return New([]Column{colA, colB, colC}, renderer, 100, 10, opts...)
+}
+
+func TestTable_WithColumnOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		order []string
+		want  []string
+	}{
+		{"no order", nil, []string{"a", "b", "c"}},
+		{"last column first", []string{"c"}, []string{"c", "a", "b"}},
+		{"reverse order", []string{"c", "b", "a"}, []string{"c", "b", "a"}},
+		{"ignore unknown keys", []string{"z", "b"}, []string{"b", "a", "c"}},
+		{"ignore duplicate keys", []string{"b", "b"}, []string{"b", "a", "c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl := setupOrderTest(WithColumnOrder[testResource](tt.order...))
+			assert.Equal(t, tt.want, tbl.ColumnOrder())
+		})
+	}
+}
+
+func TestTable_MoveColumn(t *testing.T) {
+	tbl := setupOrderTest()
+
+	// No column is active, so moving a column does nothing.
+	tbl.MoveColumn(1)
+	assert.Equal(t, []string{"a", "b", "c"}, tbl.ColumnOrder())
+
+	// Select first column, and move it to the end.
+	tbl.SelectColumn(1)
+	tbl.MoveColumn(1)
+	tbl.MoveColumn(1)
+	assert.Equal(t, []string{"b", "c", "a"}, tbl.ColumnOrder())
+
+	// Cannot move column beyond the end.
+	tbl.MoveColumn(1)
+	assert.Equal(t, []string{"b", "c", "a"}, tbl.ColumnOrder())
+
+	// Select previous column, and move it to the start.
+	tbl.SelectColumn(-1)
+	tbl.MoveColumn(-1)
+	assert.Equal(t, []string{"c", "b", "a"}, tbl.ColumnOrder())
+
+	// Flex columns still fill the available width: the total width less the
+	// borders, the scrollbar, the padding on each column, and the fixed width
+	// column.
+	var flexWidth int
+	for _, col := range tbl.cols {
+		if col.FlexFactor > 0 {
+			flexWidth += col.Width
+		}
+	}
+	assert.Equal(t, 100-2-1-2*3-10, flexWidth)
+
+	// Order persists after the table is re-populated.
+	tbl.SetItems(testResource{ID: resource.NewID(resource.Workspace)})
+	assert.Equal(t, []string{"c", "b", "a"}, tbl.ColumnOrder())
+}
+
+func TestTable_SelectColumn(t *testing.T) {
+	tbl := setupOrderTest()
+	assert.Equal(t, -1, tbl.activeColumn)
+
+	// First selection makes the first column active.
+	tbl.SelectColumn(-1)
+	assert.Equal(t, 0, tbl.activeColumn)
+
+	// Wrap around to the last column.
+	tbl.SelectColumn(-1)
+	assert.Equal(t, 2, tbl.activeColumn)
+
+	// Wrap around to the first column.
+	tbl.SelectColumn(1)
+	assert.Equal(t, 0, tbl.activeColumn)
+}
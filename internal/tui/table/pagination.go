@@ -0,0 +1,40 @@
+package table
+
+import (
+	"fmt"
+
+	"github.com/leg100/pug/internal/resource"
+)
+
+// WithPagination sets whether rows are divided into discrete pages, with the
+// page up and page down keys moving between them, rather than scrolled
+// continuously.
+func WithPagination[V resource.Resource](paginate bool) Option[V] {
+	return func(m *Model[V]) {
+		m.paginate = paginate
+	}
+}
+
+// paginated returns true if rows are divided into pages. Rows that wrap onto
+// several lines vary in height and so cannot be divided into pages of equal
+// size, in which case rows are scrolled continuously instead.
+func (m Model[V]) paginated() bool {
+	return m.paginate && !m.wrapping()
+}
+
+// pageSize returns the number of rows in a page.
+func (m Model[V]) pageSize() int {
+	return max(1, m.rowAreaHeight())
+}
+
+// Pagination describes the page currently visible, e.g. "page 2 of 5". An
+// empty string is returned if the table is not paginated.
+func (m Model[V]) Pagination() string {
+	if !m.paginated() {
+		return ""
+	}
+	size := m.pageSize()
+	page := m.start/size + 1
+	pages := max(1, (len(m.rows)+size-1)/size)
+	return fmt.Sprintf("page %d of %d", page, pages)
+}
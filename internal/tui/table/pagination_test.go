@@ -0,0 +1,36 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable_Pagination(t *testing.T) {
+	tbl := setupTest()
+	// Borders and header leave room for three rows per page.
+	tbl.setDimensions(20, 6)
+
+	// Continuous scrolling is the default.
+	assert.Equal(t, "", tbl.Pagination())
+
+	tbl.paginate = true
+	tbl.setStart()
+	assert.Equal(t, "page 1 of 2", tbl.Pagination())
+
+	// Moving down within the first page leaves the first page visible.
+	tbl.MoveDown(2)
+	assert.Equal(t, 0, tbl.start)
+	assert.Equal(t, "page 1 of 2", tbl.Pagination())
+
+	// Moving onto the next page shows all of the second page.
+	tbl.MoveDown(1)
+	assert.Equal(t, 3, tbl.start)
+	assert.Equal(t, "page 2 of 2", tbl.Pagination())
+
+	// Moving back a page shows all of the first page.
+	tbl.MoveUp(tbl.pageSize())
+	assert.Equal(t, 0, tbl.start)
+	assert.Equal(t, 0, tbl.currentRowIndex)
+	assert.Equal(t, "page 1 of 2", tbl.Pagination())
+}
@@ -0,0 +1,132 @@
+package table
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/tui"
+	"github.com/leg100/pug/internal/tui/keys"
+)
+
+// handleColumnPicker handles column picker related messages, returning false
+// if the message is not one of them.
+func (m *Model[V]) handleColumnPicker(msg tea.Msg) (tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case tui.ColumnPickerReqMsg:
+		m.picking = true
+		m.pickerIndex = 0
+		// Acknowledge the request with a non-nil command.
+		return func() tea.Msg { return nil }, true
+	case tui.ColumnPickerCloseMsg:
+		m.picking = false
+		return nil, true
+	case tui.ColumnPickerKeyMsg:
+		kmsg := tea.KeyMsg(msg)
+		switch {
+		case key.Matches(kmsg, keys.ColumnPicker.Up):
+			m.pickerIndex = max(0, m.pickerIndex-1)
+		case key.Matches(kmsg, keys.ColumnPicker.Down):
+			m.pickerIndex = min(len(m.allCols)-1, m.pickerIndex+1)
+		case key.Matches(kmsg, keys.ColumnPicker.Toggle):
+			if m.pickerIndex < len(m.allCols) {
+				m.ToggleColumn(m.allCols[m.pickerIndex].Key)
+			}
+		}
+		return nil, true
+	}
+	return nil, false
+}
+
+// ToggleColumn hides the column with the given key if it is shown, or shows
+// it if it is hidden. The last shown column cannot be hidden.
+func (m *Model[V]) ToggleColumn(key ColumnKey) {
+	if !slices.ContainsFunc(m.allCols, func(col Column) bool {
+		return col.Key == key
+	}) {
+		return
+	}
+	if !m.hidden[key] && len(m.cols) == 1 {
+		return
+	}
+	if m.hidden[key] {
+		delete(m.hidden, key)
+	} else {
+		m.hidden[key] = true
+	}
+	m.setShownColumns()
+}
+
+// HiddenColumns returns the keys of the hidden columns, in the order they
+// would be displayed.
+func (m Model[V]) HiddenColumns() []ColumnKey {
+	var hidden []ColumnKey
+	for _, col := range m.allCols {
+		if m.hidden[col.Key] {
+			hidden = append(hidden, col.Key)
+		}
+	}
+	return hidden
+}
+
+// setShownColumns populates the columns to be displayed, omitting hidden
+// columns, and re-calculates their widths.
+func (m *Model[V]) setShownColumns() {
+	var active ColumnKey
+	if m.activeColumn >= 0 && m.activeColumn < len(m.cols) {
+		active = m.cols[m.activeColumn].Key
+	}
+	cols := make([]Column, 0, len(m.allCols))
+	for _, col := range m.allCols {
+		if !m.hidden[col.Key] {
+			cols = append(cols, col)
+		}
+	}
+	m.cols = cols
+	// The active column remains active unless it has been hidden.
+	m.activeColumn = slices.IndexFunc(m.cols, func(col Column) bool {
+		return active != "" && col.Key == active
+	})
+	m.setColumnWidths()
+	m.clampColumnOffset()
+}
+
+// syncColumnOrder updates the order of all columns to match that of the shown
+// columns, leaving hidden columns in place.
+func (m *Model[V]) syncColumnOrder() {
+	var j int
+	for i, col := range m.allCols {
+		if !m.hidden[col.Key] && j < len(m.cols) {
+			m.allCols[i] = m.cols[j]
+			j++
+		}
+	}
+}
+
+// columnPickerView renders a line for each column, checked if the column is
+// shown, along with a scrollbar, for display in place of the rows.
+func (m Model[V]) columnPickerView() ([]string, string) {
+	height := m.rowAreaHeight()
+	// Scroll the cursor into view.
+	start := max(0, m.pickerIndex-height+1)
+	end := min(len(m.allCols), start+height)
+	lines := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		col := m.allCols[i]
+		check := "[x]"
+		if m.hidden[col.Key] {
+			check = "[ ]"
+		}
+		title := col.Title
+		if title == "" {
+			title = string(col.Key)
+		}
+		style := tui.Regular.Padding(0, 1).Width(m.width - tui.ScrollbarWidth)
+		if i == m.pickerIndex {
+			style = style.Background(tui.CurrentBackground).Foreground(tui.CurrentForeground)
+		}
+		lines = append(lines, style.Render(check+" "+title))
+	}
+	scrollbar := tui.Scrollbar(height, len(m.allCols), end-start, start)
+	return lines, scrollbar
+}
@@ -0,0 +1,98 @@
+package table
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/tui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTable_ToggleColumn(t *testing.T) {
+	tests := []struct {
+		name   string
+		toggle []ColumnKey
+		want   []string
+		hidden []ColumnKey
+	}{
+		{"hide column", []ColumnKey{"b"}, []string{"a", "c"}, []ColumnKey{"b"}},
+		{"show hidden column", []ColumnKey{"b", "b"}, []string{"a", "b", "c"}, nil},
+		{"ignore unknown column", []ColumnKey{"z"}, []string{"a", "b", "c"}, nil},
+		{"cannot hide last shown column", []ColumnKey{"a", "b", "c"}, []string{"c"}, []ColumnKey{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl := setupOrderTest()
+			for _, key := range tt.toggle {
+				tbl.ToggleColumn(key)
+			}
+			assert.Equal(t, tt.want, tbl.ColumnOrder())
+			assert.Equal(t, tt.hidden, tbl.HiddenColumns())
+		})
+	}
+}
+
+func TestTable_ToggleColumn_Widths(t *testing.T) {
+	tbl := setupOrderTest()
+	tbl.ToggleColumn("c")
+
+	// The hidden flex column's share of the width goes to the remaining flex
+	// column: 100 - borders (2) - scrollbar (1) - padding (2*2) - column a
+	// (10).
+	require.Len(t, tbl.cols, 2)
+	assert.Equal(t, 83, tbl.cols[1].Width)
+}
+
+func TestTable_ToggleColumn_PreservesOrder(t *testing.T) {
+	tbl := setupOrderTest()
+	tbl.ToggleColumn("b")
+	// Move column c before column a whilst b is hidden.
+	tbl.SelectColumn(1)
+	tbl.SelectColumn(1)
+	tbl.MoveColumn(-1)
+	assert.Equal(t, []string{"c", "a"}, tbl.ColumnOrder())
+
+	// Column b reappears in its original position.
+	tbl.ToggleColumn("b")
+	assert.Equal(t, []string{"c", "b", "a"}, tbl.ColumnOrder())
+}
+
+func TestTable_ToggleColumn_ExportCSV(t *testing.T) {
+	cols := []Column{
+		{Key: "n", Title: "NUMBER"},
+		{Key: "parity", Title: "PARITY"},
+	}
+	renderer := func(v testResource) RenderedRow {
+		return RenderedRow{"n": strconv.Itoa(v.n), "parity": "even"}
+	}
+	tbl := New(cols, renderer, 100, 20,
+		WithSortFunc(func(i, j testResource) int { return i.n - j.n }),
+	)
+	tbl.SetItems(resource0, resource2)
+	tbl.ToggleColumn("parity")
+
+	var buf bytes.Buffer
+	require.NoError(t, tbl.ExportCSV(&buf))
+	assert.Equal(t, "NUMBER\n0\n2\n", buf.String())
+}
+
+func TestTable_ColumnPicker(t *testing.T) {
+	tbl := setupOrderTest()
+
+	tbl, cmd := tbl.Update(tui.ColumnPickerReqMsg{})
+	assert.NotNil(t, cmd, "request should be acknowledged")
+	assert.True(t, tbl.picking)
+
+	// Move the cursor to the second column and hide it.
+	tbl, _ = tbl.Update(tui.ColumnPickerKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}))
+	tbl, _ = tbl.Update(tui.ColumnPickerKeyMsg(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}))
+	assert.Equal(t, []string{"a", "c"}, tbl.ColumnOrder())
+	assert.Contains(t, tbl.View(), "[ ] b")
+
+	tbl, _ = tbl.Update(tui.ColumnPickerCloseMsg{})
+	assert.False(t, tbl.picking)
+	assert.NotContains(t, tbl.View(), "[ ] b")
+}
@@ -0,0 +1,109 @@
+package table
+
+import (
+	"slices"
+
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/tui"
+)
+
+// minFlexWidth is the default minimum width of a flex column, and the width
+// below which a flex column with a larger minimum is never shrunk. If the
+// columns are too wide to fit within the table then the table is scrolled
+// horizontally to reveal the hidden columns.
+const minFlexWidth = 10
+
+// WithPinnedColumn sets whether the first column remains in view when the
+// table is scrolled horizontally.
+func WithPinnedColumn[V resource.Resource](pin bool) Option[V] {
+	return func(m *Model[V]) {
+		m.pinFirstColumn = pin
+	}
+}
+
+// ScrollLeft scrolls the table left by one column.
+func (m *Model[V]) ScrollLeft() {
+	m.colOffset = max(0, m.colOffset-1)
+}
+
+// ScrollRight scrolls the table right by one column, unless the last column is
+// already in view.
+func (m *Model[V]) ScrollRight() {
+	if !m.lastColumnVisible() {
+		m.colOffset++
+	}
+}
+
+// firstScrollableColumn returns the index of the first column that can be
+// scrolled out of view.
+func (m Model[V]) firstScrollableColumn() int {
+	if m.pinFirstColumn {
+		return 1
+	}
+	return 0
+}
+
+// visibleColumns returns the indices of the columns that fit within the width
+// of the table, starting with the column at the scroll offset. A pinned first
+// column is always visible.
+func (m Model[V]) visibleColumns() []int {
+	var (
+		visible []int
+		avail   = m.width - tui.ScrollbarWidth
+	)
+	add := func(i int) bool {
+		// Account for padding either side of the column.
+		width := m.cols[i].Width + 2
+		// Always render at least one column, even if it doesn't fit.
+		if len(visible) > 0 && width > avail {
+			return false
+		}
+		visible = append(visible, i)
+		avail -= width
+		return true
+	}
+	if m.pinFirstColumn && len(m.cols) > 0 {
+		add(0)
+	}
+	for i := m.firstScrollableColumn() + m.colOffset; i < len(m.cols); i++ {
+		if !add(i) {
+			break
+		}
+	}
+	return visible
+}
+
+func (m Model[V]) lastColumnVisible() bool {
+	visible := m.visibleColumns()
+	return len(visible) == 0 || visible[len(visible)-1] == len(m.cols)-1
+}
+
+// clampColumnOffset reduces the scroll offset for as long as the columns to
+// the right remain in view, so that a table that has been widened doesn't
+// leave hidden columns on the left and empty space on the right.
+func (m *Model[V]) clampColumnOffset() {
+	m.colOffset = clamp(m.colOffset, 0, max(0, len(m.cols)-m.firstScrollableColumn()-1))
+	for m.colOffset > 0 {
+		m.colOffset--
+		if !m.lastColumnVisible() {
+			m.colOffset++
+			return
+		}
+	}
+}
+
+// scrollToColumn scrolls the table horizontally until the column with the
+// given index is in view.
+func (m *Model[V]) scrollToColumn(i int) {
+	if i < m.firstScrollableColumn() {
+		return
+	}
+	offset := i - m.firstScrollableColumn()
+	if offset < m.colOffset {
+		m.colOffset = offset
+		return
+	}
+	for m.colOffset < offset && !slices.Contains(m.visibleColumns(), i) {
+		m.colOffset++
+	}
+}
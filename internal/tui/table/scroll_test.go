@@ -0,0 +1,86 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// setupScrollTest sets up a table with room for only two of its four columns.
+func setupScrollTest(opts ...Option[testResource]) Model[testResource] {
+	cols := []Column{
+		{Key: "a", Title: "A", Width: 20},
+		{Key: "b", Title: "B", Width: 20},
+		{Key: "c", Title: "C", Width: 20},
+		{Key: "d", Title: "D", Width: 20},
+	}
+	renderer := func(v testResource) RenderedRow { return nil }
+	return New(cols, renderer, 53, 10, opts...)
+}
+
+func TestTable_Scroll(t *testing.T) {
+	tbl := setupScrollTest()
+	assert.Equal(t, []int{0, 1}, tbl.visibleColumns())
+
+	tbl.ScrollRight()
+	assert.Equal(t, []int{1, 2}, tbl.visibleColumns())
+
+	tbl.ScrollRight()
+	assert.Equal(t, []int{2, 3}, tbl.visibleColumns())
+
+	// Cannot scroll beyond last column
+	tbl.ScrollRight()
+	assert.Equal(t, []int{2, 3}, tbl.visibleColumns())
+
+	tbl.ScrollLeft()
+	tbl.ScrollLeft()
+	assert.Equal(t, []int{0, 1}, tbl.visibleColumns())
+
+	// Cannot scroll beyond first column
+	tbl.ScrollLeft()
+	assert.Equal(t, []int{0, 1}, tbl.visibleColumns())
+}
+
+func TestTable_ScrollPinned(t *testing.T) {
+	tbl := setupScrollTest(WithPinnedColumn[testResource](true))
+	assert.Equal(t, []int{0, 1}, tbl.visibleColumns())
+
+	tbl.ScrollRight()
+	assert.Equal(t, []int{0, 2}, tbl.visibleColumns())
+
+	tbl.ScrollRight()
+	assert.Equal(t, []int{0, 3}, tbl.visibleColumns())
+
+	tbl.ScrollRight()
+	assert.Equal(t, []int{0, 3}, tbl.visibleColumns())
+	assert.Contains(t, tbl.headersView(), "A")
+	assert.NotContains(t, tbl.headersView(), "B")
+}
+
+func TestTable_ScrollResize(t *testing.T) {
+	tbl := setupScrollTest()
+	tbl.ScrollRight()
+
+	// The offset persists when resized...
+	tbl.setDimensions(53, 20)
+	assert.Equal(t, []int{1, 2}, tbl.visibleColumns())
+
+	// ...unless there is room to show the columns scrolled out of view.
+	tbl.setDimensions(100, 20)
+	assert.Equal(t, []int{0, 1, 2, 3}, tbl.visibleColumns())
+}
+
+func TestTable_ScrollToSelectedColumn(t *testing.T) {
+	tbl := setupScrollTest()
+
+	// Selecting the last column scrolls it into view.
+	tbl.SelectColumn(-1)
+	tbl.SelectColumn(-1)
+	assert.Equal(t, 3, tbl.activeColumn)
+	assert.Equal(t, []int{2, 3}, tbl.visibleColumns())
+
+	// Selecting the first column scrolls it back into view.
+	tbl.SelectColumn(1)
+	assert.Equal(t, 0, tbl.activeColumn)
+	assert.Equal(t, []int{0, 1}, tbl.visibleColumns())
+}
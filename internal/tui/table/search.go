@@ -0,0 +1,21 @@
+package table
+
+// FindNext makes the next row satisfying fn the current row, searching
+// downwards from the current row and wrapping around to the top. Set reverse
+// to search upwards instead. Returns false if no row satisfies fn, in which
+// case the current row is unchanged.
+func (m *Model[V]) FindNext(fn func(V) bool, reverse bool) bool {
+	n := len(m.rows)
+	step := 1
+	if reverse {
+		step = -1
+	}
+	for i := 1; i <= n; i++ {
+		idx := ((m.currentRowIndex+i*step)%n + n) % n
+		if !m.rows[idx].header && fn(m.rows[idx].Value) {
+			m.moveCurrentRow(idx - m.currentRowIndex)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTable_FindNext(t *testing.T) {
+	even := func(v testResource) bool { return v.n%2 == 0 }
+
+	t.Run("forwards", func(t *testing.T) {
+		tbl := setupTest()
+
+		require.True(t, tbl.FindNext(even, false))
+		assert.Equal(t, 2, tbl.currentRowIndex)
+	})
+
+	t.Run("forwards wraps around", func(t *testing.T) {
+		tbl := setupTest()
+		tbl.GotoBottom()
+
+		require.True(t, tbl.FindNext(even, false))
+		assert.Equal(t, 0, tbl.currentRowIndex)
+	})
+
+	t.Run("backwards wraps around", func(t *testing.T) {
+		tbl := setupTest()
+
+		require.True(t, tbl.FindNext(even, true))
+		assert.Equal(t, 4, tbl.currentRowIndex)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		tbl := setupTest()
+		tbl.MoveDown(3)
+
+		none := func(v testResource) bool { return false }
+		assert.False(t, tbl.FindNext(none, false))
+		assert.Equal(t, 3, tbl.currentRowIndex)
+	})
+}
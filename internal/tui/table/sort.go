@@ -0,0 +1,95 @@
+package table
+
+import (
+	"slices"
+
+	"github.com/leg100/pug/internal/resource"
+	"golang.org/x/exp/maps"
+)
+
+const (
+	ascendingIndicator  = "▲"
+	descendingIndicator = "▼"
+)
+
+// WithColumnSortFunc permits the user to sort rows by the column with the
+// given key, using the given func to sort rows in ascending order.
+func WithColumnSortFunc[V resource.Resource](key ColumnKey, fn SortFunc[V]) Option[V] {
+	return func(m *Model[V]) {
+		m.columnSortFuncs[key] = fn
+	}
+}
+
+// WithSortFuncs configures the table to sort rows using several funcs, in
+// order, with each func breaking ties left by the funcs before it.
+func WithSortFuncs[V resource.Resource](fns ...SortFunc[V]) Option[V] {
+	return WithSortFunc(SortFuncs(fns...))
+}
+
+// SortFuncs chains several sort funcs into one. Rows are compared using each
+// func in turn until one of them finds the rows are unequal.
+func SortFuncs[V any](fns ...SortFunc[V]) SortFunc[V] {
+	return func(i, j V) int {
+		for _, fn := range fns {
+			if c := fn(i, j); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+}
+
+// SetSortFunc sorts rows using fn, re-sorting the current items. It is
+// overridden by a sort column if there is one.
+func (m *Model[V]) SetSortFunc(fn SortFunc[V]) {
+	m.sortFunc = fn
+	m.setRows(maps.Values(m.items)...)
+}
+
+// CycleSortColumn sorts rows by the next column for which there is a sort
+// func, in the order the columns are displayed. Cycling beyond the last such
+// column reverts to the table's default sort order.
+func (m *Model[V]) CycleSortColumn() {
+	start := slices.IndexFunc(m.cols, func(col Column) bool {
+		return col.Key == m.sortColumn
+	})
+	m.sortColumn = ""
+	m.sortDescending = false
+	for _, col := range m.cols[start+1:] {
+		if _, ok := m.columnSortFuncs[col.Key]; ok {
+			m.sortColumn = col.Key
+			break
+		}
+	}
+	m.setRows(maps.Values(m.items)...)
+}
+
+// ReverseSort toggles between sorting rows by the sort column in ascending
+// and descending order. It has no effect if rows are not sorted by a column.
+func (m *Model[V]) ReverseSort() {
+	if m.sortColumn == "" {
+		return
+	}
+	m.sortDescending = !m.sortDescending
+	m.setRows(maps.Values(m.items)...)
+}
+
+// currentSortFunc returns the func for sorting rows, which is that of the
+// sort column if there is one, otherwise the table's default sort func.
+func (m Model[V]) currentSortFunc() SortFunc[V] {
+	fn, ok := m.columnSortFuncs[m.sortColumn]
+	if !ok {
+		return m.sortFunc
+	}
+	if m.sortDescending {
+		return func(i, j V) int { return fn(j, i) }
+	}
+	return fn
+}
+
+func (m Model[V]) sortIndicator() string {
+	if m.sortDescending {
+		return descendingIndicator
+	}
+	return ascendingIndicator
+}
@@ -0,0 +1,126 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupSortTest() Model[testResource] {
+	var (
+		number = Column{Key: "number", Title: "NUMBER", Width: 10}
+		parity = Column{Key: "parity", Title: "PARITY", Width: 10}
+		none   = Column{Key: "none", Title: "NONE", Width: 10}
+	)
+	renderer := func(v testResource) RenderedRow { return nil }
+	tbl := New([]Column{none, number, parity}, renderer, 100, 20,
+		WithSortFunc(func(i, j testResource) int { return i.n - j.n }),
+		// Sort by number in descending order
+		WithColumnSortFunc(number.Key, func(i, j testResource) int { return j.n - i.n }),
+		// Sort even numbers first, and then by number
+		WithColumnSortFunc(parity.Key, func(i, j testResource) int {
+			if a, b := i.n%2, j.n%2; a != b {
+				return a - b
+			}
+			return i.n - j.n
+		}),
+	)
+	tbl.SetItems(resource0, resource1, resource2, resource3, resource4, resource5)
+	return tbl
+}
+
+func rowNumbers(tbl Model[testResource]) []int {
+	numbers := make([]int, len(tbl.rows))
+	for i, row := range tbl.rows {
+		numbers[i] = row.Value.n
+	}
+	return numbers
+}
+
+func TestTable_CycleSortColumn(t *testing.T) {
+	tbl := setupSortTest()
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, rowNumbers(tbl))
+
+	// Columns without a sort func are skipped.
+	tbl.CycleSortColumn()
+	assert.Equal(t, ColumnKey("number"), tbl.sortColumn)
+	assert.Equal(t, []int{5, 4, 3, 2, 1, 0}, rowNumbers(tbl))
+
+	tbl.CycleSortColumn()
+	assert.Equal(t, ColumnKey("parity"), tbl.sortColumn)
+	assert.Equal(t, []int{0, 2, 4, 1, 3, 5}, rowNumbers(tbl))
+
+	// Cycling beyond the last sortable column reverts to the default sort.
+	tbl.CycleSortColumn()
+	assert.Equal(t, ColumnKey(""), tbl.sortColumn)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, rowNumbers(tbl))
+}
+
+func TestTable_ReverseSort(t *testing.T) {
+	tbl := setupSortTest()
+
+	// No effect without a sort column.
+	tbl.ReverseSort()
+	assert.False(t, tbl.sortDescending)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, rowNumbers(tbl))
+
+	tbl.CycleSortColumn()
+	tbl.ReverseSort()
+	assert.True(t, tbl.sortDescending)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, rowNumbers(tbl))
+	assert.Contains(t, tbl.headersView(), "NUMBER "+descendingIndicator)
+
+	tbl.ReverseSort()
+	assert.Equal(t, []int{5, 4, 3, 2, 1, 0}, rowNumbers(tbl))
+	assert.Contains(t, tbl.headersView(), "NUMBER "+ascendingIndicator)
+
+	// Selecting another sort column resets the order to ascending.
+	tbl.ReverseSort()
+	tbl.CycleSortColumn()
+	assert.False(t, tbl.sortDescending)
+}
+
+func TestTable_SortCurrentRow(t *testing.T) {
+	tbl := setupSortTest()
+	tbl.MoveDown(1)
+
+	// The current row follows its item when the rows are re-sorted.
+	tbl.CycleSortColumn()
+	got, ok := tbl.CurrentRow()
+	assert.True(t, ok)
+	assert.Equal(t, resource1, got.Value)
+	assert.Equal(t, 4, tbl.currentRowIndex)
+}
+
+func TestTable_WithSortFuncs(t *testing.T) {
+	renderer := func(v testResource) RenderedRow { return nil }
+	tbl := New(nil, renderer, 100, 20,
+		WithSortFuncs(
+			// Sort even numbers first...
+			func(i, j testResource) int { return i.n%2 - j.n%2 },
+			// ...then break ties by sorting numbers in descending order.
+			func(i, j testResource) int { return j.n - i.n },
+		),
+	)
+	// Ties are resolved the same way regardless of the order in which items
+	// are added.
+	for _, items := range [][]testResource{
+		{resource0, resource1, resource2, resource3, resource4, resource5},
+		{resource5, resource4, resource3, resource2, resource1, resource0},
+	} {
+		tbl.SetItems(items...)
+		assert.Equal(t, []int{4, 2, 0, 5, 3, 1}, rowNumbers(tbl))
+	}
+}
+
+func TestTable_SetSortFunc(t *testing.T) {
+	tbl := setupSortTest()
+
+	tbl.SetSortFunc(func(i, j testResource) int { return j.n - i.n })
+	assert.Equal(t, []int{5, 4, 3, 2, 1, 0}, rowNumbers(tbl))
+
+	// A sort column overrides the sort func.
+	tbl.CycleSortColumn()
+	tbl.CycleSortColumn()
+	assert.Equal(t, []int{0, 2, 4, 1, 3, 5}, rowNumbers(tbl))
+}
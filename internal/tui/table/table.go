@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -23,6 +24,8 @@ import (
 const (
 	// Height of the table header
 	headerHeight = 1
+	// Height of the optional rule beneath the table header
+	headerRuleHeight = 1
 	// Height of filter widget
 	filterHeight = 2
 	// Minimum recommended height for the table widget. Respecting this minimum
@@ -32,26 +35,117 @@ const (
 
 // Model defines a state for the table widget.
 type Model[V resource.Resource] struct {
-	cols        []Column
+	// cols are the columns that are shown, in the order they are displayed.
+	cols []Column
+	// allCols are all the columns, shown and hidden, in the order they are
+	// displayed.
+	allCols []Column
+	// hidden records the keys of columns hidden by the user.
+	hidden map[ColumnKey]bool
+
 	rows        []Row[V]
 	rowRenderer RowRenderer[V]
 	focus       bool
 	rendered    map[resource.ID]RenderedRow
 
+	// filterable caches the content of each rendered row, stripped of ANSI
+	// escape codes, for matching against the filter. Like rendered, an entry
+	// is refreshed only when its item is added or updated, so that editing the
+	// filter does not re-render rows.
+	filterable map[resource.ID]RenderedRow
+
 	border      lipgloss.Border
 	borderColor lipgloss.TerminalColor
 
 	currentRowIndex int
 	currentRowID    resource.ID
+	// currentHeader is true if the current row is the header of the group
+	// named currentGroup.
+	currentHeader bool
+	currentGroup  string
+
+	// groupFunc, if non-nil, groups rows by the name it returns for each item.
+	groupFunc GroupFunc[V]
+	// collapsed records the names of groups whose rows are hidden.
+	collapsed map[string]bool
+
+	// activeColumn is the index of the column selected for reordering, or -1
+	// if no column is selected.
+	activeColumn int
+
+	// colOffset is the number of scrollable columns scrolled out of view to
+	// the left.
+	colOffset int
+	// pinFirstColumn keeps the first column in view when scrolling
+	// horizontally.
+	pinFirstColumn bool
+	// headerRule draws a horizontal rule between the header and the rows.
+	headerRule bool
+
+	// paginate divides rows into discrete pages rather than scrolling them
+	// continuously.
+	paginate bool
 
 	// items are the unfiltered set of items available to the table.
 	items    map[resource.ID]V
 	sortFunc SortFunc[V]
 
+	// columnSortFuncs are the funcs available for sorting rows by a
+	// particular column.
+	columnSortFuncs map[ColumnKey]SortFunc[V]
+	// sortColumn is the key of the column by which rows are sorted, or empty
+	// if rows are sorted using sortFunc.
+	sortColumn ColumnKey
+	// sortDescending reverses the order of rows sorted by sortColumn.
+	sortDescending bool
+
+	// copyFunc, if non-nil, produces the string copied to the clipboard for
+	// the current row.
+	copyFunc CopyFunc[V]
+
 	selected   map[resource.ID]V
 	selectable bool
+	// followNew moves the cursor to newly created items.
+	followNew bool
 
 	filter textinput.Model
+	// fuzzy is true if rows are filtered using fuzzy matching rather than
+	// substring matching. Matching rows are ranked by how well they match,
+	// overriding sortFunc.
+	fuzzy bool
+	// regex is true if rows are filtered by matching the filter value as a
+	// regular expression.
+	regex bool
+	// filterInvalid is true if the filter value is an invalid regular
+	// expression.
+	filterInvalid bool
+	// lastRegexTerm is the term parsed from the last valid regular expression,
+	// used in place of an invalid regular expression.
+	lastRegexTerm *filterTerm
+	// predicate, if non-nil, hides those items for which it returns false.
+	predicate func(V) bool
+
+	// filterSeq identifies the last key typed into the filter, or is zero if
+	// rows have been filtered since.
+	filterSeq int64
+
+	// loading is true until the table receives its initial items.
+	loading bool
+	// spinner, if non-nil, is shown whilst loading.
+	spinner *spinner.Model
+
+	// jumping is true whilst the user is typing a prefix to jump to a row.
+	jumping bool
+	// jumpPrefix is the prefix typed so far.
+	jumpPrefix string
+	// jumpSeq identifies the last key typed whilst jumping.
+	jumpSeq int64
+
+	// picking is true whilst the column picker is open.
+	picking bool
+	// pickerIndex is the index of the column under the cursor in the column
+	// picker.
+	pickerIndex int
 
 	// index of first visible row
 	start int
@@ -66,13 +160,28 @@ type Model[V resource.Resource] struct {
 type Column struct {
 	Key ColumnKey
 	// TODO: Default to upper case of key
-	Title          string
-	Width          int
-	FlexFactor     int
+	Title      string
+	Width      int
+	FlexFactor int
+	// MinWidth is the width below which a flex column is not shrunk to make
+	// room for other flex columns. Defaults to minFlexWidth.
+	MinWidth       int
 	TruncationFunc func(s string, w int, tail string) string
 	// RightAlign aligns content to the right. If false, content is aligned to
 	// the left.
 	RightAlign bool
+	// Wrap word-wraps content across as many lines as necessary to fit the
+	// column width, increasing the height of the row. If false, content is
+	// truncated to fit on one line.
+	Wrap bool
+}
+
+// minWidth returns the minimum width of a flex column.
+func (c Column) minWidth() int {
+	if c.MinWidth > 0 {
+		return c.MinWidth
+	}
+	return minFlexWidth
 }
 
 type ColumnKey string
@@ -80,6 +189,14 @@ type ColumnKey string
 type Row[V any] struct {
 	ID    resource.ID
 	Value V
+
+	// group is the name of the group to which the row belongs, or which the
+	// row heads. Empty if rows are not grouped.
+	group string
+	// header is true if the row is the header of a group rather than an item.
+	header bool
+	// size is the number of items in the group headed by a header row.
+	size int
 }
 
 type RowRenderer[V any] func(V) RenderedRow
@@ -98,15 +215,16 @@ func New[V resource.Resource](cols []Column, fn RowRenderer[V], width, height in
 		rowRenderer:     fn,
 		items:           make(map[resource.ID]V),
 		rendered:        make(map[resource.ID]RenderedRow),
+		filterable:      make(map[resource.ID]RenderedRow),
 		selected:        make(map[resource.ID]V),
+		columnSortFuncs: make(map[ColumnKey]SortFunc[V]),
+		hidden:          make(map[ColumnKey]bool),
 		selectable:      true,
 		focus:           true,
 		filter:          filter,
 		border:          lipgloss.NormalBorder(),
 		currentRowIndex: -1,
-	}
-	for _, fn := range opts {
-		fn(&m)
+		activeColumn:    -1,
 	}
 
 	// Copy column structs onto receiver, because the caller may modify columns.
@@ -119,6 +237,11 @@ func New[V resource.Resource](cols []Column, fn RowRenderer[V], width, height in
 		}
 	}
 
+	for _, fn := range opts {
+		fn(&m)
+	}
+	m.allCols = slices.Clone(m.cols)
+
 	m.setDimensions(width, height)
 
 	return m
@@ -133,6 +256,31 @@ func WithSortFunc[V resource.Resource](sortFunc func(V, V) int) Option[V] {
 	}
 }
 
+// WithDefaultFilter seeds the filter with an expression, which filters rows
+// from the moment the table is first populated. The user can see the filter
+// and clear it as they would any other.
+func WithDefaultFilter[V resource.Resource](expr string) Option[V] {
+	return func(m *Model[V]) {
+		m.filter.SetValue(expr)
+	}
+}
+
+// WithHeaderRule sets whether a horizontal rule is drawn between the header and
+// the rows.
+func WithHeaderRule[V resource.Resource](rule bool) Option[V] {
+	return func(m *Model[V]) {
+		m.headerRule = rule
+	}
+}
+
+// WithFollowNew sets whether the current row moves to items as they are
+// created.
+func WithFollowNew[V resource.Resource](follow bool) Option[V] {
+	return func(m *Model[V]) {
+		m.followNew = follow
+	}
+}
+
 // WithSelectable sets whether rows are selectable.
 func WithSelectable[V resource.Resource](s bool) Option[V] {
 	return func(m *Model[V]) {
@@ -147,18 +295,29 @@ func (m *Model[V]) filterVisible() bool {
 
 // setDimensions sets the dimensions of the table.
 func (m *Model[V]) setDimensions(width, height int) {
-	// Adjust height to accomodate borders
-	m.height = height - 2
+	// Adjust height to accomodate borders, which in a tiny terminal may leave
+	// no room at all.
+	m.height = max(0, height-2)
 	// Adjust width to accomodate borders
-	m.width = width - 2
+	m.width = max(0, width-2)
 	m.setColumnWidths()
+	m.clampColumnOffset()
 
 	m.setStart()
 }
 
+// headerAreaHeight returns the height of the header, including the optional
+// rule beneath it.
+func (m Model[V]) headerAreaHeight() int {
+	if m.headerRule {
+		return headerHeight + headerRuleHeight
+	}
+	return headerHeight
+}
+
 // rowAreaHeight returns the height of the terminal allocated to rows.
 func (m Model[V]) rowAreaHeight() int {
-	height := max(0, m.height-headerHeight)
+	height := max(0, m.height-m.headerAreaHeight())
 
 	if m.filterVisible() {
 		// Accommodate height of filter widget
@@ -169,8 +328,22 @@ func (m Model[V]) rowAreaHeight() int {
 
 // visibleRows returns the number of renderable visible rows.
 func (m Model[V]) visibleRows() int {
-	// The number of visible rows cannot exceed the row area height.
-	return min(m.rowAreaHeight(), len(m.rows)-m.start)
+	area := m.rowAreaHeight()
+	if !m.wrapping() {
+		// The number of visible rows cannot exceed the row area height.
+		return min(area, len(m.rows)-m.start)
+	}
+	// Rows may span several lines, so count the rows that fit in the row
+	// area, always including at least one row.
+	var n, lines int
+	for i := m.start; i < len(m.rows) && lines < area; i++ {
+		lines += m.rowHeight(i)
+		if lines > area && n > 0 {
+			break
+		}
+		n++
+	}
+	return n
 }
 
 // Update is the Bubble Tea update loop.
@@ -178,6 +351,12 @@ func (m Model[V]) Update(msg tea.Msg) (Model[V], tea.Cmd) {
 	if !m.focus {
 		return m, nil
 	}
+	if cmd, ok := m.handleJump(msg); ok {
+		return m, cmd
+	}
+	if cmd, ok := m.handleColumnPicker(msg); ok {
+		return m, cmd
+	}
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -206,12 +385,40 @@ func (m Model[V]) Update(msg tea.Msg) (Model[V], tea.Cmd) {
 			m.DeselectAll()
 		case key.Matches(msg, keys.Global.SelectRange):
 			m.SelectRange()
+		case key.Matches(msg, keys.Global.SelectInvert):
+			m.InvertSelection()
+		case key.Matches(msg, keys.Global.Copy):
+			return m, m.copyRows()
+		case key.Matches(msg, keys.Columns.PrevColumn):
+			m.SelectColumn(-1)
+		case key.Matches(msg, keys.Columns.NextColumn):
+			m.SelectColumn(1)
+		case key.Matches(msg, keys.Columns.MoveLeft):
+			m.MoveColumn(-1)
+		case key.Matches(msg, keys.Columns.MoveRight):
+			m.MoveColumn(1)
+		case key.Matches(msg, keys.Columns.ScrollLeft):
+			m.ScrollLeft()
+		case key.Matches(msg, keys.Columns.ScrollRight):
+			m.ScrollRight()
+		case key.Matches(msg, keys.Columns.Sort):
+			m.CycleSortColumn()
+		case key.Matches(msg, keys.Columns.Reverse):
+			m.ReverseSort()
 		}
+	case tea.MouseMsg:
+		m.handleMouse(msg)
 	case BulkInsertMsg[V]:
+		m.loading = false
 		m.AddItems(msg...)
 	case resource.Event[V]:
 		switch msg.Type {
-		case resource.CreatedEvent, resource.UpdatedEvent:
+		case resource.CreatedEvent:
+			m.AddItems(msg.Payload)
+			if m.followNew {
+				m.gotoRow(msg.Payload.GetID())
+			}
+		case resource.UpdatedEvent:
 			m.AddItems(msg.Payload)
 		case resource.DeletedEvent:
 			m.removeItem(msg.Payload)
@@ -226,22 +433,47 @@ func (m Model[V]) Update(msg tea.Msg) (Model[V], tea.Cmd) {
 	case tui.FilterBlurMsg:
 		// Blur the filter widget
 		m.filter.Blur()
+		// Filter rows without waiting for any scheduled filtering.
+		if m.filterSeq != 0 {
+			m.applyFilter()
+		}
 		return m, nil
 	case tui.FilterCloseMsg:
 		// Close the filter widget
 		m.filter.Blur()
 		m.filter.SetValue("")
 		// Unfilter table items
-		m.setRows(maps.Values(m.items)...)
+		m.applyFilter()
+		return m, nil
+	case tui.FilterSetMsg:
+		m.filter.SetValue(string(msg))
+		m.applyFilter()
 		return m, nil
 	case tui.FilterKeyMsg:
 		// unwrap key and send to filter widget
 		kmsg := tea.KeyMsg(msg)
+		if key.Matches(kmsg, keys.Filter.Fuzzy) {
+			m.ToggleFuzzy()
+			return m, nil
+		}
+		if key.Matches(kmsg, keys.Filter.Regex) {
+			m.ToggleRegex()
+			return m, nil
+		}
+		value := m.filter.Value()
 		var cmd tea.Cmd
 		m.filter, cmd = m.filter.Update(kmsg)
-		// Filter table items
-		m.setRows(maps.Values(m.items)...)
-		return m, cmd
+		if m.filter.Value() == value {
+			// Key only moved the cursor.
+			return m, cmd
+		}
+		// Filter table items once typing pauses.
+		return m, tea.Batch(cmd, m.debounceFilter())
+	case filterMsg:
+		if msg.seq == m.filterSeq {
+			m.applyFilter()
+		}
+		return m, nil
 	default:
 		// Send any other messages to the filter if it is focused.
 		if m.filter.Focused() {
@@ -254,6 +486,36 @@ func (m Model[V]) Update(msg tea.Msg) (Model[V], tea.Cmd) {
 	return m, nil
 }
 
+// handleMouse moves the current row in response to the mouse wheel, and makes
+// a row the current row when it is clicked. Clicking a row whilst holding ctrl
+// toggles its selection.
+func (m *Model[V]) handleMouse(msg tea.MouseMsg) {
+	if msg.Action != tea.MouseActionPress {
+		return
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.MoveUp(1)
+	case tea.MouseButtonWheelDown:
+		m.MoveDown(1)
+	case tea.MouseButtonLeft:
+		// Determine the row clicked, ignoring clicks on the top border, the
+		// filter widget, and the header.
+		y := msg.Y - 1 - m.headerAreaHeight()
+		if m.filterVisible() {
+			y -= filterHeight
+		}
+		rowIdx, ok := m.rowAtLine(y)
+		if !ok {
+			return
+		}
+		m.moveCurrentRow(rowIdx - m.currentRowIndex)
+		if msg.Ctrl {
+			m.ToggleSelection()
+		}
+	}
+}
+
 // Focused returns the focus state of the table.
 func (m Model[V]) Focused() bool {
 	return m.focus
@@ -276,13 +538,22 @@ func (m Model[V]) View() string {
 	// (a) optional filter widget
 	// (b) header
 	// (c) rows + scrollbar
-	components := make([]string, 0, 1+1+m.visibleRows())
+	components := make([]string, 0, 1+1+1+m.visibleRows())
 	if m.filterVisible() {
-		components = append(components, tui.Regular.Margin(0, 1).Render(m.filter.View()))
+		filter := m.filter.View()
+		if m.filterInvalid {
+			filter += tui.Regular.Foreground(tui.Red).Render(" (invalid regex)")
+		}
+		components = append(components, tui.Regular.Margin(0, 1).Render(filter))
 		// Add horizontal rule between filter widget and table
 		components = append(components, strings.Repeat("─", m.width))
 	}
 	components = append(components, m.headersView())
+	if m.headerRule {
+		// Add horizontal rule between header and rows, spanning the full
+		// width so that it remains aligned however the columns flex.
+		components = append(components, strings.Repeat("─", m.width))
+	}
 	// Generate scrollbar
 	scrollbar := tui.Scrollbar(m.rowAreaHeight(), len(m.rows), m.visibleRows(), m.start)
 	// Get all the visible rows
@@ -290,9 +561,18 @@ func (m Model[V]) View() string {
 	for i := range m.visibleRows() {
 		rows = append(rows, m.renderRow(m.start+i))
 	}
-	rowarea := lipgloss.NewStyle().Width(m.width - tui.ScrollbarWidth).Render(
-		strings.Join(rows, "\n"),
-	)
+	if len(m.rows) == 0 {
+		rows = append(rows, m.placeholder())
+	}
+	if m.picking {
+		// Show the column picker in place of the rows.
+		rows, scrollbar = m.columnPickerView()
+	}
+	rowarea := lipgloss.NewStyle().
+		Width(m.width - tui.ScrollbarWidth).
+		// A row taller than the row area is cut short.
+		MaxHeight(m.rowAreaHeight()).
+		Render(strings.Join(rows, "\n"))
 	// Put rows alongside the scrollbar to the right.
 	components = append(components, lipgloss.JoinHorizontal(lipgloss.Top, rowarea, scrollbar))
 	// Render table components, ensuring it is at least a min height
@@ -306,11 +586,21 @@ func (m Model[V]) View() string {
 		top := m.start + 1
 		bottom := m.start + m.visibleRows()
 		prefix := fmt.Sprintf("%d-%d of ", top, bottom)
-		if m.filterVisible() {
+		if m.filterVisible() || m.predicate != nil {
 			metadata = prefix + fmt.Sprintf("%d/%d", len(m.rows), len(m.items))
 		} else {
 			metadata = prefix + strconv.Itoa(len(m.rows))
 		}
+		if page := m.Pagination(); page != "" {
+			metadata += " · " + page
+		}
+		if m.jumping {
+			// Show the jump prefix typed so far.
+			metadata = fmt.Sprintf("jump: %s_ · %s", m.jumpPrefix, metadata)
+		}
+		if m.picking {
+			metadata = fmt.Sprintf("columns: %d/%d shown", len(m.cols), len(m.allCols))
+		}
 	}
 	// Render top border with metadata in the center
 	var topBorder string
@@ -346,6 +636,10 @@ func (m Model[V]) CurrentRow() (Row[V], bool) {
 	if m.currentRowIndex < 0 || m.currentRowIndex >= len(m.rows) {
 		return *new(Row[V]), false
 	}
+	if m.rows[m.currentRowIndex].header {
+		// A group header is not an item.
+		return *new(Row[V]), false
+	}
 	return m.rows[m.currentRowIndex], true
 }
 
@@ -410,13 +704,18 @@ func (m *Model[V]) ToggleSelectionByID(id resource.ID) {
 	}
 }
 
-// SelectAll selects all rows. Any rows not currently selected are selected.
+// SelectAll selects all rows visible through the filter, leaving the selection
+// of any filtered out rows unchanged. Any rows not currently selected are
+// selected.
 func (m *Model[V]) SelectAll() {
 	if !m.selectable {
 		return
 	}
 
 	for _, row := range m.rows {
+		if row.header {
+			continue
+		}
 		m.selected[row.ID] = row.Value
 	}
 }
@@ -430,50 +729,73 @@ func (m *Model[V]) DeselectAll() {
 	m.selected = make(map[resource.ID]V)
 }
 
-// SelectRange selects a range of rows. If the current row is *below* a selected
-// row then rows between them are selected, including the current row.
-// Otherwise, if the current row is *above* a selected row then rows between
-// them are selected, including the current row. If there are no selected rows
-// then no action is taken.
-func (m *Model[V]) SelectRange() {
+// InvertSelection selects those rows that are not selected and de-selects
+// those rows that are selected. Only the rows visible through the filter are
+// inverted; the selection of any filtered out rows is unchanged.
+func (m *Model[V]) InvertSelection() {
 	if !m.selectable {
 		return
 	}
-	if len(m.selected) == 0 {
+
+	for _, row := range m.rows {
+		if row.header {
+			continue
+		}
+		if _, ok := m.selected[row.ID]; ok {
+			delete(m.selected, row.ID)
+		} else {
+			m.selected[row.ID] = row.Value
+		}
+	}
+}
+
+// SelectRange selects the contiguous range of rows between the current row and
+// a selected row, the anchor, inclusive of both, regardless of whether the
+// anchor is above or below the current row. If there is more than one selected
+// row then the anchor is the selected row nearest to the current row, and if
+// rows above and below are equally near then the row above is chosen. If there
+// are no selected rows then no action is taken.
+func (m *Model[V]) SelectRange() {
+	if !m.selectable {
 		return
 	}
-	// Determine the first row to select, and the number of rows to select.
-	first := -1
-	n := 0
+	anchor := -1
 	for i, row := range m.rows {
-		if i == m.currentRowIndex && first > -1 && first < m.currentRowIndex {
-			// Select rows before and including current row
-			n = m.currentRowIndex - first + 1
-			break
+		if row.header {
+			continue
 		}
 		if _, ok := m.selected[row.ID]; !ok {
 			// Ignore unselected rows
 			continue
 		}
-		if i > m.currentRowIndex {
-			// Select rows including current row and all rows up to but not
-			// including next selected row
-			first = m.currentRowIndex
-			n = i - m.currentRowIndex
-			break
+		if anchor < 0 || abs(i-m.currentRowIndex) < abs(anchor-m.currentRowIndex) {
+			anchor = i
 		}
-		// Start selecting rows after this currently selected row.
-		first = i + 1
 	}
-	for _, row := range m.rows[first : first+n] {
+	if anchor < 0 {
+		return
+	}
+	first, last := min(anchor, m.currentRowIndex), max(anchor, m.currentRowIndex)
+	for _, row := range m.rows[first : last+1] {
+		if row.header {
+			continue
+		}
 		m.selected[row.ID] = row.Value
 	}
 }
 
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
 // SetItems overwrites all existing items in the table with items.
 func (m *Model[V]) SetItems(items ...V) {
 	m.items = make(map[resource.ID]V)
 	m.rendered = make(map[resource.ID]RenderedRow)
+	m.filterable = make(map[resource.ID]RenderedRow)
 	m.AddItems(items...)
 }
 
@@ -484,15 +806,62 @@ func (m *Model[V]) AddItems(items ...V) {
 		// Add/update item
 		m.items[item.GetID()] = item
 		// (Re-)render item's row.
-		m.rendered[item.GetID()] = m.rowRenderer(item)
+		m.render(item)
 	}
 	m.setRows(maps.Values(m.items)...)
 }
 
+// render renders the item's row, caching both the rendered row and its
+// content stripped of ANSI escape codes in readiness for filtering.
+func (m *Model[V]) render(item V) {
+	rendered := m.rowRenderer(item)
+	m.rendered[item.GetID()] = rendered
+	m.filterable[item.GetID()] = stripAnsi(rendered)
+}
+
+// stripAnsi returns a copy of the rendered row with ANSI escape codes removed
+// from each cell.
+func stripAnsi(rendered RenderedRow) RenderedRow {
+	stripped := make(RenderedRow, len(rendered))
+	for k, col := range rendered {
+		stripped[k] = internal.StripAnsi(col)
+	}
+	return stripped
+}
+
+// Rerender re-renders the visible rows of those items for which fn returns
+// true. Unlike AddItems, the rows are not re-sorted or re-filtered, which makes
+// it suitable for refreshing content that changes with the passage of time,
+// e.g. upon every tick of the spinner. Rows out of view are skipped, so that a
+// table with many rows is cheap to refresh, and are instead re-rendered by a
+// later call once they come into view.
+func (m *Model[V]) Rerender(fn func(V) bool) {
+	start := min(m.start, len(m.rows))
+	end := min(len(m.rows), start+max(0, m.visibleRows()))
+	for _, row := range m.rows[start:end] {
+		if row.header || !fn(row.Value) {
+			continue
+		}
+		m.render(row.Value)
+	}
+}
+
+// RerenderAll re-renders the rows of every item, including those out of view,
+// e.g. after a change to how items are rendered.
+func (m *Model[V]) RerenderAll() {
+	m.AddItems(maps.Values(m.items)...)
+}
+
 func (m *Model[V]) removeItem(item V) {
 	delete(m.rendered, item.GetID())
+	delete(m.filterable, item.GetID())
 	delete(m.items, item.GetID())
 	delete(m.selected, item.GetID())
+	if m.groupFunc != nil {
+		// Re-group rows, removing the header of a group left empty.
+		m.setRows(maps.Values(m.items)...)
+		return
+	}
 	for i, row := range m.rows {
 		if row.ID == item.GetID() {
 			// TODO: this might well produce a memory leak. See note:
@@ -515,54 +884,115 @@ func (m *Model[V]) removeItem(item V) {
 func (m *Model[V]) setRows(items ...V) {
 	selected := make(map[resource.ID]V)
 	m.rows = make([]Row[V], 0, len(items))
+	// scores records how well each row fuzzily matches the filter.
+	var scores map[resource.ID]int
+	if m.fuzzy && m.filter.Value() != "" {
+		scores = make(map[resource.ID]int, len(items))
+	}
+	terms := m.parseFilter(m.filter.Value())
 	for _, item := range items {
-		if m.filterVisible() && !m.matchFilter(item.GetID()) {
-			// Skip item that doesn't match filter
-			continue
-		}
-		m.rows = append(m.rows, Row[V]{ID: item.GetID(), Value: item})
+		// Retain the selection of items even if they are filtered out, so
+		// that items selected whilst filtering remain selected once the
+		// filter is cleared.
 		if m.selectable {
 			if _, ok := m.selected[item.GetID()]; ok {
 				selected[item.GetID()] = item
 			}
 		}
+		if m.filterVisible() {
+			score, ok := m.matchFilter(item.GetID(), terms)
+			if !ok {
+				// Skip item that doesn't match filter
+				continue
+			}
+			if scores != nil {
+				scores[item.GetID()] = score
+			}
+		}
+		if m.predicate != nil && !m.predicate(item) {
+			// Skip item that doesn't satisfy predicate
+			continue
+		}
+		m.rows = append(m.rows, Row[V]{ID: item.GetID(), Value: item})
 	}
 	m.selected = selected
-	// Sort rows in-place
-	if m.sortFunc != nil {
+	// Sort rows in-place, ranking the best fuzzy matches first.
+	sortFunc := m.currentSortFunc()
+	if scores != nil || sortFunc != nil {
 		slices.SortFunc(m.rows, func(i, j Row[V]) int {
-			return m.sortFunc(i.Value, j.Value)
+			if scores != nil && scores[i.ID] != scores[j.ID] {
+				return scores[j.ID] - scores[i.ID]
+			}
+			if sortFunc == nil {
+				return 0
+			}
+			return sortFunc(i.Value, j.Value)
 		})
 	}
-	// Track current row index
+	if m.groupFunc != nil {
+		m.rows = m.groupRows(m.rows)
+	}
+	// Track current row index, following the current row to its new position
+	// should the rows have been re-sorted.
+	previousIndex := m.currentRowIndex
 	m.currentRowIndex = -1
 	for i, row := range m.rows {
-		if row.ID == m.currentRowID {
+		if m.isCurrent(row) {
 			m.currentRowIndex = i
 			break
 		}
 	}
 	// Check if item corresponding to current row doesn't exist, which occurs
-	// the very first time the table is populated. If so, set current row to the
-	// first row.
-	if len(m.rows) > 0 && m.currentRowIndex == -1 {
-		m.currentRowIndex = 0
-		m.currentRowID = m.rows[m.currentRowIndex].ID
+	// the very first time the table is populated, or when the item has been
+	// removed or filtered out. If so, keep the current row at the same
+	// position, within the bounds of the remaining rows.
+	if len(m.rows) > 0 {
+		if m.currentRowIndex == -1 {
+			m.currentRowIndex = clamp(previousIndex, 0, len(m.rows)-1)
+		}
+		// The current row may be the header of a group that has since been
+		// expanded.
+		m.skipHeader(1)
 	}
 	m.setStart()
 }
 
-// matchFilter returns true if the item with the given ID matches the filter
-// value.
-func (m *Model[V]) matchFilter(id resource.ID) bool {
-	for _, col := range m.rendered[id] {
-		// Remove ANSI escapes code before filtering
-		stripped := internal.StripAnsi(col)
-		if strings.Contains(stripped, m.filter.Value()) {
-			return true
-		}
+// SetPredicate hides those items for which fn returns false. Set fn to nil to
+// show all items.
+func (m *Model[V]) SetPredicate(fn func(V) bool) {
+	m.predicate = fn
+	m.setRows(maps.Values(m.items)...)
+}
+
+// ToggleFuzzy toggles between fuzzy matching and substring matching of the
+// filter value.
+func (m *Model[V]) ToggleFuzzy() {
+	m.fuzzy = !m.fuzzy
+	m.regex = false
+	m.setFilterPrompt()
+	m.setRows(maps.Values(m.items)...)
+}
+
+// ToggleRegex toggles between matching the filter value as a regular
+// expression and substring matching.
+func (m *Model[V]) ToggleRegex() {
+	m.regex = !m.regex
+	m.fuzzy = false
+	m.filterInvalid = false
+	m.lastRegexTerm = nil
+	m.setFilterPrompt()
+	m.setRows(maps.Values(m.items)...)
+}
+
+func (m *Model[V]) setFilterPrompt() {
+	switch {
+	case m.fuzzy:
+		m.filter.Prompt = "Fuzzy filter: "
+	case m.regex:
+		m.filter.Prompt = "Regex filter: "
+	default:
+		m.filter.Prompt = "Filter: "
 	}
-	return false
 }
 
 // MoveUp moves the current row up by any number of rows.
@@ -580,12 +1010,48 @@ func (m *Model[V]) MoveDown(n int) {
 func (m *Model[V]) moveCurrentRow(n int) {
 	if len(m.rows) > 0 {
 		m.currentRowIndex = clamp(m.currentRowIndex+n, 0, len(m.rows)-1)
-		m.currentRowID = m.rows[m.currentRowIndex].ID
+		direction := 1
+		if n < 0 {
+			direction = -1
+		}
+		m.skipHeader(direction)
 		m.setStart()
 	}
 }
 
+// skipHeader makes the row at the current row index the current row, unless
+// the cursor may not rest upon it, in which case the nearest row in the given
+// direction on which it may rest is made the current row instead, or failing
+// that, the nearest row in the opposite direction.
+func (m *Model[V]) skipHeader(direction int) {
+	for _, step := range []int{direction, -direction} {
+		for i := m.currentRowIndex; i >= 0 && i < len(m.rows); i += step {
+			if m.navigable(m.rows[i]) {
+				m.setCurrentRow(i)
+				return
+			}
+		}
+	}
+	m.setCurrentRow(m.currentRowIndex)
+}
+
 func (m *Model[V]) setStart() {
+	if m.paginated() {
+		// The first visible row is the first row of the current row's page.
+		m.start = (m.currentRowIndex / m.pageSize()) * m.pageSize()
+		return
+	}
+	if m.wrapping() && len(m.rows) > 0 {
+		// Rows may span several lines. Start index must be at least the index
+		// of the first of the rows that fit above and including the current
+		// row, and at most the lesser of the current row index and the index
+		// of the first of the rows that fit above and including the last row.
+		area := m.rowAreaHeight()
+		minimum := m.firstFitting(m.currentRowIndex, area)
+		maximum := min(m.currentRowIndex, m.firstFitting(len(m.rows)-1, area))
+		m.start = clamp(m.start, minimum, maximum)
+		return
+	}
 	// Start index must be at least the current row index minus the max number
 	// of visible rows.
 	minimum := max(0, m.currentRowIndex-m.rowAreaHeight()+1)
@@ -597,6 +1063,17 @@ func (m *Model[V]) setStart() {
 	m.start = clamp(m.start, minimum, maximum)
 }
 
+// gotoRow makes the row with the given ID the current row. If there is no such
+// row, e.g. it is filtered out, then the current row is unchanged.
+func (m *Model[V]) gotoRow(id resource.ID) {
+	for i, row := range m.rows {
+		if row.ID == id {
+			m.moveCurrentRow(i - m.currentRowIndex)
+			return
+		}
+	}
+}
+
 // GotoTop makes the top row the current row.
 func (m *Model[V]) GotoTop() {
 	m.MoveUp(m.currentRowIndex)
@@ -608,13 +1085,25 @@ func (m *Model[V]) GotoBottom() {
 }
 
 func (m Model[V]) headersView() string {
-	var s = make([]string, 0, len(m.cols))
-	for _, col := range m.cols {
+	visible := m.visibleColumns()
+	var s = make([]string, 0, len(visible))
+	for _, i := range visible {
+		col := m.cols[i]
 		style := lipgloss.NewStyle().Width(col.Width).MaxWidth(col.Width).Inline(true)
 		if col.RightAlign {
 			style = style.AlignHorizontal(lipgloss.Right)
 		}
-		renderedCell := style.Render(runewidth.Truncate(col.Title, col.Width, "…"))
+		if i == m.activeColumn {
+			// Highlight column selected for reordering
+			style = style.Bold(true).Underline(true)
+		}
+		title := runewidth.Truncate(col.Title, col.Width, "…")
+		if col.Key == m.sortColumn {
+			// Indicate the column by which rows are sorted, truncating the
+			// title if necessary to make room for the indicator.
+			title = runewidth.Truncate(col.Title, col.Width-2, "…") + " " + m.sortIndicator()
+		}
+		renderedCell := style.Render(title)
 		s = append(s, tui.Regular.Padding(0, 1).Render(renderedCell))
 	}
 	return lipgloss.JoinHorizontal(lipgloss.Left, s...)
@@ -622,10 +1111,13 @@ func (m Model[V]) headersView() string {
 
 func (m *Model[V]) renderRow(rowIdx int) string {
 	row := m.rows[rowIdx]
+	if row.header {
+		return m.renderHeader(row, rowIdx == m.currentRowIndex)
+	}
 
 	var (
-		background lipgloss.Color
-		foreground lipgloss.Color
+		background lipgloss.TerminalColor
+		foreground lipgloss.TerminalColor
 		current    bool
 		selected   bool
 	)
@@ -647,20 +1139,34 @@ func (m *Model[V]) renderRow(rowIdx int) string {
 	}
 
 	cells := m.rendered[row.ID]
-	styledCells := make([]string, len(m.cols))
-	for i, col := range m.cols {
-		content := cells[col.Key]
-		// Truncate content if it is wider than column
-		truncated := col.TruncationFunc(content, col.Width, "…")
-		// Ensure content is all on one line.
-		style := lipgloss.NewStyle().
-			Width(col.Width).
-			MaxWidth(col.Width).
-			Inline(true)
-		if col.RightAlign {
-			style = style.AlignHorizontal(lipgloss.Right)
+	visible := m.visibleColumns()
+	styledCells := make([]string, len(visible))
+	for i, colIdx := range visible {
+		col := m.cols[colIdx]
+		content := m.cellContent(cells, i, col)
+		var inlined string
+		if col.Wrap {
+			// Wrap content across lines.
+			style := lipgloss.NewStyle().
+				Width(col.Width).
+				MaxWidth(col.Width)
+			if col.RightAlign {
+				style = style.AlignHorizontal(lipgloss.Right)
+			}
+			inlined = style.Render(content)
+		} else {
+			// Truncate content if it is wider than column
+			truncated := col.TruncationFunc(content, col.Width, "…")
+			// Ensure content is all on one line.
+			style := lipgloss.NewStyle().
+				Width(col.Width).
+				MaxWidth(col.Width).
+				Inline(true)
+			if col.RightAlign {
+				style = style.AlignHorizontal(lipgloss.Right)
+			}
+			inlined = style.Render(truncated)
 		}
-		inlined := style.Render(truncated)
 		// Apply block-styling to content
 		boxed := lipgloss.NewStyle().
 			Padding(0, 1).
@@ -2,9 +2,13 @@ package table
 
 import (
 	"slices"
+	"strconv"
+	"strings"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/tui"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/maps"
@@ -67,6 +71,43 @@ func TestTable_ToggleSelection(t *testing.T) {
 	assert.Equal(t, resource0, tbl.selected[resource0.ID])
 }
 
+func TestTable_InvertSelection(t *testing.T) {
+	t.Run("all rows", func(t *testing.T) {
+		tbl := setupTest()
+		tbl.ToggleSelectionByID(resource1.ID)
+		tbl.ToggleSelectionByID(resource4.ID)
+
+		tbl.InvertSelection()
+
+		assert.ElementsMatch(t,
+			[]resource.ID{resource0.ID, resource2.ID, resource3.ID, resource5.ID},
+			maps.Keys(tbl.selected),
+		)
+	})
+
+	t.Run("only rows satisfying predicate", func(t *testing.T) {
+		tbl := setupTest()
+		tbl.SetPredicate(func(v testResource) bool { return v.n%2 == 0 })
+		tbl.ToggleSelectionByID(resource2.ID)
+
+		tbl.InvertSelection()
+
+		assert.ElementsMatch(t,
+			[]resource.ID{resource0.ID, resource4.ID},
+			maps.Keys(tbl.selected),
+		)
+	})
+
+	t.Run("not selectable", func(t *testing.T) {
+		tbl := setupTest()
+		tbl.selectable = false
+
+		tbl.InvertSelection()
+
+		assert.Empty(t, tbl.selected)
+	})
+}
+
 func TestTable_SelectRange(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -113,6 +154,30 @@ func TestTable_SelectRange(t *testing.T) {
 			cursor:   2,                           // third row
 			want:     []resource.ID{resource2.ID, resource3.ID, resource4.ID, resource5.ID},
 		},
+		{
+			name:     "select rows between cursor in first row and selected third row",
+			selected: []resource.ID{resource2.ID}, // third row
+			cursor:   0,                           // first row
+			want:     []resource.ID{resource0.ID, resource1.ID, resource2.ID},
+		},
+		{
+			name:     "select no range when cursor is on a selected row, ignoring selected last row",
+			selected: []resource.ID{resource2.ID, resource5.ID}, // third and last row
+			cursor:   2,                                         // third row
+			want:     []resource.ID{resource2.ID, resource5.ID},
+		},
+		{
+			name:     "select rows between cursor and nearest selected row below",
+			selected: []resource.ID{resource0.ID, resource4.ID}, // first and fifth row
+			cursor:   3,                                         // fourth row
+			want:     []resource.ID{resource0.ID, resource3.ID, resource4.ID},
+		},
+		{
+			name:     "select rows between cursor and selected row above when selected rows are equally near",
+			selected: []resource.ID{resource1.ID, resource5.ID}, // second and last row
+			cursor:   3,                                         // fourth row
+			want:     []resource.ID{resource1.ID, resource2.ID, resource3.ID, resource5.ID},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -138,3 +203,424 @@ func sortStrings(i, j resource.ID) int {
 	}
 	return 1
 }
+
+func TestTable_SetPredicate(t *testing.T) {
+	tbl := setupTest()
+
+	// Hide odd numbered rows
+	tbl.SetPredicate(func(v testResource) bool { return v.n%2 == 0 })
+
+	got := make([]testResource, len(tbl.rows))
+	for i, row := range tbl.rows {
+		got[i] = row.Value
+	}
+	assert.Equal(t, []testResource{resource0, resource2, resource4}, got)
+
+	// Show all rows again
+	tbl.SetPredicate(nil)
+	assert.Len(t, tbl.rows, 6)
+}
+
+func TestTable_DefaultFilter(t *testing.T) {
+	renderer := func(v testResource) RenderedRow {
+		return RenderedRow{"n": strconv.Itoa(v.n)}
+	}
+	tbl := New(nil, renderer, 0, 0, WithDefaultFilter[testResource]("3"))
+	tbl.SetItems(resource0, resource1, resource2, resource3, resource4, resource5)
+
+	if assert.Len(t, tbl.rows, 1) {
+		assert.Equal(t, resource3, tbl.rows[0].Value)
+	}
+	assert.True(t, tbl.filterVisible())
+
+	// Clearing the filter shows all rows
+	tbl, _ = tbl.Update(tui.FilterCloseMsg{})
+	assert.Len(t, tbl.rows, 6)
+}
+
+func TestTable_FollowNew(t *testing.T) {
+	tests := []struct {
+		name   string
+		follow bool
+		want   testResource
+	}{
+		{"follow new item", true, resource5},
+		{"do not follow new item", false, resource0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer := func(v testResource) RenderedRow { return nil }
+			tbl := New(nil, renderer, 0, 0,
+				WithSortFunc(func(i, j testResource) int { return i.n - j.n }),
+				WithFollowNew[testResource](tt.follow),
+			)
+			tbl.SetItems(resource0, resource1, resource2, resource3, resource4)
+
+			tbl, _ = tbl.Update(resource.Event[testResource]{
+				Type:    resource.CreatedEvent,
+				Payload: resource5,
+			})
+
+			got, ok := tbl.CurrentRow()
+			require.True(t, ok)
+			assert.Equal(t, tt.want, got.Value)
+		})
+	}
+}
+
+func TestTable_FuzzyFilter(t *testing.T) {
+	paths := map[resource.ID]string{
+		resource0.ID: "dev/networking",
+		resource1.ID: "prod/compute",
+		resource2.ID: "prod/networking",
+		resource3.ID: "staging/pipelines",
+	}
+	renderer := func(v testResource) RenderedRow {
+		return RenderedRow{"path": paths[v.ID]}
+	}
+	tbl := New(nil, renderer, 0, 0,
+		WithSortFunc(func(i, j testResource) int { return i.n - j.n }),
+		WithDefaultFilter[testResource]("prodnet"),
+	)
+	tbl.SetItems(resource0, resource1, resource2, resource3)
+
+	// Substring matching is the default.
+	assert.Len(t, tbl.rows, 0)
+
+	tbl.ToggleFuzzy()
+
+	// The best match is ranked first, overriding the sort func.
+	got := make([]testResource, len(tbl.rows))
+	for i, row := range tbl.rows {
+		got[i] = row.Value
+	}
+	assert.Equal(t, []testResource{resource2}, got)
+
+	// Loosen the filter to match more rows.
+	tbl.filter.SetValue("pn")
+	tbl.setRows(maps.Values(tbl.items)...)
+	if assert.Len(t, tbl.rows, 2) {
+		assert.Equal(t, resource2, tbl.rows[0].Value)
+	}
+
+	// Toggling fuzzy matching off reverts to substring matching.
+	tbl.ToggleFuzzy()
+	assert.Len(t, tbl.rows, 0)
+}
+
+func TestTable_RegexFilter(t *testing.T) {
+	paths := map[resource.ID]string{
+		resource0.ID: "prod-vpc-db",
+		resource1.ID: "prod-vpc",
+		resource2.ID: "dev-vpc-db",
+		resource3.ID: "prod-eks-db",
+	}
+	renderer := func(v testResource) RenderedRow {
+		return RenderedRow{"path": paths[v.ID]}
+	}
+	tbl := New([]Column{{Key: "path", Title: "PATH"}}, renderer, 0, 0,
+		WithSortFunc(func(i, j testResource) int { return i.n - j.n }),
+		WithDefaultFilter[testResource]("^prod-.*-db$"),
+	)
+	tbl.SetItems(resource0, resource1, resource2, resource3)
+
+	// Substring matching is the default.
+	assert.Len(t, tbl.rows, 0)
+
+	tbl.ToggleRegex()
+	assert.Equal(t, []testResource{resource0, resource3}, rowValues(tbl))
+	assert.False(t, tbl.filterInvalid)
+
+	// An invalid expression retains the rows matching the last valid one.
+	tbl.filter.SetValue("^prod-(")
+	tbl.setRows(maps.Values(tbl.items)...)
+	assert.Equal(t, []testResource{resource0, resource3}, rowValues(tbl))
+	assert.True(t, tbl.filterInvalid)
+	assert.Contains(t, tbl.View(), "(invalid regex)")
+
+	// An expression prefixed with a column matches only that column.
+	tbl.filter.SetValue("path:vpc$")
+	tbl.setRows(maps.Values(tbl.items)...)
+	assert.Equal(t, []testResource{resource1}, rowValues(tbl))
+	assert.False(t, tbl.filterInvalid)
+
+	// Toggling regex matching off reverts to substring matching.
+	tbl.ToggleRegex()
+	assert.Len(t, tbl.rows, 0)
+}
+
+func TestTable_RegexFilter_InvalidFromStart(t *testing.T) {
+	renderer := func(v testResource) RenderedRow {
+		return RenderedRow{"n": strconv.Itoa(v.n)}
+	}
+	tbl := New(nil, renderer, 0, 0,
+		WithDefaultFilter[testResource]("["),
+	)
+	tbl.ToggleRegex()
+	tbl.SetItems(resource0, resource1)
+
+	// Without a valid expression to fall back on, rows are unfiltered.
+	assert.Len(t, tbl.rows, 2)
+	assert.True(t, tbl.filterInvalid)
+}
+
+// rowValues returns the values of the table's rows, in order.
+func rowValues(tbl Model[testResource]) []testResource {
+	got := make([]testResource, len(tbl.rows))
+	for i, row := range tbl.rows {
+		got[i] = row.Value
+	}
+	return got
+}
+
+func TestTable_FilterDoesNotRerender(t *testing.T) {
+	var renders int
+	renderer := func(v testResource) RenderedRow {
+		renders++
+		return RenderedRow{"n": tui.Bold.Render(strconv.Itoa(v.n))}
+	}
+	tbl := New(nil, renderer, 0, 0)
+	tbl.SetItems(resource0, resource1, resource2, resource3, resource4, resource5)
+	require.Equal(t, 6, renders)
+
+	tbl, _ = tbl.Update(tui.FilterFocusReqMsg{})
+	tbl, _ = tbl.Update(tui.FilterKeyMsg{Type: tea.KeyRunes, Runes: []rune("3")})
+	tbl, _ = tbl.Update(filterMsg{seq: tbl.filterSeq})
+
+	// Rows are filtered using content cached when they were rendered...
+	if assert.Len(t, tbl.rows, 1) {
+		assert.Equal(t, resource3, tbl.rows[0].Value)
+	}
+	assert.Equal(t, 6, renders)
+
+	// ...and only updated items are re-rendered.
+	tbl.AddItems(resource3)
+	assert.Equal(t, 7, renders)
+}
+
+func TestTable_RerenderVisibleRowsOnly(t *testing.T) {
+	var rendered []int
+	renderer := func(v testResource) RenderedRow {
+		rendered = append(rendered, v.n)
+		return RenderedRow{"n": strconv.Itoa(v.n)}
+	}
+	items := make([]testResource, 100)
+	for i := range items {
+		items[i] = testResource{n: i, ID: resource.NewID(resource.Workspace)}
+	}
+	tbl := New(nil, renderer, 100, 10,
+		WithSortFunc(func(i, j testResource) int { return i.n - j.n }),
+	)
+	tbl.SetItems(items...)
+	visible := tbl.visibleRows()
+	require.Less(t, visible, len(items))
+
+	rendered = nil
+	tbl.Rerender(func(testResource) bool { return true })
+	assert.Len(t, rendered, visible)
+
+	// Only rows for which fn returns true are re-rendered.
+	rendered = nil
+	tbl.Rerender(func(v testResource) bool { return v.n%2 == 0 })
+	for _, n := range rendered {
+		assert.Equal(t, 0, n%2)
+	}
+
+	// Rows coming into view are re-rendered.
+	tbl.GotoBottom()
+	rendered = nil
+	tbl.Rerender(func(testResource) bool { return true })
+	assert.Contains(t, rendered, len(items)-1)
+	assert.NotContains(t, rendered, 0)
+
+	// All rows are re-rendered, including those out of view.
+	rendered = nil
+	tbl.RerenderAll()
+	assert.Len(t, rendered, len(items))
+}
+
+// BenchmarkTable_Filter measures the time and allocations taken to edit the
+// filter value of a table with many rows, along with the number of rows
+// rendered per edit, which should be zero because filtering matches their
+// cached content.
+func BenchmarkTable_Filter(b *testing.B) {
+	var renders int
+	renderer := func(v testResource) RenderedRow {
+		renders++
+		return RenderedRow{
+			"id": v.ID.String(),
+			"n":  tui.Bold.Render(strconv.Itoa(v.n)),
+		}
+	}
+	items := make([]testResource, 500)
+	for i := range items {
+		items[i] = testResource{n: i, ID: resource.NewID(resource.Workspace)}
+	}
+	tbl := New(nil, renderer, 100, 50)
+	tbl.SetItems(items...)
+	tbl, _ = tbl.Update(tui.FilterFocusReqMsg{})
+
+	renders = 0
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := range b.N {
+		// Alternate between typing a character and deleting it.
+		key := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1")}
+		if i%2 == 1 {
+			key = tea.KeyMsg{Type: tea.KeyBackspace}
+		}
+		tbl, _ = tbl.Update(tui.FilterKeyMsg(key))
+		tbl, _ = tbl.Update(filterMsg{seq: tbl.filterSeq})
+	}
+	b.ReportMetric(float64(renders)/float64(b.N), "renders/op")
+}
+
+func TestTable_CurrentRowFollowsItem(t *testing.T) {
+	tbl := setupTest()
+	tbl.MoveDown(3)
+
+	// Updating the item re-sorts it to the bottom of the table, and the
+	// current row follows it.
+	updated := testResource{n: 10, ID: resource3.ID}
+	tbl.AddItems(updated)
+
+	got, ok := tbl.CurrentRow()
+	require.True(t, ok)
+	assert.Equal(t, updated, got.Value)
+	assert.Equal(t, 5, tbl.currentRowIndex)
+}
+
+func TestTable_CurrentRowFilteredOut(t *testing.T) {
+	tbl := setupTest()
+	tbl.MoveDown(3)
+
+	// Hide odd numbered rows, including the current row. The current row stays
+	// at the same position, within the bounds of the remaining rows.
+	tbl.SetPredicate(func(v testResource) bool { return v.n%2 == 0 })
+
+	got, ok := tbl.CurrentRow()
+	require.True(t, ok)
+	assert.Equal(t, resource4, got.Value)
+}
+
+func TestTable_Mouse(t *testing.T) {
+	setup := func() Model[testResource] {
+		renderer := func(v testResource) RenderedRow { return nil }
+		tbl := New(nil, renderer, 100, 20,
+			WithSortFunc(func(i, j testResource) int { return i.n - j.n }),
+		)
+		tbl.SetItems(resource0, resource1, resource2, resource3, resource4, resource5)
+		return tbl
+	}
+	press := func(button tea.MouseButton, y int) tea.MouseMsg {
+		return tea.MouseMsg{Button: button, Action: tea.MouseActionPress, Y: y}
+	}
+
+	t.Run("wheel", func(t *testing.T) {
+		tbl := setup()
+
+		tbl, _ = tbl.Update(press(tea.MouseButtonWheelDown, 0))
+		tbl, _ = tbl.Update(press(tea.MouseButtonWheelDown, 0))
+		assert.Equal(t, 2, tbl.currentRowIndex)
+
+		tbl, _ = tbl.Update(press(tea.MouseButtonWheelUp, 0))
+		assert.Equal(t, 1, tbl.currentRowIndex)
+	})
+
+	t.Run("click row", func(t *testing.T) {
+		tbl := setup()
+
+		// The first row is beneath the top border and the header.
+		tbl, _ = tbl.Update(press(tea.MouseButtonLeft, 5))
+		assert.Equal(t, 3, tbl.currentRowIndex)
+		assert.Empty(t, tbl.selected)
+	})
+
+	t.Run("click row with filter visible", func(t *testing.T) {
+		tbl := setup()
+		tbl, _ = tbl.Update(tui.FilterFocusReqMsg{})
+
+		tbl, _ = tbl.Update(press(tea.MouseButtonLeft, 5))
+		assert.Equal(t, 1, tbl.currentRowIndex)
+	})
+
+	t.Run("click row with header rule", func(t *testing.T) {
+		tbl := setup()
+		WithHeaderRule[testResource](true)(&tbl)
+
+		tbl, _ = tbl.Update(press(tea.MouseButtonLeft, 5))
+		assert.Equal(t, 2, tbl.currentRowIndex)
+	})
+
+	t.Run("ctrl-click row", func(t *testing.T) {
+		tbl := setup()
+
+		msg := press(tea.MouseButtonLeft, 3)
+		msg.Ctrl = true
+		tbl, _ = tbl.Update(msg)
+		assert.Equal(t, 1, tbl.currentRowIndex)
+		assert.Contains(t, tbl.selected, resource1.ID)
+	})
+
+	t.Run("ignore clicks outside of rows", func(t *testing.T) {
+		tbl := setup()
+		tbl.MoveDown(2)
+
+		// Click header
+		tbl, _ = tbl.Update(press(tea.MouseButtonLeft, 1))
+		assert.Equal(t, 2, tbl.currentRowIndex)
+		// Click below last row
+		tbl, _ = tbl.Update(press(tea.MouseButtonLeft, 10))
+		assert.Equal(t, 2, tbl.currentRowIndex)
+	})
+}
+
+func TestTable_HeaderRule(t *testing.T) {
+	cols := []Column{{Key: "n", Title: "N", FlexFactor: 1}}
+	renderer := func(v testResource) RenderedRow {
+		return RenderedRow{"n": strconv.Itoa(v.n)}
+	}
+	for _, rule := range []bool{false, true} {
+		tbl := New(cols, renderer, 30, 9, WithHeaderRule[testResource](rule))
+		tbl.SetItems(resource0, resource1, resource2, resource3, resource4, resource5)
+
+		lines := strings.Split(tbl.View(), "\n")
+		// Lines beneath the top border: header, optional rule, rows.
+		if rule {
+			assert.Equal(t, "│"+strings.Repeat("─", 28)+"│", lines[2])
+			assert.Equal(t, 5, tbl.visibleRows())
+		} else {
+			assert.NotContains(t, lines[2], "──")
+			assert.Equal(t, 6, tbl.visibleRows())
+		}
+	}
+}
+
+func TestTable_TinyDimensions(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		// number of visible rows
+		rows int
+	}{
+		{"zero", 0, 0, 0},
+		{"one row", 80, 1, 0},
+		{"one column", 1, 24, 6},
+		{"borders only", 2, 2, 0},
+		{"header only", 10, 3, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tbl := setupScrollTest(WithHeaderRule[testResource](true))
+			tbl.SetItems(resource0, resource1, resource2, resource3, resource4, resource5)
+			tbl.GotoBottom()
+
+			tbl.setDimensions(tt.width, tt.height)
+
+			assert.GreaterOrEqual(t, tbl.height, 0)
+			assert.GreaterOrEqual(t, tbl.width, 0)
+			assert.Equal(t, tt.rows, tbl.visibleRows())
+			assert.NotPanics(t, func() { _ = tbl.View() })
+		})
+	}
+}
@@ -13,6 +13,25 @@ func TruncateRight(s string, w int, tail string) string {
 	return truncate.StringWithTail(s, uint(w), tail)
 }
 
+// TruncateLeft truncates s from the left, retaining its rightmost portion, so
+// that, including the prefix, it is no wider than w cells. Useful for paths,
+// whose rightmost segments are the most specific.
 func TruncateLeft(s string, w int, prefix string) string {
-	return runewidth.TruncateLeft(s, w, prefix)
+	if runewidth.StringWidth(s) <= w {
+		return s
+	}
+	avail := w - runewidth.StringWidth(prefix)
+	if avail <= 0 {
+		return runewidth.Truncate(prefix, max(0, w), "")
+	}
+	runes := []rune(s)
+	i := len(runes)
+	for width := 0; i > 0; i-- {
+		rw := runewidth.RuneWidth(runes[i-1])
+		if width+rw > avail {
+			break
+		}
+		width += rw
+	}
+	return prefix + string(runes[i:])
 }
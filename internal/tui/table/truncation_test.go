@@ -0,0 +1,32 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/leg100/go-runewidth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncateLeft(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		w    int
+		want string
+	}{
+		{"fits", "prod/networking", 15, "prod/networking"},
+		{"truncated", "modules/prod/networking", 16, "…prod/networking"},
+		{"wide runes", "環境/本番/ネットワーク", 14, "…/ネットワーク"},
+		{"wide rune straddling boundary", "ab本番", 4, "…番"},
+		{"no room for content", "prod/networking", 1, "…"},
+		{"no room at all", "prod/networking", 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TruncateLeft(tt.s, tt.w, "…")
+			assert.Equal(t, tt.want, got)
+			// Cell never overflows
+			assert.LessOrEqual(t, runewidth.StringWidth(got), tt.w)
+		})
+	}
+}
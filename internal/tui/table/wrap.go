@@ -0,0 +1,79 @@
+package table
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// wrapping returns true if any column wraps its content, in which case rows
+// may span more than one line.
+func (m Model[V]) wrapping() bool {
+	for _, col := range m.cols {
+		if col.Wrap {
+			return true
+		}
+	}
+	return false
+}
+
+// rowHeight returns the number of lines spanned by the row at the given index.
+func (m Model[V]) rowHeight(rowIdx int) int {
+	height := 1
+	if !m.wrapping() || m.rows[rowIdx].header {
+		return height
+	}
+	cells := m.rendered[m.rows[rowIdx].ID]
+	for i, colIdx := range m.visibleColumns() {
+		col := m.cols[colIdx]
+		if !col.Wrap || col.Width <= 0 {
+			continue
+		}
+		content := m.cellContent(cells, i, col)
+		height = max(height, lipgloss.Height(wrapCell(content, col.Width)))
+	}
+	return height
+}
+
+// cellContent returns the content of the cell in the given column, which is
+// the i-th visible column, as it is to be rendered.
+func (m Model[V]) cellContent(cells RenderedRow, i int, col Column) string {
+	content := cells[col.Key]
+	if i == 0 && m.groupFunc != nil {
+		// Indent rows beneath their group header.
+		content = groupIndent + content
+	}
+	return content
+}
+
+// wrapCell word-wraps content to the given width.
+func wrapCell(content string, width int) string {
+	return lipgloss.NewStyle().Width(width).MaxWidth(width).Render(content)
+}
+
+// firstFitting returns the index of the first of the consecutive rows, ending
+// with the row at the given index, that fit within the given number of lines.
+// The row at the given index is always included, even if it does not fit.
+func (m Model[V]) firstFitting(last, lines int) int {
+	first := last
+	lines -= m.rowHeight(last)
+	for first > 0 && lines-m.rowHeight(first-1) >= 0 {
+		first--
+		lines -= m.rowHeight(first)
+	}
+	return first
+}
+
+// rowAtLine returns the index of the row rendered on the given line of the
+// row area, or false if no row is rendered on that line.
+func (m Model[V]) rowAtLine(line int) (int, bool) {
+	if line < 0 {
+		return 0, false
+	}
+	var lines int
+	for i := range m.visibleRows() {
+		lines += m.rowHeight(m.start + i)
+		if line < lines {
+			return m.start + i, true
+		}
+	}
+	return 0, false
+}
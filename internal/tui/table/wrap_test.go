@@ -0,0 +1,102 @@
+package table
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupWrapTest sets up a table with a wrapping column, in which the rows for
+// resources 1 and 3 span three lines, and the other rows span one line. The
+// row area has room for five lines.
+func setupWrapTest() Model[testResource] {
+	cols := []Column{{Key: "a", Title: "A", Width: 10, Wrap: true}}
+	renderer := func(v testResource) RenderedRow {
+		if v.n%2 == 1 {
+			return RenderedRow{"a": "aaaaaaaa bbbbbbbb cccccccc"}
+		}
+		return RenderedRow{"a": "short"}
+	}
+	// Height of 8 leaves 5 lines for rows after subtracting borders and the
+	// header.
+	tbl := New(cols, renderer, 20, 8,
+		WithSortFunc(func(i, j testResource) int { return i.n - j.n }),
+	)
+	tbl.SetItems(resource0, resource1, resource2, resource3, resource4, resource5)
+	return tbl
+}
+
+func TestTable_Wrap(t *testing.T) {
+	t.Run("row heights", func(t *testing.T) {
+		tbl := setupWrapTest()
+
+		assert.Equal(t, 1, tbl.rowHeight(0))
+		assert.Equal(t, 3, tbl.rowHeight(1))
+	})
+
+	t.Run("visible rows", func(t *testing.T) {
+		tbl := setupWrapTest()
+
+		// Rows 0 (1 line), 1 (3 lines) and 2 (1 line) fill the row area.
+		assert.Equal(t, 3, tbl.visibleRows())
+		assert.Contains(t, tbl.View(), "cccccccc")
+	})
+
+	t.Run("scroll to keep current row in view", func(t *testing.T) {
+		tbl := setupWrapTest()
+
+		tbl.MoveDown(3)
+		// Rows 2 (1 line) and 3 (3 lines) fit, but not row 1 as well.
+		assert.Equal(t, 2, tbl.start)
+
+		tbl.GotoBottom()
+		// Rows 3 (3 lines), 4 (1 line) and 5 (3 lines) cannot all fit, so
+		// the last rows that fit are 4 and 5.
+		assert.Equal(t, 4, tbl.start)
+
+		tbl.GotoTop()
+		assert.Equal(t, 0, tbl.start)
+	})
+
+	t.Run("click wrapped row", func(t *testing.T) {
+		tbl := setupWrapTest()
+
+		// Lines 1 to 3 of the row area belong to row 1. The row area begins
+		// on the third line, beneath the top border and the header.
+		press := tea.MouseMsg{Button: tea.MouseButtonLeft, Action: tea.MouseActionPress, Y: 2 + 3}
+		tbl, _ = tbl.Update(press)
+		assert.Equal(t, 1, tbl.currentRowIndex)
+
+		press.Y = 2 + 4
+		tbl, _ = tbl.Update(press)
+		assert.Equal(t, 2, tbl.currentRowIndex)
+	})
+
+	t.Run("group indent", func(t *testing.T) {
+		cols := []Column{{Key: "a", Title: "A", Width: 10, Wrap: true}}
+		renderer := func(v testResource) RenderedRow {
+			// Fits the column exactly, but not once indented.
+			return RenderedRow{"a": "aaaaa bbbb"}
+		}
+		tbl := New(cols, renderer, 20, 8)
+		tbl.SetItems(resource0)
+		assert.Equal(t, 1, tbl.rowHeight(0))
+
+		tbl.SetGroupFunc(func(testResource) string { return "group" })
+		// The header row is followed by the indented row.
+		assert.Equal(t, 1, tbl.rowHeight(0))
+		assert.Equal(t, 2, tbl.rowHeight(1))
+		assert.Equal(t, 2, strings.Count(tbl.renderRow(1), "\n")+1)
+	})
+
+	t.Run("default is single line", func(t *testing.T) {
+		tbl := setupWrapTest()
+		tbl.cols[0].Wrap = false
+
+		assert.Equal(t, 1, tbl.rowHeight(1))
+		assert.Equal(t, 5, tbl.visibleRows())
+		assert.Equal(t, 1, strings.Count(tbl.renderRow(1), "\n")+1)
+	})
+}
@@ -3,43 +3,91 @@ package task
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/logging"
 	"github.com/leg100/pug/internal/resource"
 	"github.com/leg100/pug/internal/task"
 	"github.com/leg100/pug/internal/tui"
 )
 
+// action is something done to one or more tasks upon the user confirming a
+// prompt.
+type action struct {
+	// name of the action, e.g. "cancel".
+	name string
+	// doing is the name of the action in progress, e.g. "cancelling".
+	doing string
+	// done reports the action as done, formatted with the tasks acted upon,
+	// e.g. "sent cancel signal to %s".
+	done string
+	// fn performs the action on a task.
+	fn func(resource.ID) (*task.Task, error)
+}
+
 // cancel task(s)
-func cancel(tasks *task.Service, taskIDs ...resource.ID) tea.Cmd {
+func cancel(tasks *task.Service, logger logging.Interface, taskIDs ...resource.ID) tea.Cmd {
+	return act(logger, action{
+		name:  "cancel",
+		doing: "cancelling",
+		done:  "sent cancel signal to %s",
+		fn:    tasks.Cancel,
+	}, taskIDs...)
+}
+
+// discard task(s) that have yet to start running
+func discard(tasks *task.Service, logger logging.Interface, taskIDs ...resource.ID) tea.Cmd {
+	return act(logger, action{
+		name:  "discard",
+		doing: "discarding",
+		done:  "discarded %s",
+		fn:    tasks.Discard,
+	}, taskIDs...)
+}
+
+// act prompts the user to confirm the action before performing it on the
+// tasks. Finished tasks are skipped, and failures are logged.
+func act(logger logging.Interface, a action, taskIDs ...resource.ID) tea.Cmd {
 	var (
-		prompt string
+		prompt = strings.ToUpper(a.name[:1]) + a.name[1:]
 		cmd    tea.Cmd
 	)
 	switch len(taskIDs) {
 	case 0:
 		return nil
 	case 1:
-		prompt = "Cancel task?"
+		prompt += " task?"
 		cmd = func() tea.Msg {
-			if _, err := tasks.Cancel(taskIDs[0]); err != nil {
-				return tui.ErrorMsg(fmt.Errorf("cancelling task: %w", err))
+			if _, err := a.fn(taskIDs[0]); errors.Is(err, task.ErrFinished) {
+				return tui.InfoMsg("task has already finished")
+			} else if err != nil {
+				return tui.ErrorMsg(fmt.Errorf("%s task: %w", a.doing, err))
 			}
-			return tui.InfoMsg("sent cancel signal to task")
+			return tui.InfoMsg(fmt.Sprintf(a.done, "task"))
 		}
 	default:
-		prompt = fmt.Sprintf("Cancel %d tasks?", len(taskIDs))
+		prompt += fmt.Sprintf(" %d tasks?", len(taskIDs))
 		cmd = func() tea.Msg {
-			var errored bool
+			var (
+				n       int
+				errored bool
+			)
 			for _, id := range taskIDs {
-				if _, err := tasks.Cancel(id); err != nil {
+				// Finished tasks are skipped.
+				if _, err := a.fn(id); errors.Is(err, task.ErrFinished) {
+					continue
+				} else if err != nil {
+					logger.Error(a.doing+" task", "error", err, "id", id)
 					errored = true
+					continue
 				}
+				n++
 			}
 			if errored {
-				return tui.ErrorMsg(errors.New("one or more cancel requests failed; see logs"))
+				return tui.ErrorMsg(fmt.Errorf("one or more %s requests failed; see logs", a.name))
 			}
-			return tui.InfoMsg(fmt.Sprintf("sent cancel signal to %d tasks", len(taskIDs)))
+			return tui.InfoMsg(fmt.Sprintf(a.done, fmt.Sprintf("%d tasks", n)))
 		}
 	}
 	return tui.YesNoPrompt(prompt, cmd)
@@ -0,0 +1,72 @@
+package task
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/leg100/pug/internal/logging"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/task"
+	"github.com/leg100/pug/internal/tui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLogger struct {
+	logging.Interface
+
+	errors []string
+}
+
+func (l *fakeLogger) Error(msg string, args ...any) {
+	l.errors = append(l.errors, msg)
+}
+
+func TestAct(t *testing.T) {
+	ok := resource.NewID(resource.Task)
+	finished := resource.NewID(resource.Task)
+	failed := resource.NewID(resource.Task)
+
+	a := action{
+		name:  "cancel",
+		doing: "cancelling",
+		done:  "sent cancel signal to %s",
+		fn: func(id resource.ID) (*task.Task, error) {
+			switch id {
+			case finished:
+				return nil, task.ErrFinished
+			case failed:
+				return nil, errors.New("boom")
+			}
+			return &task.Task{}, nil
+		},
+	}
+
+	t.Run("one task", func(t *testing.T) {
+		prompt, isPrompt := act(&fakeLogger{}, a, ok)().(tui.PromptMsg)
+		require.True(t, isPrompt)
+		assert.Equal(t, "Cancel task? (y/N): ", prompt.Prompt)
+
+		got := prompt.Action("y")()
+		assert.Equal(t, tui.InfoMsg("sent cancel signal to task"), got)
+	})
+
+	t.Run("several tasks", func(t *testing.T) {
+		prompt, isPrompt := act(&fakeLogger{}, a, ok, finished, ok)().(tui.PromptMsg)
+		require.True(t, isPrompt)
+		assert.Equal(t, "Cancel 3 tasks? (y/N): ", prompt.Prompt)
+
+		got := prompt.Action("y")()
+		assert.Equal(t, tui.InfoMsg("sent cancel signal to 2 tasks"), got)
+	})
+
+	t.Run("log each failure", func(t *testing.T) {
+		logger := &fakeLogger{}
+		prompt, isPrompt := act(logger, a, failed, ok, failed)().(tui.PromptMsg)
+		require.True(t, isPrompt)
+
+		got := prompt.Action("y")()
+		assert.Equal(t, tui.ErrorMsg(errors.New("one or more cancel requests failed; see logs")), got)
+		assert.Equal(t, []string{"cancelling task", "cancelling task"}, logger.errors)
+	})
+}
@@ -0,0 +1,166 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/leg100/pug/internal/plan"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/task"
+	"github.com/leg100/pug/internal/tui"
+)
+
+// changeStyles style resource changes according to their action, along with a
+// symbol akin to those used by terraform.
+var changeStyles = map[plan.Action]struct {
+	symbol string
+	style  lipgloss.Style
+}{
+	plan.CreateAction:  {"+", tui.Regular.Foreground(tui.Green)},
+	plan.UpdateAction:  {"~", tui.Regular.Foreground(tui.Yellow)},
+	plan.ReplaceAction: {"-/+", tui.Regular.Foreground(tui.Red)},
+	plan.DestroyAction: {"-", tui.Regular.Foreground(tui.Red)},
+	plan.ReadAction:    {"<=", tui.Regular.Foreground(tui.LightBlue)},
+}
+
+// ChangesMaker makes models that list the changes to resources proposed by a
+// plan task.
+type ChangesMaker struct {
+	Plans   *plan.Service
+	Tasks   *task.Service
+	Helpers *tui.Helpers
+}
+
+func (mm *ChangesMaker) Make(id resource.ID, width, height int) (tea.Model, error) {
+	t, err := mm.Tasks.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	m := changesModel{
+		Helpers:   mm.Helpers,
+		plans:     mm.Plans,
+		task:      t,
+		lastState: t.State,
+		width:     width,
+		height:    height,
+	}
+	if err := m.render(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type changesModel struct {
+	*tui.Helpers
+
+	plans     *plan.Service
+	task      *task.Task
+	lastState task.Status
+	viewport  tui.Viewport
+	width     int
+	height    int
+}
+
+func (m changesModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m changesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case resource.Event[*task.Task]:
+		if msg.Payload.ID != m.task.ID {
+			// Ignore event for different task.
+			return m, nil
+		}
+		// Re-render changes once the plan task finishes, comparing against the
+		// state last seen because the payload is the same pointer as m.task.
+		finished := !m.lastState.IsFinal() && msg.Payload.State.IsFinal()
+		m.task = msg.Payload
+		m.lastState = msg.Payload.State
+		if finished {
+			if err := m.render(); err != nil {
+				return m, tui.ReportError(err)
+			}
+		}
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.viewport.SetDimensions(m.viewportWidth(), m.viewportHeight())
+		return m, nil
+	}
+
+	// Handle keyboard and mouse events in the viewport
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m changesModel) View() string {
+	return tui.Border.Render(m.viewport.View())
+}
+
+func (m changesModel) Title() tui.Breadcrumbs {
+	return m.Breadcrumbs("Resource Changes", m.task)
+}
+
+// render populates the viewport with the resource changes.
+func (m *changesModel) render() error {
+	var content string
+	switch m.task.State {
+	case task.Exited:
+		changes, err := m.plans.ResourceChanges(m.task.ID)
+		if err != nil {
+			return err
+		}
+		content = renderChanges(changes)
+	case task.Errored, task.Canceled, task.Discarded:
+		content = fmt.Sprintf("Plan %s: no resource changes to report.", m.task.State)
+	default:
+		content = "Plan has not yet finished."
+	}
+	m.viewport = tui.NewViewport(tui.ViewportOptions{
+		Width:  m.viewportWidth(),
+		Height: m.viewportHeight(),
+	})
+	return m.viewport.AppendContent([]byte(content), true)
+}
+
+func (m changesModel) viewportWidth() int {
+	// Subtract 2 to accommodate borders
+	return max(0, m.width-2)
+}
+
+func (m changesModel) viewportHeight() int {
+	// Subtract 2 to accommodate borders
+	return max(0, m.height-2)
+}
+
+// renderChanges renders resource changes grouped by action, with a heading for
+// each action followed by a line for each resource, prefixed with a symbol
+// and colored according to the action.
+func renderChanges(changes []plan.ResourceChange) string {
+	if len(changes) == 0 {
+		return "No changes. Your infrastructure matches the configuration."
+	}
+	var groups []string
+	for _, action := range plan.Actions {
+		var lines []string
+		for _, change := range changes {
+			if change.Action != action {
+				continue
+			}
+			s := changeStyles[action]
+			lines = append(lines, s.style.Render(fmt.Sprintf("  %s %s", s.symbol, change.Address)))
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		heading := tui.Bold.Render(fmt.Sprintf("%s (%d)", action, len(lines)))
+		groups = append(groups, heading+"\n"+strings.Join(lines, "\n"))
+	}
+	return strings.Join(groups, "\n\n")
+}
@@ -0,0 +1,29 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/leg100/pug/internal"
+	"github.com/leg100/pug/internal/plan"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderChanges(t *testing.T) {
+	changes := []plan.ResourceChange{
+		{Address: "aws_instance.b", Action: plan.DestroyAction},
+		{Address: "aws_instance.a", Action: plan.CreateAction},
+		{Address: "aws_instance.c", Action: plan.CreateAction},
+	}
+	want := `create (2)
+  + aws_instance.a
+  + aws_instance.c
+
+destroy (1)
+  - aws_instance.b`
+	assert.Equal(t, want, internal.StripAnsi(renderChanges(changes)))
+}
+
+func TestRenderChanges_NoChanges(t *testing.T) {
+	got := renderChanges(nil)
+	assert.Equal(t, "No changes. Your infrastructure matches the configuration.", got)
+}
@@ -0,0 +1,156 @@
+package task
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/task"
+	"github.com/leg100/pug/internal/tui"
+)
+
+// errorTailLines is the number of lines at the end of the output of an errored
+// task that are shown alongside its error.
+const errorTailLines = 50
+
+// ErrorMaker makes models that show the error of an errored task alongside the
+// tail of its output.
+type ErrorMaker struct {
+	Tasks   *task.Service
+	Helpers *tui.Helpers
+}
+
+func (mm *ErrorMaker) Make(id resource.ID, width, height int) (tea.Model, error) {
+	t, err := mm.Tasks.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	m := errorModel{
+		Helpers:   mm.Helpers,
+		task:      t,
+		lastState: t.State,
+		width:     width,
+		height:    height,
+	}
+	if err := m.render(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+type errorModel struct {
+	*tui.Helpers
+
+	task      *task.Task
+	lastState task.Status
+	viewport  tui.Viewport
+	width     int
+	height    int
+}
+
+func (m errorModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m errorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case resource.Event[*task.Task]:
+		if msg.Payload.ID != m.task.ID {
+			// Ignore event for different task.
+			return m, nil
+		}
+		// Re-render once the task finishes, e.g. after it is retried. The
+		// payload is updated in place, so rely on the state last seen.
+		finished := !m.lastState.IsFinal() && msg.Payload.State.IsFinal()
+		m.task = msg.Payload
+		m.lastState = msg.Payload.State
+		if finished {
+			if err := m.render(); err != nil {
+				return m, tui.ReportError(err)
+			}
+		}
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.viewport.SetDimensions(m.viewportWidth(), m.viewportHeight())
+		return m, nil
+	}
+
+	// Handle keyboard and mouse events in the viewport
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m errorModel) View() string {
+	return tui.Border.Render(m.viewport.View())
+}
+
+func (m errorModel) Title() tui.Breadcrumbs {
+	return m.Breadcrumbs("Error", m.task)
+}
+
+func (m errorModel) Status() string {
+	return m.TaskStatus(m.task, true)
+}
+
+// render populates the viewport with the error and the tail of the output.
+func (m *errorModel) render() error {
+	m.viewport = tui.NewViewport(tui.ViewportOptions{
+		Width:  m.viewportWidth(),
+		Height: m.viewportHeight(),
+	})
+	return m.viewport.AppendContent([]byte(renderError(m.task)), true)
+}
+
+func (m errorModel) viewportWidth() int {
+	// Subtract 2 to accommodate borders
+	return max(0, m.width-2)
+}
+
+func (m errorModel) viewportHeight() int {
+	// Subtract 2 to accommodate borders
+	return max(0, m.height-2)
+}
+
+// renderError renders the error of a task followed by the tail of its
+// combined stdout and stderr. If there is no output then only the error is
+// rendered.
+func renderError(t *task.Task) string {
+	if t.State != task.Errored || t.Err == nil {
+		return "Task has not errored."
+	}
+	msg := tui.Regular.Foreground(tui.Red).Render(t.Err.Error())
+	output := tail(t.NewReader(true), errorTailLines)
+	if output == "" {
+		return msg
+	}
+	return msg + "\n\n" + tui.Bold.Render("Output") + "\n" + output
+}
+
+// tail returns the last n lines read from r.
+func tail(r io.Reader, n int) string {
+	lines := make([]string, 0, n)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if len(lines) == n {
+			lines = lines[1:]
+		}
+		lines = append(lines, scanner.Text())
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// navigateToError navigates to the error of the task, or reports that the task
+// has not errored.
+func navigateToError(t *task.Task) tea.Cmd {
+	if t.State != task.Errored {
+		return tui.ReportError(errors.New("task has not errored"))
+	}
+	return tui.NavigateTo(tui.TaskErrorKind, tui.WithParent(t.ID))
+}
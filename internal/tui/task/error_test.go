@@ -0,0 +1,40 @@
+package task
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/tui"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTail(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		n     int
+		want  string
+	}{
+		{"empty", "", 3, ""},
+		{"fewer lines", "a\nb\n", 3, "a\nb"},
+		{"more lines", "a\nb\nc\nd\ne\n", 3, "c\nd\ne"},
+		{"no trailing newline", "a\nb\nc", 2, "b\nc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tail(strings.NewReader(tt.input), tt.n))
+		})
+	}
+}
+
+func TestErrorKey_ReadOnly(t *testing.T) {
+	h := &tui.Helpers{ReadOnly: true}
+
+	// The error key shares its key with the shell key, which is mutating
+	// elsewhere, but showing error detail is permitted in read-only mode.
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("!")}
+	assert.Nil(t, h.RefuseMutation(msg, mutatingKeys...))
+	assert.Len(t, h.HideMutations([]key.Binding{localKeys.Error}, mutatingKeys...), 1)
+}
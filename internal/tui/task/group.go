@@ -95,6 +95,11 @@ func (m groupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.skip(msg.Payload) {
 			return m, nil
 		}
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, groupKeys.Report):
+			return m, tui.NavigateTo(tui.TaskGroupReportKind, tui.WithParent(m.group.ID))
+		}
 	}
 
 	// Forward message to wrapped task list model
@@ -116,7 +121,7 @@ func (m *groupModel) skip(tasks ...*task.Task) bool {
 	return false
 }
 
-func (m groupModel) Title() string {
+func (m groupModel) Title() tui.Breadcrumbs {
 	return m.Breadcrumbs("TaskGroup", m.group)
 }
 
@@ -127,9 +132,28 @@ func (m groupModel) Status() string {
 func (m groupModel) HelpBindings() []key.Binding {
 	bindings := []key.Binding{
 		keys.Common.Cancel,
+		localKeys.Discard,
 		keys.Common.Apply,
+		localKeys.ApplyAll,
 		keys.Common.State,
 		keys.Common.Retry,
+		groupKeys.Report,
 	}
+	bindings = m.HideMutations(bindings, mutatingKeys...)
+	bindings = append(bindings, keys.Common.Time)
 	return append(bindings, keys.KeyMapToSlice(split.Keys)...)
 }
+
+// Loading returns true if the list of the group's tasks is yet to load.
+func (m groupModel) Loading() bool {
+	model, ok := m.Model.(tui.ModelLoading)
+	return ok && model.Loading()
+}
+
+// Pagination describes the page of the group's tasks currently visible.
+func (m groupModel) Pagination() string {
+	if model, ok := m.Model.(tui.ModelPagination); ok {
+		return model.Pagination()
+	}
+	return ""
+}
@@ -1,6 +1,7 @@
 package task
 
 import (
+	"io"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -49,6 +50,13 @@ func (m *GroupListMaker) Make(_ resource.ID, width, height int) (tea.Model, erro
 
 	table := table.New(columns, renderer, width, height,
 		table.WithSortFunc(task.SortGroupsByCreated),
+		table.WithColumnOrder[*task.Group](m.Helpers.ColumnOrder...),
+		table.WithPinnedColumn[*task.Group](m.Helpers.PinFirstColumn),
+		table.WithHeaderRule[*task.Group](m.Helpers.HeaderRule),
+		table.WithPagination[*task.Group](m.Helpers.Paginated("task-groups")),
+		table.WithDefaultFilter[*task.Group](m.Helpers.DefaultFilter("task-groups")),
+		table.WithLoading[*task.Group](m.Helpers.Spinner),
+		table.WithCopyFunc(func(g *task.Group) string { return g.ID.String() }),
 	)
 
 	return groupList{
@@ -94,10 +102,30 @@ func (m groupList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
-func (m groupList) Title() string {
+func (m groupList) Title() tui.Breadcrumbs {
 	return m.Breadcrumbs("TaskGroups", nil)
 }
 
+// ExportCSV writes the table in CSV format.
+func (m groupList) ExportCSV(w io.Writer) error {
+	return m.table.ExportCSV(w)
+}
+
+// FilterValue returns the value of the table's filter.
+func (m groupList) FilterValue() string {
+	return m.table.FilterValue()
+}
+
+// Loading returns true if the table is yet to load its items.
+func (m groupList) Loading() bool {
+	return m.table.Loading()
+}
+
+// Pagination describes the page of the table currently visible.
+func (m groupList) Pagination() string {
+	return m.table.Pagination()
+}
+
 func (m groupList) View() string {
 	return m.table.View()
 }
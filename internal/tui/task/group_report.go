@@ -0,0 +1,252 @@
+package task
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/leg100/pug/internal/plan"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/task"
+	"github.com/leg100/pug/internal/tui"
+	"github.com/leg100/pug/internal/tui/table"
+)
+
+var (
+	additionsColumn = table.Column{
+		Key:        "additions",
+		Title:      "ADD",
+		Width:      len("DESTROY"),
+		RightAlign: true,
+	}
+	changesColumn = table.Column{
+		Key:        "changes",
+		Title:      "CHANGE",
+		Width:      len("DESTROY"),
+		RightAlign: true,
+	}
+	destructionsColumn = table.Column{
+		Key:        "destructions",
+		Title:      "DESTROY",
+		Width:      len("DESTROY"),
+		RightAlign: true,
+	}
+)
+
+// GroupReportMaker makes models that report the outcome of each task in a
+// task group, e.g. the changes proposed by each plan in a batch of plans.
+type GroupReportMaker struct {
+	Tasks   *task.Service
+	Helpers *tui.Helpers
+}
+
+func (mm *GroupReportMaker) Make(id resource.ID, width, height int) (tea.Model, error) {
+	group, err := mm.Tasks.GetGroup(id)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := []table.Column{
+		table.ModuleColumn,
+		table.WorkspaceColumn,
+		statusColumn,
+		additionsColumn,
+		changesColumn,
+		destructionsColumn,
+	}
+
+	renderer := func(t *task.Task) table.RenderedRow {
+		row := table.RenderedRow{
+			table.ModuleColumn.Key:    mm.Helpers.TaskModulePath(t),
+			table.WorkspaceColumn.Key: mm.Helpers.TaskWorkspaceName(t),
+			statusColumn.Key:          mm.Helpers.TaskStatus(t, false),
+		}
+		if report, ok := t.Summary.(plan.Report); ok {
+			row[additionsColumn.Key] = strconv.Itoa(report.Additions)
+			row[changesColumn.Key] = strconv.Itoa(report.Changes)
+			row[destructionsColumn.Key] = strconv.Itoa(report.Destructions)
+		}
+		return row
+	}
+
+	table := table.New(columns, renderer, width, height,
+		table.WithSortFunc(byChanges),
+		table.WithColumnSortFunc(statusColumn.Key, task.ByState),
+		table.WithColumnSortFunc(additionsColumn.Key, byReport(func(r plan.Report) int { return r.Additions })),
+		table.WithColumnSortFunc(changesColumn.Key, byReport(func(r plan.Report) int { return r.Changes })),
+		table.WithColumnSortFunc(destructionsColumn.Key, byReport(func(r plan.Report) int { return r.Destructions })),
+		table.WithColumnOrder[*task.Task](mm.Helpers.ColumnOrder...),
+		table.WithPinnedColumn[*task.Task](mm.Helpers.PinFirstColumn),
+		table.WithHeaderRule[*task.Task](mm.Helpers.HeaderRule),
+		table.WithLoading[*task.Task](mm.Helpers.Spinner),
+	)
+
+	return groupReportModel{
+		Helpers: mm.Helpers,
+		table:   table,
+		group:   group,
+	}, nil
+}
+
+type groupReportModel struct {
+	*tui.Helpers
+
+	table table.Model[*task.Task]
+	group *task.Group
+}
+
+func (m groupReportModel) Init() tea.Cmd {
+	return func() tea.Msg {
+		return table.BulkInsertMsg[*task.Task](m.group.Tasks)
+	}
+}
+
+func (m groupReportModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case resource.Event[*task.Task]:
+		// Only handle events for tasks belonging to the group.
+		if !m.group.IncludesTask(msg.Payload.ID) {
+			return m, nil
+		}
+	case table.BulkInsertMsg[*task.Task]:
+		for _, t := range msg {
+			if !m.group.IncludesTask(t.ID) {
+				return m, nil
+			}
+		}
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, localKeys.Enter):
+			if row, ok := m.table.CurrentRow(); ok {
+				return m, tui.NavigateTo(tui.TaskKind, tui.WithParent(row.ID))
+			}
+		}
+	}
+	// Handle keyboard and mouse events in the table widget
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+func (m groupReportModel) Title() tui.Breadcrumbs {
+	return m.Breadcrumbs("TaskGroupReport", m.group)
+}
+
+func (m groupReportModel) Status() string {
+	return m.renderGroupReport(newGroupReport(m.group.Tasks))
+}
+
+// ExportCSV writes the table in CSV format.
+func (m groupReportModel) ExportCSV(w io.Writer) error {
+	return m.table.ExportCSV(w)
+}
+
+// FilterValue returns the value of the table's filter.
+func (m groupReportModel) FilterValue() string {
+	return m.table.FilterValue()
+}
+
+// Loading returns true if the table is yet to load its items.
+func (m groupReportModel) Loading() bool {
+	return m.table.Loading()
+}
+
+// Pagination describes the page of the table currently visible.
+func (m groupReportModel) Pagination() string {
+	return m.table.Pagination()
+}
+
+func (m groupReportModel) View() string {
+	return m.table.View()
+}
+
+func (m groupReportModel) HelpBindings() []key.Binding {
+	return []key.Binding{localKeys.Enter}
+}
+
+func (m groupReportModel) renderGroupReport(report groupReport) string {
+	style := lipgloss.NewStyle().Background(tui.TaskSummaryBackgroundColor)
+	counts := fmt.Sprintf("%d changed, %d unchanged, %d failed, %d pending ",
+		report.changed,
+		report.unchanged,
+		report.failed,
+		report.pending,
+	)
+	if mode, ok := plan.BatchModeOf(m.group.Tasks); ok {
+		counts = fmt.Sprintf("%s: %s", mode, counts)
+	}
+	return tui.Padded.Background(tui.TaskSummaryBackgroundColor).Render(
+		style.Render(counts) + m.ResourceReport(report.total, style),
+	)
+}
+
+// groupReport summarises the outcome of the tasks in a task group.
+type groupReport struct {
+	// total is the sum of the resource changes proposed by plans.
+	total plan.Report
+	// changed is the number of tasks with resource changes.
+	changed int
+	// unchanged is the number of tasks that succeeded without resource
+	// changes.
+	unchanged int
+	// failed is the number of tasks that errored or were canceled.
+	failed int
+	// pending is the number of tasks yet to finish.
+	pending int
+}
+
+func newGroupReport(tasks []*task.Task) groupReport {
+	var report groupReport
+	for _, t := range tasks {
+		switch t.State {
+		case task.Errored, task.Canceled, task.Discarded:
+			report.failed++
+			continue
+		case task.Exited:
+		default:
+			report.pending++
+			continue
+		}
+		summary, ok := t.Summary.(plan.Report)
+		if !ok || !summary.HasChanges() {
+			report.unchanged++
+			continue
+		}
+		report.changed++
+		report.total.Additions += summary.Additions
+		report.total.Changes += summary.Changes
+		report.total.Destructions += summary.Destructions
+	}
+	return report
+}
+
+// byChanges sorts tasks with the most resource changes first, and then by
+// state.
+func byChanges(i, j *task.Task) int {
+	if a, b := totalChanges(i), totalChanges(j); a != b {
+		return b - a
+	}
+	return task.ByState(i, j)
+}
+
+// byReport returns a func that sorts tasks with the most of a particular kind
+// of resource change first.
+func byReport(count func(plan.Report) int) table.SortFunc[*task.Task] {
+	return func(i, j *task.Task) int {
+		a, _ := i.Summary.(plan.Report)
+		b, _ := j.Summary.(plan.Report)
+		return count(b) - count(a)
+	}
+}
+
+func totalChanges(t *task.Task) int {
+	report, ok := t.Summary.(plan.Report)
+	if !ok {
+		return 0
+	}
+	return report.Additions + report.Changes + report.Destructions
+}
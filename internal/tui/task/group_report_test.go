@@ -0,0 +1,50 @@
+package task
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/leg100/pug/internal/plan"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/task"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupReport(t *testing.T) {
+	newTask := func(state task.Status, summary task.Summary) *task.Task {
+		return &task.Task{
+			ID:      resource.NewID(resource.Task),
+			State:   state,
+			Summary: summary,
+		}
+	}
+	changed1 := newTask(task.Exited, plan.Report{Additions: 1, Changes: 3})
+	changed2 := newTask(task.Exited, plan.Report{Destructions: 3})
+	unchanged := newTask(task.Exited, plan.Report{})
+	errored := newTask(task.Errored, nil)
+	canceled := newTask(task.Canceled, nil)
+	running := newTask(task.Running, nil)
+
+	tasks := []*task.Task{unchanged, changed1, errored, running, changed2, canceled}
+
+	t.Run("summary", func(t *testing.T) {
+		got := newGroupReport(tasks)
+
+		assert.Equal(t, groupReport{
+			total:     plan.Report{Additions: 1, Changes: 3, Destructions: 3},
+			changed:   2,
+			unchanged: 1,
+			failed:    2,
+			pending:   1,
+		}, got)
+	})
+
+	t.Run("sort by changes", func(t *testing.T) {
+		sorted := slices.Clone(tasks)
+		slices.SortStableFunc(sorted, byChanges)
+
+		// Tasks with the most changes are listed first.
+		assert.Equal(t, changed1, sorted[0])
+		assert.Equal(t, changed2, sorted[1])
+	})
+}
@@ -1,9 +1,19 @@
 package task
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"slices"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/leg100/pug/internal/tui/keys"
+)
 
 type keyMap struct {
 	ToggleInfo key.Binding
+	Changes    key.Binding
+	Discard    key.Binding
+	Error      key.Binding
+	Keep       key.Binding
+	ApplyAll   key.Binding
 	Enter      key.Binding
 }
 
@@ -12,12 +22,79 @@ var localKeys = keyMap{
 		key.WithKeys("I"),
 		key.WithHelp("I", "toggle info"),
 	),
+	Changes: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "resource changes"),
+	),
+	Discard: key.NewBinding(
+		key.WithKeys("X"),
+		key.WithHelp("X", "discard queued"),
+	),
+	Error: key.NewBinding(
+		key.WithKeys("!"),
+		key.WithHelp("!", "error detail"),
+	),
+	Keep: key.NewBinding(
+		key.WithKeys("K"),
+		key.WithHelp("K", "keep preview"),
+	),
+	ApplyAll: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "apply all plans"),
+	),
 	Enter: key.NewBinding(
 		key.WithKeys("enter"),
 		key.WithHelp("enter", "view task"),
 	),
 }
 
+type listKeyMap struct {
+	LastHour      key.Binding
+	Today         key.Binding
+	LastWeek      key.Binding
+	AllTime       key.Binding
+	Group         key.Binding
+	CollapseGroup key.Binding
+}
+
+var listKeys = listKeyMap{
+	LastHour: key.NewBinding(
+		key.WithKeys("1"),
+		key.WithHelp("1", "last hour"),
+	),
+	Today: key.NewBinding(
+		key.WithKeys("2"),
+		key.WithHelp("2", "today"),
+	),
+	LastWeek: key.NewBinding(
+		key.WithKeys("3"),
+		key.WithHelp("3", "last 7 days"),
+	),
+	AllTime: key.NewBinding(
+		key.WithKeys("0"),
+		key.WithHelp("0", "all time"),
+	),
+	Group: key.NewBinding(
+		key.WithKeys("ctrl+g"),
+		key.WithHelp("ctrl+g", "group by"),
+	),
+	CollapseGroup: key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "collapse/expand group"),
+	),
+}
+
+type groupKeyMap struct {
+	Report key.Binding
+}
+
+var groupKeys = groupKeyMap{
+	Report: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "report"),
+	),
+}
+
 type groupListKeyMap struct {
 	Enter key.Binding
 }
@@ -28,3 +105,13 @@ var groupListKeys = groupListKeyMap{
 		key.WithHelp("enter", "view group"),
 	),
 }
+
+// mutatingKeys are disabled in read-only mode. The shell key is left out
+// because task pages don't open a shell, and bind its key to showing error
+// detail instead.
+var mutatingKeys = slices.Concat(
+	slices.DeleteFunc(slices.Clone(keys.Mutating), func(b key.Binding) bool {
+		return slices.Equal(b.Keys(), keys.Common.Shell.Keys())
+	}),
+	[]key.Binding{localKeys.ApplyAll},
+)
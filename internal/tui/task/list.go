@@ -1,11 +1,15 @@
 package task
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/leg100/pug/internal/plan"
 	"github.com/leg100/pug/internal/resource"
@@ -16,6 +20,10 @@ import (
 	"github.com/leg100/pug/internal/tui/table"
 )
 
+// ageFormat is the layout of the time a task was last updated, rendered when
+// relative times are toggled off, or when the task is older than a day.
+const ageFormat = "01-02 15:04"
+
 var (
 	taskIDColumn = table.Column{
 		Key:   "task_id",
@@ -30,12 +38,18 @@ var (
 	statusColumn = table.Column{
 		Key:   "task_status",
 		Title: "STATUS",
-		Width: task.MaxStatusLen,
+		Width: tui.TaskStatusWidth,
+	}
+	durationColumn = table.Column{
+		Key:        "duration",
+		Title:      "DURATION",
+		Width:      8,
+		RightAlign: true,
 	}
 	ageColumn = table.Column{
 		Key:   "age",
 		Title: "AGE",
-		Width: 7,
+		Width: len(ageFormat),
 	}
 )
 
@@ -75,34 +89,57 @@ func (mm *ListMaker) Make(_ resource.ID, width, height int) (tea.Model, error) {
 		commandColumn,
 		statusColumn,
 		table.SummaryColumn,
+		durationColumn,
 		ageColumn,
 	}
 
+	relativeTime := true
 	renderer := func(t *task.Task) table.RenderedRow {
+		age := t.Updated.Format(ageFormat)
+		if relativeTime {
+			age = tui.RelativeTime(time.Now(), t.Updated, ageFormat)
+		}
 		return table.RenderedRow{
 			taskIDColumn.Key:          t.ID.String(),
 			table.ModuleColumn.Key:    mm.Helpers.TaskModulePath(t),
 			table.WorkspaceColumn.Key: mm.Helpers.TaskWorkspaceName(t),
 			commandColumn.Key:         t.String(),
-			ageColumn.Key:             tui.Ago(time.Now(), t.Updated),
+			durationColumn.Key:        tui.HumanDuration(t.Duration(time.Now())),
+			ageColumn.Key:             age,
 			statusColumn.Key:          mm.Helpers.TaskStatus(t, false),
 			table.SummaryColumn.Key:   mm.Helpers.TaskSummary(t, true),
 		}
 	}
 
+	tableOptions := []table.Option[*task.Task]{
+		table.WithSortFunc(task.ByState),
+		table.WithColumnSortFunc(statusColumn.Key, task.ByState),
+		table.WithColumnSortFunc(durationColumn.Key, byDuration),
+		table.WithColumnSortFunc(ageColumn.Key, byAge),
+		table.WithColumnSortFunc(table.SummaryColumn.Key, bySummary),
+		table.WithColumnOrder[*task.Task](mm.Helpers.ColumnOrder...),
+		table.WithPinnedColumn[*task.Task](mm.Helpers.PinFirstColumn),
+		table.WithHeaderRule[*task.Task](mm.Helpers.HeaderRule),
+		table.WithPagination[*task.Task](mm.Helpers.Paginated("tasks")),
+		table.WithDefaultFilter[*task.Task](mm.Helpers.DefaultFilter("tasks")),
+		table.WithLoading[*task.Task](mm.Helpers.Spinner),
+		table.WithCopyFunc(func(t *task.Task) string { return t.ID.String() }),
+		table.WithFollowNew[*task.Task](mm.Helpers.FollowNew),
+	}
 	splitModel := split.New(split.Options[*task.Task]{
 		Columns:      columns,
 		Renderer:     renderer,
-		TableOptions: []table.Option[*task.Task]{table.WithSortFunc(task.ByState)},
+		TableOptions: tableOptions,
 		Width:        width,
 		Height:       height,
 		Maker:        mm.TaskMaker,
 	})
 	m := List{
-		Model:   splitModel,
-		plans:   mm.Plans,
-		tasks:   mm.Tasks,
-		Helpers: mm.Helpers,
+		Model:        splitModel,
+		plans:        mm.Plans,
+		tasks:        mm.Tasks,
+		relativeTime: &relativeTime,
+		Helpers:      mm.Helpers,
 	}
 	return m, nil
 }
@@ -113,6 +150,18 @@ type List struct {
 
 	plans *plan.Service
 	tasks *task.Service
+
+	// grouping is what tasks are grouped by, or empty if they are not
+	// grouped.
+	grouping string
+
+	// timeRange describes the window of time within which tasks must have
+	// been created to be listed. Empty if there is no such window.
+	timeRange string
+
+	// relativeTime renders the ages of tasks relative to now rather than as
+	// absolute times.
+	relativeTime *bool
 }
 
 func (m List) Init() tea.Cmd {
@@ -124,27 +173,53 @@ func (m List) Init() tea.Cmd {
 
 func (m List) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case spinner.TickMsg:
+		// Keep the durations of unfinished tasks ticking over, along with
+		// the ages of all tasks if they are relative to now.
+		m.Table.Rerender(func(t *task.Task) bool { return *m.relativeTime || !t.State.IsFinal() })
 	case tea.KeyMsg:
+		if cmd := m.RefuseMutation(msg, mutatingKeys...); cmd != nil {
+			return m, cmd
+		}
 		switch {
 		case key.Matches(msg, keys.Common.Cancel):
 			taskIDs := m.Table.SelectedOrCurrentIDs()
-			return m, cancel(m.tasks, taskIDs...)
+			return m, cancel(m.tasks, m.Logger, taskIDs...)
+		case key.Matches(msg, localKeys.Discard):
+			taskIDs := m.Table.SelectedOrCurrentIDs()
+			return m, discard(m.tasks, m.Logger, taskIDs...)
 		case key.Matches(msg, localKeys.Enter):
 			if row, ok := m.Table.CurrentRow(); ok {
 				return m, tui.NavigateTo(tui.TaskKind, tui.WithParent(row.ID))
 			}
+		case key.Matches(msg, localKeys.Error):
+			if row, ok := m.Table.CurrentRow(); ok {
+				return m, navigateToError(row.Value)
+			}
 		case key.Matches(msg, keys.Common.Apply):
+			var destroy bool
 			specs, err := m.Table.Prune(func(t *task.Task) (task.Spec, error) {
 				// Task must be a plan in order to be applied
-				return m.plans.ApplyPlan(t.ID)
+				spec, err := m.plans.ApplyPlan(t.ID)
+				if err == nil && m.plans.IsDestroy(t.ID) {
+					destroy = true
+				}
+				return spec, err
 			})
 			if err != nil {
 				return m, tui.ReportError(fmt.Errorf("applying tasks: %w", err))
 			}
-			return m, tui.YesNoPrompt(
-				fmt.Sprintf("Apply %d plans?", len(specs)),
+			prompt := fmt.Sprintf("Apply %d plans?", len(specs))
+			if destroy {
+				prompt = fmt.Sprintf("Apply %d plans, destroying resources?", len(specs))
+			}
+			return m, m.ConfirmApply(
+				m.applyPrompt(prompt, specs),
+				destroy,
 				m.CreateTasksWithSpecs(specs...),
 			)
+		case key.Matches(msg, localKeys.ApplyAll):
+			return m, m.applyAll()
 		case key.Matches(msg, keys.Common.State):
 			if row, ok := m.Table.CurrentRow(); ok {
 				if ws := m.TaskWorkspaceOrCurrentWorkspace(row.Value); ws != nil {
@@ -163,6 +238,29 @@ func (m List) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				fmt.Sprintf("Retry %d tasks?", len(rows)),
 				m.CreateTasksWithSpecs(specs...),
 			)
+		case key.Matches(msg, listKeys.LastHour):
+			m.setTimeRange("last hour", task.TimeRange{Since: time.Now().Add(-time.Hour)})
+			return m, nil
+		case key.Matches(msg, listKeys.Today):
+			y, mo, d := time.Now().Date()
+			m.setTimeRange("today", task.TimeRange{Since: time.Date(y, mo, d, 0, 0, 0, 0, time.Local)})
+			return m, nil
+		case key.Matches(msg, listKeys.LastWeek):
+			m.setTimeRange("last 7 days", task.TimeRange{Since: time.Now().AddDate(0, 0, -7)})
+			return m, nil
+		case key.Matches(msg, listKeys.AllTime):
+			m.setTimeRange("", task.TimeRange{})
+			return m, nil
+		case key.Matches(msg, listKeys.Group):
+			m.cycleGrouping()
+			return m, nil
+		case key.Matches(msg, listKeys.CollapseGroup):
+			m.Table.ToggleGroup()
+			return m, nil
+		case key.Matches(msg, keys.Common.Time):
+			*m.relativeTime = !*m.relativeTime
+			m.Table.RerenderAll()
+			return m, nil
 		}
 	}
 
@@ -171,16 +269,176 @@ func (m List) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-func (m List) Title() string {
-	return m.Breadcrumbs("Tasks", nil)
+// applyAll applies the plans of the selected tasks, or of the current task if
+// none are selected, following a single confirmation naming the workspaces to
+// which they are to be applied. Unlike applying with keys.Common.Apply, tasks
+// that cannot be applied, e.g. tasks that are not plans or plans yet to
+// finish, are skipped rather than de-selected.
+func (m List) applyAll() tea.Cmd {
+	var (
+		specs      []task.Spec
+		workspaces []string
+		skipped    int
+		destroy    bool
+	)
+	for _, row := range m.Table.SelectedOrCurrent() {
+		spec, err := m.plans.ApplyPlan(row.ID)
+		if err != nil {
+			skipped++
+			continue
+		}
+		specs = append(specs, spec)
+		if m.plans.IsDestroy(row.ID) {
+			destroy = true
+		}
+		if ws := m.TaskWorkspace(row.Value); ws != nil {
+			workspaces = append(workspaces, ws.ModulePath+":"+ws.Name)
+		}
+	}
+	if len(specs) == 0 {
+		return tui.ReportError(errors.New("no tasks are plans ready to be applied"))
+	}
+	prompt := m.applyPrompt(applyAllPrompt(len(specs), workspaces, skipped, destroy), specs)
+	cmd := m.ConfirmApply(prompt, destroy, m.CreateTasksWithSpecs(specs...))
+	if m.SkipApplyConfirm && skipped > 0 {
+		return tea.Batch(cmd, tui.ReportInfo("skipped %d tasks that cannot be applied", skipped))
+	}
+	return cmd
+}
+
+// applyPrompt prefixes the prompt to confirm applying plans with the variable
+// inputs of the plan if there is only one plan to apply.
+func (m List) applyPrompt(prompt string, specs []task.Spec) string {
+	rows := m.Table.SelectedOrCurrent()
+	if len(specs) != 1 || len(rows) != 1 {
+		return prompt
+	}
+	vars, err := m.plans.Variables(rows[0].ID)
+	if err != nil {
+		return prompt
+	}
+	return tui.ApplyPrompt(prompt, vars)
+}
+
+// maxApplyAllWorkspaces is the maximum number of workspaces named in the
+// prompt to apply all plans.
+const maxApplyAllWorkspaces = 3
+
+// applyAllPrompt prompts the user to confirm applying plans to the workspaces,
+// noting the number of tasks skipped and whether resources are to be destroyed.
+func applyAllPrompt(plans int, workspaces []string, skipped int, destroy bool) string {
+	names := workspaces
+	if len(names) > maxApplyAllWorkspaces {
+		names = append(slices.Clone(names[:maxApplyAllWorkspaces]), fmt.Sprintf("%d more", len(workspaces)-maxApplyAllWorkspaces))
+	}
+	prompt := fmt.Sprintf("Apply %d plans to %s?", plans, strings.Join(names, ", "))
+	if destroy {
+		prompt = fmt.Sprintf("Apply %d plans to %s, destroying resources?", plans, strings.Join(names, ", "))
+	}
+	if skipped > 0 {
+		prompt += fmt.Sprintf(" (skipping %d tasks that cannot be applied)", skipped)
+	}
+	return prompt
+}
+
+// setTimeRange only lists those tasks created within the given window of time.
+func (m *List) setTimeRange(label string, rng task.TimeRange) {
+	m.timeRange = label
+	if rng.IsZero() {
+		m.Table.SetPredicate(nil)
+		return
+	}
+	m.Table.SetPredicate(func(t *task.Task) bool {
+		return rng.Contains(t.Created)
+	})
+}
+
+// groupings are what tasks can be grouped by, in the order in which they are
+// cycled through. The first, empty, grouping lists tasks without grouping
+// them.
+var groupings = []string{"", "module", "workspace", "status"}
+
+// cycleGrouping groups tasks by the next of the groupings.
+func (m *List) cycleGrouping() {
+	i := slices.Index(groupings, m.grouping)
+	m.grouping = groupings[(i+1)%len(groupings)]
+	m.Table.SetGroupFunc(m.groupFunc())
+}
+
+// groupFunc returns the func that groups tasks according to the current
+// grouping, or nil if tasks are not grouped.
+func (m List) groupFunc() table.GroupFunc[*task.Task] {
+	orNone := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	switch m.grouping {
+	case "module":
+		return func(t *task.Task) string {
+			return orNone(m.TaskModulePath(t))
+		}
+	case "workspace":
+		return func(t *task.Task) string {
+			if name := m.TaskWorkspaceName(t); name != "" {
+				return fmt.Sprintf("%s (%s)", name, orNone(m.TaskModulePath(t)))
+			}
+			return "-"
+		}
+	case "status":
+		return func(t *task.Task) string {
+			return string(t.State)
+		}
+	default:
+		return nil
+	}
+}
+
+func (m List) Title() tui.Breadcrumbs {
+	var crumbs []string
+	if m.timeRange != "" {
+		crumbs = append(crumbs, tui.TitleTimeRange.Render(m.timeRange))
+	}
+	if m.grouping != "" {
+		crumbs = append(crumbs, tui.TitleTimeRange.Render("by "+m.grouping))
+	}
+	return m.Breadcrumbs("Tasks", nil, crumbs...)
 }
 
 func (m List) HelpBindings() []key.Binding {
 	bindings := []key.Binding{
 		keys.Common.Cancel,
+		localKeys.Discard,
 		keys.Common.Apply,
+		localKeys.ApplyAll,
 		keys.Common.State,
 		keys.Common.Retry,
 	}
+	bindings = m.HideMutations(bindings, mutatingKeys...)
+	bindings = append(bindings, localKeys.Error, keys.Common.Time)
+	bindings = append(bindings, keys.KeyMapToSlice(listKeys)...)
 	return append(bindings, keys.KeyMapToSlice(split.Keys)...)
 }
+
+// byAge sorts tasks with the most recently updated first.
+func byAge(i, j *task.Task) int {
+	return j.Updated.Compare(i.Updated)
+}
+
+// bySummary sorts tasks with the most resource changes first, comparing their
+// counts of changes numerically rather than as rendered. Ties are broken by
+// the number of additions, then changes, and then destructions. Tasks without
+// a report of changes count as having none.
+var bySummary = table.SortFuncs(
+	func(i, j *task.Task) int { return totalChanges(j) - totalChanges(i) },
+	byReport(func(r plan.Report) int { return r.Additions }),
+	byReport(func(r plan.Report) int { return r.Changes }),
+	byReport(func(r plan.Report) int { return r.Destructions }),
+)
+
+// byDuration sorts tasks with the longest running first.
+func byDuration(i, j *task.Task) int {
+	now := time.Now()
+	return cmp.Compare(j.Duration(now), i.Duration(now))
+}
@@ -0,0 +1,66 @@
+package task
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/leg100/pug/internal/plan"
+	"github.com/leg100/pug/internal/task"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBySummary(t *testing.T) {
+	withReport := func(additions, changes, destructions int) *task.Task {
+		return &task.Task{Summary: plan.Report{
+			Additions:    additions,
+			Changes:      changes,
+			Destructions: destructions,
+		}}
+	}
+	var (
+		two      = withReport(2, 0, 0)
+		ten      = withReport(10, 0, 0)
+		twelve   = withReport(1, 1, 10)
+		nine     = withReport(0, 9, 0)
+		noReport = &task.Task{}
+	)
+	tasks := []*task.Task{two, noReport, nine, ten, twelve}
+	slices.SortFunc(tasks, bySummary)
+
+	assert.Equal(t, []*task.Task{twelve, ten, nine, two, noReport}, tasks)
+}
+
+func TestBySummary_Ties(t *testing.T) {
+	var (
+		additions    = &task.Task{Summary: plan.Report{Additions: 10}}
+		changes      = &task.Task{Summary: plan.Report{Changes: 10}}
+		destructions = &task.Task{Summary: plan.Report{Destructions: 10}}
+	)
+	tasks := []*task.Task{destructions, changes, additions}
+	slices.SortFunc(tasks, bySummary)
+
+	assert.Equal(t, []*task.Task{additions, changes, destructions}, tasks)
+}
+
+func TestApplyAllPrompt(t *testing.T) {
+	tests := []struct {
+		name       string
+		plans      int
+		workspaces []string
+		skipped    int
+		destroy    bool
+		want       string
+	}{
+		{"one", 1, []string{"modules/a:dev"}, 0, false, "Apply 1 plans to modules/a:dev?"},
+		{"several", 2, []string{"modules/a:dev", "modules/b:dev"}, 0, false, "Apply 2 plans to modules/a:dev, modules/b:dev?"},
+		{"skipped", 1, []string{"modules/a:dev"}, 2, false, "Apply 1 plans to modules/a:dev? (skipping 2 tasks that cannot be applied)"},
+		{"too many to name", 5, []string{"a:dev", "b:dev", "c:dev", "d:dev", "e:dev"}, 0, false, "Apply 5 plans to a:dev, b:dev, c:dev, 2 more?"},
+		{"destroy", 1, []string{"modules/a:dev"}, 0, true, "Apply 1 plans to modules/a:dev, destroying resources?"},
+		{"workspace not found", 2, []string{"modules/a:dev"}, 0, false, "Apply 2 plans to modules/a:dev?"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, applyAllPrompt(tt.plans, tt.workspaces, tt.skipped, tt.destroy))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -27,6 +28,8 @@ type Maker struct {
 	Helpers *tui.Helpers
 	Logger  *logging.Logger
 	Program string
+	// Minimap shows a gutter marking errors and warnings in task output.
+	Minimap bool
 
 	disableAutoscroll bool
 	showInfo          bool
@@ -56,6 +59,7 @@ func (mm *Maker) make(id resource.ID, width, height int, border bool) (tea.Model
 		border:   border,
 		width:    width,
 		program:  mm.Program,
+		minimap:  mm.Minimap,
 	}
 	m.setHeight(height)
 
@@ -65,6 +69,7 @@ func (mm *Maker) make(id resource.ID, width, height int, border bool) (tea.Model
 		Width:      m.viewportWidth(),
 		Height:     m.height,
 		Spinner:    m.spinner,
+		Minimap:    mm.Minimap,
 	})
 
 	return m, nil
@@ -108,6 +113,7 @@ type model struct {
 	showInfo bool
 	border   bool
 	program  string
+	minimap  bool
 
 	viewport tui.Viewport
 	spinner  *spinner.Model
@@ -128,16 +134,34 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if cmd := m.RefuseMutation(msg, mutatingKeys...); cmd != nil {
+			return m, cmd
+		}
 		switch {
 		case key.Matches(msg, keys.Common.Cancel):
-			return m, cancel(m.tasks, m.task.ID)
+			return m, cancel(m.tasks, m.Logger, m.task.ID)
+		case key.Matches(msg, localKeys.Discard):
+			return m, discard(m.tasks, m.Logger, m.task.ID)
 		case key.Matches(msg, keys.Common.Apply):
 			spec, err := m.plans.ApplyPlan(m.task.ID)
-			if err != nil {
+			if errors.Is(err, plan.ErrStalePlan) {
+				// Offer to re-plan instead.
+				return m, tui.YesNoPrompt(
+					"Plan is stale: module files have changed. Re-plan?",
+					m.CreateTasks(m.plans.Replan, m.task.ID),
+				)
+			} else if err != nil {
 				return m, tui.ReportError(err)
 			}
-			return m, tui.YesNoPrompt(
-				"Apply plan?",
+			vars, _ := m.plans.Variables(m.task.ID)
+			destroy := m.plans.IsDestroy(m.task.ID)
+			prompt := "Apply plan?"
+			if destroy {
+				prompt = "Apply plan, destroying resources?"
+			}
+			return m, m.ConfirmApply(
+				tui.ApplyPrompt(prompt, vars),
+				destroy,
 				m.CreateTasksWithSpecs(spec),
 			)
 		case key.Matches(msg, keys.Common.State):
@@ -151,9 +175,17 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				"Retry task?",
 				m.CreateTasksWithSpecs(m.task.Spec),
 			)
+		case key.Matches(msg, localKeys.Error):
+			return m, navigateToError(m.task)
+		case key.Matches(msg, localKeys.Keep):
+			return m, keepPreview(m.plans, m.task.ID)
+		case key.Matches(msg, localKeys.Changes):
+			if m.task.Identifier == plan.PlanTask {
+				return m, tui.NavigateTo(tui.PlanChangesKind, tui.WithParent(m.task.ID))
+			}
 		}
 	case toggleAutoscrollMsg:
-		m.viewport.Autoscroll = !m.viewport.Autoscroll
+		m.viewport.SetAutoscroll(!m.viewport.Autoscroll)
 	case toggleTaskInfoMsg:
 		m.showInfo = !m.showInfo
 		// adjust width of viewport to accomodate info
@@ -225,10 +257,10 @@ func (m model) View() string {
 			envs = "-"
 		)
 		if len(m.task.Args) > 0 {
-			args = strings.Join(m.task.Args, "\n")
+			args = strings.Join(m.task.RedactedArgs(), "\n")
 		}
-		if len(m.task.AdditionalEnv) > 0 {
-			envs = strings.Join(m.task.AdditionalEnv, "\n")
+		if env := slices.Concat(m.task.AdditionalEnv, redactEnv(m.task.OverrideEnv)); len(env) > 0 {
+			envs = strings.Join(env, "\n")
 		}
 
 		// Show info to the left of the viewport.
@@ -287,10 +319,41 @@ func boolToOnOff(b bool) string {
 	return "off"
 }
 
-func (m model) Title() string {
+func (m model) Title() tui.Breadcrumbs {
+	if m.isPreview() {
+		return m.Breadcrumbs("Preview", m.task)
+	}
 	return m.Breadcrumbs("Task", m.task)
 }
 
+// Leave discards the task's plan if it is a preview, or once it finishes if it
+// has yet to finish, unless navigating to a page belonging to the task, e.g.
+// its resource changes.
+func (m model) Leave(to tui.Page) tea.Cmd {
+	if !m.isPreview() || to.ID == m.task.ID {
+		return nil
+	}
+	return discardPreview(m.plans, m.task.ID)
+}
+
+// Visit ensures the task's plan is no longer discarded once it finishes if it
+// is a preview that the user left and has now returned to.
+func (m model) Visit() tea.Cmd {
+	if !m.isPreview() {
+		return nil
+	}
+	return func() tea.Msg {
+		m.plans.VisitPreview(m.task.ID)
+		return nil
+	}
+}
+
+// isPreview returns true if the task created a preview plan that is yet to be
+// kept.
+func (m model) isPreview() bool {
+	return m.task.Identifier == plan.PlanTask && m.plans.IsPreview(m.task.ID)
+}
+
 func (m model) Status() string {
 	return lipgloss.JoinHorizontal(lipgloss.Top,
 		m.TaskSummary(m.task, false),
@@ -301,6 +364,7 @@ func (m model) Status() string {
 func (m model) HelpBindings() []key.Binding {
 	bindings := []key.Binding{
 		keys.Common.Cancel,
+		localKeys.Discard,
 		keys.Common.State,
 		keys.Common.Retry,
 		localKeys.ToggleInfo,
@@ -314,6 +378,19 @@ func (m model) HelpBindings() []key.Binding {
 	if m.task.Identifier == plan.ApplyTask {
 		bindings = append(bindings, keys.Common.Apply)
 	}
+	if m.task.Identifier == plan.PlanTask {
+		bindings = append(bindings, localKeys.Changes)
+	}
+	if m.isPreview() {
+		bindings = append(bindings, localKeys.Keep)
+	}
+	if m.task.State == task.Errored {
+		bindings = append(bindings, localKeys.Error)
+	}
+	bindings = m.HideMutations(bindings, mutatingKeys...)
+	if m.minimap {
+		bindings = append(bindings, keys.KeyMapToSlice(keys.Minimap)...)
+	}
 	return bindings
 }
 
@@ -334,3 +411,14 @@ type outputMsg struct {
 	output  []byte
 	eof     bool
 }
+
+// redactEnv replaces the values of environment variables, which may be
+// sensitive, with asterisks.
+func redactEnv(env []string) []string {
+	redacted := make([]string, len(env))
+	for i, kv := range env {
+		name, _, _ := strings.Cut(kv, "=")
+		redacted[i] = name + "=***"
+	}
+	return redacted
+}
@@ -0,0 +1,36 @@
+package task
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/plan"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/tui"
+)
+
+// keepPreview keeps the preview plan created by the task, so that it is no
+// longer discarded upon navigating away from it.
+func keepPreview(plans *plan.Service, taskID resource.ID) tea.Cmd {
+	return func() tea.Msg {
+		if err := plans.Keep(taskID); err != nil {
+			return tui.ErrorMsg(fmt.Errorf("keeping preview: %w", err))
+		}
+		return tui.InfoMsg("kept preview plan")
+	}
+}
+
+// discardPreview discards the preview plan created by the task, unless it has
+// yet to finish, in which case it is discarded once it finishes.
+func discardPreview(plans *plan.Service, taskID resource.ID) tea.Cmd {
+	return func() tea.Msg {
+		discarded, err := plans.DiscardPreview(taskID)
+		if err != nil {
+			return tui.ErrorMsg(fmt.Errorf("discarding preview: %w", err))
+		}
+		if !discarded {
+			return tui.InfoMsg("preview plan has yet to finish: discarding once finished")
+		}
+		return tui.InfoMsg("discarded preview plan")
+	}
+}
@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+)
+
+// Theme maps the names of colors to their values. Each value is either a hex
+// code, e.g. "#ff0000", or an ANSI color number, e.g. "214".
+type Theme map[string]string
+
+// Themes are the built-in themes, selectable by name.
+var Themes = map[string]Theme{
+	"dark": {
+		"debug_log_level":                 "63",
+		"info_log_level":                  "86",
+		"error_log_level":                 "#FF5353",
+		"warn_log_level":                  "#DBBD70",
+		"log_record_attribute_key":        "245",
+		"help_key":                        "#ffffff",
+		"help_desc":                       "248",
+		"inactive_preview_border":         "244",
+		"current_background":              "#737373",
+		"current_foreground":              "#ffffff",
+		"selected_background":             "110",
+		"selected_foreground":             "#000000",
+		"current_and_selected_background": "117",
+		"current_and_selected_foreground": "#000000",
+		"group_report_background":         "236",
+		"task_summary_background":         "236",
+		"task_pending":                    "#737373",
+		"task_queued":                     "214",
+		"task_running":                    "#DBBD70",
+		"task_succeeded":                  "34",
+		"task_no_changes":                 "245",
+		"task_errored":                    "#FF5353",
+		"task_canceled":                   "135",
+		"task_discarded":                  "250",
+	},
+	"light": {
+		"debug_log_level":                 "63",
+		"info_log_level":                  "34",
+		"error_log_level":                 "160",
+		"warn_log_level":                  "130",
+		"log_record_attribute_key":        "240",
+		"help_key":                        "#000000",
+		"help_desc":                       "240",
+		"inactive_preview_border":         "250",
+		"current_background":              "250",
+		"current_foreground":              "#000000",
+		"selected_background":             "153",
+		"selected_foreground":             "#000000",
+		"current_and_selected_background": "117",
+		"current_and_selected_foreground": "#000000",
+		"group_report_background":         "253",
+		"task_summary_background":         "253",
+		"task_pending":                    "244",
+		"task_queued":                     "166",
+		"task_running":                    "136",
+		"task_succeeded":                  "28",
+		"task_no_changes":                 "246",
+		"task_errored":                    "160",
+		"task_canceled":                   "91",
+		"task_discarded":                  "250",
+	},
+}
+
+// themeColors maps the name of each color that can be overridden by a theme to
+// the variable holding its value.
+func themeColors() map[string]*lipgloss.TerminalColor {
+	return map[string]*lipgloss.TerminalColor{
+		"debug_log_level":                 &DebugLogLevel,
+		"info_log_level":                  &InfoLogLevel,
+		"error_log_level":                 &ErrorLogLevel,
+		"warn_log_level":                  &WarnLogLevel,
+		"log_record_attribute_key":        &LogRecordAttributeKey,
+		"help_key":                        &HelpKey,
+		"help_desc":                       &HelpDesc,
+		"inactive_preview_border":         &InactivePreviewBorder,
+		"current_background":              &CurrentBackground,
+		"current_foreground":              &CurrentForeground,
+		"selected_background":             &SelectedBackground,
+		"selected_foreground":             &SelectedForeground,
+		"current_and_selected_background": &CurrentAndSelectedBackground,
+		"current_and_selected_foreground": &CurrentAndSelectedForeground,
+		"group_report_background":         &GroupReportBackgroundColor,
+		"task_summary_background":         &TaskSummaryBackgroundColor,
+		"task_pending":                    &TaskPendingColor,
+		"task_queued":                     &TaskQueuedColor,
+		"task_running":                    &TaskRunningColor,
+		"task_succeeded":                  &TaskSucceededColor,
+		"task_no_changes":                 &TaskNoChangesColor,
+		"task_errored":                    &TaskErroredColor,
+		"task_canceled":                   &TaskCanceledColor,
+		"task_discarded":                  &TaskDiscardedColor,
+	}
+}
+
+// ThemeNames returns the names of the built-in themes.
+func ThemeNames() []string {
+	names := maps.Keys(Themes)
+	slices.Sort(names)
+	return names
+}
+
+// LoadTheme applies the built-in theme with the given name, followed by any
+// colors in the YAML file at the given path. If neither is given then the
+// default colors, which adapt to the terminal background, are retained.
+func LoadTheme(name, path string) error {
+	if name != "" {
+		theme, ok := Themes[name]
+		if !ok {
+			return fmt.Errorf("unknown theme: %s: must be one of: %v", name, ThemeNames())
+		}
+		if err := ApplyTheme(theme); err != nil {
+			return err
+		}
+	}
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading theme file: %w", err)
+		}
+		var theme Theme
+		if err := yaml.Unmarshal(b, &theme); err != nil {
+			return fmt.Errorf("parsing theme file: %w", err)
+		}
+		if err := ApplyTheme(theme); err != nil {
+			return fmt.Errorf("applying theme file: %w", err)
+		}
+	}
+	return nil
+}
+
+// ApplyTheme overrides colors with those in the theme. An error is returned if
+// the theme contains an unknown color name.
+func ApplyTheme(theme Theme) error {
+	colors := themeColors()
+	for name := range theme {
+		if _, ok := colors[name]; !ok {
+			return fmt.Errorf("unknown color: %s", name)
+		}
+	}
+	for name, value := range theme {
+		*colors[name] = lipgloss.Color(value)
+	}
+	return nil
+}
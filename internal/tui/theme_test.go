@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// restoreColors restores the default colors once the test finishes.
+func restoreColors(t *testing.T) {
+	defaults := make(map[string]lipgloss.TerminalColor)
+	for name, color := range themeColors() {
+		defaults[name] = *color
+	}
+	t.Cleanup(func() {
+		for name, color := range themeColors() {
+			*color = defaults[name]
+		}
+	})
+}
+
+func TestThemes_Complete(t *testing.T) {
+	// Each built-in theme should set every color
+	for name, theme := range Themes {
+		for color := range themeColors() {
+			assert.Contains(t, theme, color, "theme %s", name)
+		}
+	}
+}
+
+func TestLoadTheme(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		restoreColors(t)
+		want := CurrentBackground
+
+		require.NoError(t, LoadTheme("", ""))
+		assert.Equal(t, want, CurrentBackground)
+	})
+
+	t.Run("built-in theme", func(t *testing.T) {
+		restoreColors(t)
+
+		require.NoError(t, LoadTheme("light", ""))
+		assert.Equal(t, lipgloss.Color("250"), CurrentBackground)
+	})
+
+	t.Run("theme file overrides built-in theme", func(t *testing.T) {
+		restoreColors(t)
+		path := filepath.Join(t.TempDir(), "theme.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("current_background: \"21\"\n"), 0o644))
+
+		require.NoError(t, LoadTheme("light", path))
+		assert.Equal(t, lipgloss.Color("21"), CurrentBackground)
+		assert.Equal(t, lipgloss.Color("153"), SelectedBackground)
+	})
+
+	t.Run("unknown theme", func(t *testing.T) {
+		restoreColors(t)
+
+		assert.Error(t, LoadTheme("solarized", ""))
+	})
+
+	t.Run("unknown color", func(t *testing.T) {
+		restoreColors(t)
+		want := CurrentBackground
+
+		assert.Error(t, ApplyTheme(Theme{"current_background": "21", "pink": "201"}))
+		assert.Equal(t, want, CurrentBackground, "no colors applied")
+	})
+}
@@ -13,3 +13,42 @@ func TestAgo(t *testing.T) {
 	assert.Equal(t, "50s ago", Ago(now, now.Add(-47*time.Second)))
 	assert.Equal(t, "47h ago", Ago(now, now.Add(-47*time.Hour)))
 }
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		t    time.Time
+		want string
+	}{
+		{"just now", now.Add(-500 * time.Millisecond), "just now"},
+		{"seconds", now.Add(-7 * time.Second), "7s ago"},
+		{"minutes", now.Add(-3 * time.Minute), "3m ago"},
+		{"hours", now.Add(-23 * time.Hour), "23h ago"},
+		{"beyond threshold", now.Add(-25 * time.Hour), "2024-04-30 11:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RelativeTime(now, tt.t, "2006-01-02 15:04"))
+		})
+	}
+}
+
+func TestHumanDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{1500 * time.Millisecond, "1s"},
+		{59 * time.Second, "59s"},
+		{3*time.Minute + 7*time.Second, "3m07s"},
+		{2*time.Hour + 5*time.Minute + 30*time.Second, "2h05m"},
+		{27 * time.Hour, "27h00m"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, HumanDuration(tt.d))
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package top
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/app"
+)
+
+// ExitError returns an error if the exit code option is enabled and any tasks
+// errored or were canceled during the session. The given model is the final
+// model returned by the program upon exit.
+func ExitError(final tea.Model) error {
+	m, ok := final.(model)
+	if !ok || !m.exitCode {
+		return nil
+	}
+	if failed := m.tasks.Failed(); failed > 0 {
+		return app.TasksFailedError{Failed: failed}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package top
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/tui"
+)
+
+// exporter is a model with a table that can be exported in CSV format.
+type exporter interface {
+	ExportCSV(w io.Writer) error
+}
+
+// exportCSV exports the table on the current page to a timestamped CSV file
+// in the given directory, reporting the path of the file.
+func exportCSV(model tea.Model, dir string, now time.Time) tea.Cmd {
+	exporter, ok := model.(exporter)
+	if !ok {
+		return tui.ReportError(errors.New("page cannot be exported"))
+	}
+	path := filepath.Join(dir, fmt.Sprintf("pug-export-%s.csv", now.Format("20060102-150405")))
+	f, err := os.Create(path)
+	if err != nil {
+		return tui.ReportError(fmt.Errorf("exporting table: %w", err))
+	}
+	err = exporter.ExportCSV(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return tui.ReportError(fmt.Errorf("exporting table: %w", err))
+	}
+	return tui.ReportInfo("exported table to %s", path)
+}
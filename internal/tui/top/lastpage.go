@@ -0,0 +1,148 @@
+package top
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/tui"
+	"github.com/leg100/pug/internal/workspace"
+)
+
+// lastPageFile is the file in the data directory to which the page last
+// visited in each working directory is persisted.
+const lastPageFile = "last-page.json"
+
+// restorableKinds are the kinds of page that can be restored upon startup.
+var restorableKinds = []tui.Kind{
+	tui.ModuleListKind,
+	tui.WorkspaceListKind,
+	tui.TaskListKind,
+	tui.TaskGroupListKind,
+	tui.LogListKind,
+	tui.ResourceListKind,
+}
+
+// lastPage is the page last visited in a working directory. Resource IDs are
+// not stable across restarts, so a page's workspace is identified by its
+// module path and name instead.
+type lastPage struct {
+	Kind       string `json:"kind"`
+	ModulePath string `json:"module_path,omitempty"`
+	Workspace  string `json:"workspace,omitempty"`
+}
+
+func (lp lastPage) kind() (tui.Kind, bool) {
+	for _, kind := range restorableKinds {
+		if kind.String() == lp.Kind {
+			return kind, true
+		}
+	}
+	return 0, false
+}
+
+// readLastPages reads the last page visited in each working directory from the
+// file at path.
+func readLastPages(path string) (map[string]lastPage, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var pages map[string]lastPage
+	if err := json.Unmarshal(b, &pages); err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+// loadLastPage retrieves the page last visited in the working directory.
+// False is returned if there is no such page, or if the file is missing or
+// corrupt.
+func loadLastPage(path, workdir string) (lastPage, bool) {
+	pages, err := readLastPages(path)
+	if err != nil {
+		return lastPage{}, false
+	}
+	lp, ok := pages[workdir]
+	if !ok {
+		return lastPage{}, false
+	}
+	if _, ok := lp.kind(); !ok {
+		return lastPage{}, false
+	}
+	return lp, true
+}
+
+// writeLastPage persists the page last visited in the working directory,
+// retaining the pages last visited in other working directories.
+func writeLastPage(path, workdir string, lp lastPage) error {
+	pages, err := readLastPages(path)
+	if err != nil {
+		// Start afresh if the file is missing or corrupt.
+		pages = make(map[string]lastPage)
+	}
+	pages[workdir] = lp
+	b, err := json.Marshal(pages)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o644)
+}
+
+// SaveLastPage persists the most recently visited page that can be restored.
+// The given model is the final model returned by the program upon exit.
+func SaveLastPage(final tea.Model, path, workdir string) error {
+	m, ok := final.(model)
+	if !ok {
+		return errors.New("unexpected model")
+	}
+	lp, ok := m.lastPage()
+	if !ok {
+		return nil
+	}
+	return writeLastPage(path, workdir, lp)
+}
+
+// lastPage converts the most recently visited page that can be restored into a
+// lastPage.
+func (m model) lastPage() (lastPage, bool) {
+	for i := len(m.history) - 1; i >= 0; i-- {
+		page := m.history[i]
+		if !slices.Contains(restorableKinds, page.Kind) {
+			continue
+		}
+		lp := lastPage{Kind: page.Kind.String()}
+		if page.ID.Kind == resource.Workspace {
+			ws, err := m.workspaces.Get(page.ID)
+			if err != nil {
+				continue
+			}
+			lp.ModulePath = ws.ModulePath
+			lp.Workspace = ws.Name
+		}
+		return lp, true
+	}
+	return lastPage{}, false
+}
+
+// restoreWorkspacePage navigates to the page to be restored once its
+// workspace has been loaded.
+func (m *model) restoreWorkspacePage(event resource.Event[*workspace.Workspace]) tea.Cmd {
+	if m.restore == nil || event.Type != resource.CreatedEvent {
+		return nil
+	}
+	ws := event.Payload
+	if ws.ModulePath != m.restore.ModulePath || ws.Name != m.restore.Workspace {
+		return nil
+	}
+	kind, _ := m.restore.kind()
+	m.restore = nil
+	return tui.NavigateTo(kind, tui.WithParent(ws.GetID()))
+}
@@ -0,0 +1,58 @@
+package top
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leg100/pug/internal/tui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLastPage(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), lastPageFile)
+		want := lastPage{
+			Kind:       tui.ResourceListKind.String(),
+			ModulePath: "modules/a",
+			Workspace:  "dev",
+		}
+
+		require.NoError(t, writeLastPage(path, "/work/a", want))
+		require.NoError(t, writeLastPage(path, "/work/b", lastPage{Kind: tui.LogListKind.String()}))
+
+		got, ok := loadLastPage(path, "/work/a")
+		require.True(t, ok)
+		assert.Equal(t, want, got)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), lastPageFile)
+
+		_, ok := loadLastPage(path, "/work/a")
+		assert.False(t, ok)
+	})
+
+	t.Run("corrupt file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), lastPageFile)
+		require.NoError(t, os.WriteFile(path, []byte("not json"), 0o644))
+
+		_, ok := loadLastPage(path, "/work/a")
+		assert.False(t, ok)
+
+		// Overwrite corrupt file
+		require.NoError(t, writeLastPage(path, "/work/a", lastPage{Kind: tui.TaskListKind.String()}))
+		got, ok := loadLastPage(path, "/work/a")
+		require.True(t, ok)
+		assert.Equal(t, tui.TaskListKind.String(), got.Kind)
+	})
+
+	t.Run("unrestorable kind", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), lastPageFile)
+		require.NoError(t, writeLastPage(path, "/work/a", lastPage{Kind: tui.TaskKind.String()}))
+
+		_, ok := loadLastPage(path, "/work/a")
+		assert.False(t, ok)
+	})
+}
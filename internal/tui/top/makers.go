@@ -19,18 +19,29 @@ type updateableMaker interface {
 // makeMakers makes model makers for making models
 func makeMakers(cfg app.Config, app *app.App, spinner *spinner.Model) map[tui.Kind]tui.Maker {
 	helpers := &tui.Helpers{
-		Modules:    app.Modules,
-		Workspaces: app.Workspaces,
-		Plans:      app.Plans,
-		States:     app.States,
-		Tasks:      app.Tasks,
-		Logger:     app.Logger,
+		Modules:          app.Modules,
+		Workspaces:       app.Workspaces,
+		Plans:            app.Plans,
+		States:           app.States,
+		Tasks:            app.Tasks,
+		Logger:           app.Logger,
+		Workdir:          cfg.Workdir,
+		ColumnOrder:      cfg.ColumnOrder,
+		FollowNew:        cfg.FollowNew,
+		PinFirstColumn:   cfg.PinFirstColumn,
+		HeaderRule:       cfg.HeaderRule,
+		Paginate:         cfg.Paginate,
+		DefaultFilters:   cfg.DefaultFilters,
+		Spinner:          spinner,
+		ReadOnly:         cfg.ReadOnly,
+		SkipApplyConfirm: cfg.SkipApplyConfirm,
 	}
 
 	workspaceListMaker := &workspacetui.ListMaker{
 		Workspaces: app.Workspaces,
 		Modules:    app.Modules,
 		Plans:      app.Plans,
+		States:     app.States,
 		Helpers:    helpers,
 	}
 	taskMaker := &tasktui.Maker{
@@ -40,6 +51,7 @@ func makeMakers(cfg app.Config, app *app.App, spinner *spinner.Model) map[tui.Ki
 		Helpers: helpers,
 		Logger:  app.Logger,
 		Program: cfg.Program,
+		Minimap: cfg.Minimap,
 	}
 	taskListMaker := tasktui.NewListMaker(
 		app.Tasks,
@@ -50,13 +62,14 @@ func makeMakers(cfg app.Config, app *app.App, spinner *spinner.Model) map[tui.Ki
 
 	makers := map[tui.Kind]tui.Maker{
 		tui.ModuleListKind: &moduletui.ListMaker{
-			Modules:    app.Modules,
-			Workspaces: app.Workspaces,
-			Plans:      app.Plans,
-			Spinner:    spinner,
-			Workdir:    cfg.Workdir,
-			Helpers:    helpers,
-			Terragrunt: cfg.Terragrunt,
+			Modules:            app.Modules,
+			Workspaces:         app.Workspaces,
+			Plans:              app.Plans,
+			Spinner:            spinner,
+			Workdir:            cfg.Workdir,
+			Helpers:            helpers,
+			Terragrunt:         cfg.Terragrunt,
+			ModuleDependencies: cfg.DependenciesFile != "",
 		},
 		tui.WorkspaceListKind: workspaceListMaker,
 		tui.TaskListKind:      taskListMaker,
@@ -71,6 +84,19 @@ func makeMakers(cfg app.Config, app *app.App, spinner *spinner.Model) map[tui.Ki
 			taskMaker,
 			helpers,
 		),
+		tui.TaskGroupReportKind: &tasktui.GroupReportMaker{
+			Tasks:   app.Tasks,
+			Helpers: helpers,
+		},
+		tui.PlanChangesKind: &tasktui.ChangesMaker{
+			Plans:   app.Plans,
+			Tasks:   app.Tasks,
+			Helpers: helpers,
+		},
+		tui.TaskErrorKind: &tasktui.ErrorMaker{
+			Tasks:   app.Tasks,
+			Helpers: helpers,
+		},
 		tui.LogListKind: &logs.ListMaker{
 			Logger:  app.Logger,
 			Helpers: helpers,
@@ -91,6 +117,15 @@ func makeMakers(cfg app.Config, app *app.App, spinner *spinner.Model) map[tui.Ki
 			Plans:   app.Plans,
 			Helpers: helpers,
 		},
+		tui.OutputsKind: &workspacetui.OutputsMaker{
+			Tasks:   app.Tasks,
+			Helpers: helpers,
+		},
+		tui.StateDiffKind: &workspacetui.DiffMaker{
+			States:     app.States,
+			Workspaces: app.Workspaces,
+			Helpers:    helpers,
+		},
 	}
 	return makers
 }
@@ -3,10 +3,14 @@ package top
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/davecgh/go-spew/spew"
@@ -18,15 +22,20 @@ import (
 	"github.com/leg100/pug/internal/tui/keys"
 	tuimodule "github.com/leg100/pug/internal/tui/module"
 	"github.com/leg100/pug/internal/version"
+	"github.com/leg100/pug/internal/workspace"
 )
 
 // pug is in one of several modes, which alter how all messages are handled.
 type mode int
 
 const (
-	normalMode mode = iota // default
-	promptMode             // confirm prompt is visible and taking input
-	filterMode             // filter is visible and taking input
+	normalMode        mode = iota // default
+	promptMode                    // confirm prompt is visible and taking input
+	filterMode                    // filter is visible and taking input
+	helpMode                      // help filter is visible and taking input
+	notificationsMode             // notifications pane is visible and taking input
+	jumpMode                      // table is taking input to jump to a row
+	columnsMode                   // column picker is visible and taking input
 
 	// minimum height of view area.
 	minViewHeight = 10
@@ -35,20 +44,49 @@ const (
 type model struct {
 	*navigator
 
-	modules  *module.Service
-	width    int
-	height   int
-	mode     mode
-	showHelp bool
-	prompt   *tui.Prompt
-	dump     *os.File
-	workdir  string
-	err      error
-	info     string
-	tasks    *task.Service
-	spinner  *spinner.Model
-	spinning bool
-	maxTasks int
+	modules    *module.Service
+	workspaces *workspace.Service
+	width      int
+	height     int
+	mode       mode
+	showHelp   bool
+	prompt     *tui.Prompt
+	dump       *os.File
+	workdir    string
+	err        error
+	info       string
+	tasks      *task.Service
+	spinner    *spinner.Model
+	spinning   bool
+	exitCode   bool
+
+	// notifications retains recent errors and informational messages, which
+	// are listed in the notifications pane.
+	notifications     notifications
+	notificationsPane tui.Viewport
+
+	// batches are task groups whose progress is summarised in the footer
+	// until their tasks have finished.
+	batches batches
+
+	// resizeSeq identifies the last window size message received.
+	resizeSeq int
+
+	// lastQuit is the time at which the quit key was last pressed.
+	lastQuit time.Time
+
+	// exportDir is the directory to which tables are exported.
+	exportDir string
+
+	// restore is the last visited page to restore once its workspace has
+	// loaded, or nil if there is no such page.
+	restore *lastPage
+
+	// helpFilter filters the bindings listed in the help widget
+	helpFilter textinput.Model
+	// helpPane scrolls the bindings listed in the help widget. Its scroll
+	// position is retained when help is toggled.
+	helpPane viewport.Model
 }
 
 func newModel(cfg app.Config, app *app.App) (model, error) {
@@ -65,26 +103,67 @@ func newModel(cfg app.Config, app *app.App) (model, error) {
 	// https://github.com/charmbracelet/bubbletea/issues/1036#issuecomment-2158563056
 	_ = lipgloss.HasDarkBackground()
 
-	spinner := spinner.New(spinner.WithSpinner(spinner.Line))
+	spinner, err := tui.NewSpinner(cfg.Spinner, cfg.SpinnerInterval)
+	if err != nil {
+		if dump != nil {
+			_ = dump.Close()
+		}
+		return model{}, err
+	}
 	makers := makeMakers(cfg, app, &spinner)
 
 	m := model{
-		modules:  app.Modules,
-		spinner:  &spinner,
-		tasks:    app.Tasks,
-		maxTasks: cfg.MaxTasks,
-		dump:     dump,
-		workdir:  cfg.Workdir.PrettyString(),
+		modules:    app.Modules,
+		workspaces: app.Workspaces,
+		spinner:    &spinner,
+		tasks:      app.Tasks,
+		exitCode:   cfg.ExitCode,
+		dump:       dump,
+		workdir:    cfg.Workdir.PrettyString(),
+
+		exportDir: cfg.Workdir.String(),
 	}
 
-	var err error
-	m.navigator, err = newNavigator(cfg.FirstPage, makers)
+	m.helpFilter = textinput.New()
+	m.helpFilter.Prompt = "Filter: "
+
+	if err := tui.LoadTheme(cfg.Theme, cfg.ThemeFile); err != nil {
+		return model{}, err
+	}
+
+	firstKind, err := tui.FirstPageKind(cfg.FirstPage)
+	if err != nil {
+		return model{}, err
+	}
+	firstPage := tui.Page{Kind: firstKind}
+	if cfg.RestorePage {
+		// Restore the page last visited in the working directory, falling
+		// back to the first page if there is none. A page belonging to a
+		// workspace is restored once the workspace has loaded.
+		path := filepath.Join(cfg.DataDir, lastPageFile)
+		if lp, ok := loadLastPage(path, cfg.Workdir.String()); ok {
+			if lp.Workspace != "" {
+				m.restore = &lp
+			} else {
+				firstPage.Kind, _ = lp.kind()
+			}
+		}
+	}
+
+	m.navigator, err = newNavigator(firstPage, makers)
 	if err != nil {
 		return model{}, err
 	}
 	return m, nil
 }
 
+// close closes the file to which messages are dumped in debug mode.
+func (m model) close() {
+	if m.dump != nil {
+		_ = m.dump.Close()
+	}
+}
+
 func (m model) Init() tea.Cmd {
 	return tea.Batch(
 		m.currentModel().Init(),
@@ -116,11 +195,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// No tasks are running so stop spinner
 			m.spinning = false
 		}
+		cmds = append(cmds, m.reportFinishedBatches())
+	case resource.Event[*workspace.Workspace]:
+		cmds = append(cmds, m.restoreWorkspacePage(msg))
 	case spinner.TickMsg:
 		var cmd tea.Cmd
 		*m.spinner, cmd = m.spinner.Update(msg)
 		_ = m.updateCurrent(msg)
-		if m.spinning {
+		if m.spinning || m.loading() {
 			// Continue spinning spinner.
 			return m, cmd
 		}
@@ -144,8 +226,26 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.info = ""
 		m.err = nil
 
+		if m.showHelp && m.mode != promptMode {
+			switch {
+			case key.Matches(msg, keys.Help.ScrollUp):
+				m.setHelpContent()
+				m.helpPane.LineUp(1)
+				return m, nil
+			case key.Matches(msg, keys.Help.ScrollDown):
+				m.setHelpContent()
+				m.helpPane.LineDown(1)
+				return m, nil
+			}
+		}
+
 		switch m.mode {
 		case promptMode:
+			if key.Matches(msg, keys.Global.Quit) && m.forceQuit(time.Now()) {
+				// Pressing ctrl-c twice in quick succession quits the app
+				// without waiting for the user to answer the prompt.
+				return m, tea.Quit
+			}
 			closePrompt, cmd := m.prompt.HandleKey(msg)
 			if closePrompt {
 				// Send message to current model to resize itself to expand back
@@ -183,25 +283,117 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				cmd = m.updateCurrent(tui.FilterKeyMsg(msg))
 				return m, cmd
 			}
+		case jumpMode:
+			switch {
+			case key.Matches(msg, keys.Global.Quit):
+				// Allow user to quit app whilst jumping, letting the key
+				// handler below handle the quit action.
+				m.mode = normalMode
+				_ = m.updateCurrent(tui.JumpCloseMsg{})
+			case key.Matches(msg, keys.Jump.Exit):
+				m.mode = normalMode
+				_ = m.updateCurrent(tui.JumpCloseMsg{})
+				return m, nil
+			default:
+				// Wrap key message in a jump key message and send to current
+				// model.
+				cmd = m.updateCurrent(tui.JumpKeyMsg(msg))
+				return m, cmd
+			}
+		case columnsMode:
+			switch {
+			case key.Matches(msg, keys.Global.Quit):
+				// Allow user to quit app whilst picking columns, letting the
+				// key handler below handle the quit action.
+				m.mode = normalMode
+				_ = m.updateCurrent(tui.ColumnPickerCloseMsg{})
+			case key.Matches(msg, keys.ColumnPicker.Exit):
+				m.mode = normalMode
+				_ = m.updateCurrent(tui.ColumnPickerCloseMsg{})
+				return m, nil
+			default:
+				// Wrap key message in a column picker key message and send to
+				// current model.
+				cmd = m.updateCurrent(tui.ColumnPickerKeyMsg(msg))
+				return m, cmd
+			}
+		case notificationsMode:
+			switch {
+			case key.Matches(msg, keys.Global.Quit):
+				// Allow user to quit app whilst viewing notifications,
+				// letting the key handler below handle the quit action.
+				m.mode = normalMode
+			case key.Matches(msg, keys.Global.Notifications, keys.Global.Back):
+				// Close notifications pane
+				m.mode = normalMode
+				return m, nil
+			default:
+				// Scroll notifications pane
+				m.notificationsPane, cmd = m.notificationsPane.Update(msg)
+				return m, cmd
+			}
+		case helpMode:
+			switch {
+			case key.Matches(msg, keys.Global.Quit):
+				// Allow user to quit app whilst filtering help, letting the
+				// key handler below handle the quit action.
+				m.mode = normalMode
+				m.helpFilter.Blur()
+			case key.Matches(msg, keys.Filter.Blur):
+				// Stop filtering but retain the filter value.
+				m.mode = normalMode
+				m.helpFilter.Blur()
+				return m, nil
+			case key.Matches(msg, keys.Filter.Close):
+				m.mode = normalMode
+				m.helpFilter.Blur()
+				m.helpFilter.SetValue("")
+				m.helpPane.GotoTop()
+				return m, nil
+			default:
+				value := m.helpFilter.Value()
+				m.helpFilter, cmd = m.helpFilter.Update(msg)
+				if m.helpFilter.Value() != value {
+					// Scroll to the top of the newly filtered bindings.
+					m.helpPane.GotoTop()
+				}
+				return m, cmd
+			}
 		}
 
 		switch {
 		case key.Matches(msg, keys.Global.Quit):
 			// ctrl-c quits the app, but not before prompting the user for
-			// confirmation.
-			return m, tui.YesNoPrompt("Quit pug?", tea.Quit)
+			// confirmation if any tasks are still active.
+			return m, m.quit(m.tasks.Counter(), time.Now())
 		case key.Matches(msg, keys.Global.Suspend):
 			// ctrl-z suspends the app
 			return m, tea.Suspend
 		case key.Matches(msg, keys.Global.Back):
 			// <esc> goes back to last page
-			m.goBack()
+			cmds = append(cmds, m.goBack())
+		case key.Matches(msg, keys.Global.MaxTasksIncrease):
+			n := m.tasks.SetMaxTasks(m.tasks.MaxTasks() + 1)
+			return m, tui.ReportInfo("Maximum parallel tasks: %d", n)
+		case key.Matches(msg, keys.Global.MaxTasksDecrease):
+			n := m.tasks.SetMaxTasks(m.tasks.MaxTasks() - 1)
+			return m, tui.ReportInfo("Maximum parallel tasks: %d", n)
 		case key.Matches(msg, keys.Global.Help):
 			// '?' toggles help widget
 			m.showHelp = !m.showHelp
+			// Reset help filter whenever help is toggled, along with the
+			// scroll position, which only pertains to the filtered bindings.
+			if m.helpFilter.Value() != "" {
+				m.helpFilter.SetValue("")
+				m.helpPane.GotoTop()
+			}
 			// Help widget takes up space so reset dimensions for all new and
 			// existing child models
 			m.resetDimensions()
+		case m.showHelp && key.Matches(msg, keys.Global.Filter):
+			// '/' filters the help bindings whilst help is visible.
+			m.mode = helpMode
+			return m, m.helpFilter.Focus()
 		case key.Matches(msg, keys.Global.Filter):
 			// '/' enables filter mode if the current model indicates it
 			// supports it, which it does so by sending back a non-nil command.
@@ -209,6 +401,32 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.mode = filterMode
 			}
 			return m, cmd
+		case key.Matches(msg, keys.Global.Jump):
+			// ' enables jump mode if the current model indicates it supports
+			// it, which it does so by sending back a non-nil command.
+			if cmd = m.updateCurrent(tui.JumpReqMsg{}); cmd != nil {
+				m.mode = jumpMode
+			}
+			return m, cmd
+		case key.Matches(msg, keys.Columns.Pick):
+			// alt+c opens the column picker if the current model indicates it
+			// supports it, which it does so by sending back a non-nil command.
+			if cmd = m.updateCurrent(tui.ColumnPickerReqMsg{}); cmd != nil {
+				m.mode = columnsMode
+			}
+			return m, cmd
+		case key.Matches(msg, keys.Global.Notifications):
+			// open notifications pane
+			m.mode = notificationsMode
+			m.notificationsPane = tui.NewViewport(tui.ViewportOptions{
+				Width:  m.viewWidth(),
+				Height: m.viewHeight(),
+			})
+			_ = m.notificationsPane.AppendContent([]byte(m.notifications.render()), true)
+			return m, nil
+		case key.Matches(msg, keys.Global.Export):
+			// export table on current page to CSV file
+			return m, exportCSV(m.currentModel(), m.exportDir, time.Now())
 		case key.Matches(msg, keys.Global.Logs):
 			// show logs
 			return m, tui.NavigateTo(tui.LogListKind)
@@ -241,22 +459,60 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		}
+	case tea.MouseMsg:
+		if msg.Y < breadcrumbsHeight {
+			return m, m.clickBreadcrumb(msg)
+		}
+		// Send mouse event to the current model, with its position made
+		// relative to the main view, ignoring events outside of the main view.
+		msg.Y -= breadcrumbsHeight
+		if m.mode == promptMode {
+			msg.Y -= tui.PromptHeight
+		}
+		if msg.Y < 0 || msg.Y >= m.viewHeight() {
+			return m, nil
+		}
+		return m, m.updateCurrent(msg)
 	case tui.NavigationMsg:
+		visiting := msg.Page != m.currentPage()
+		if visiting {
+			cmds = append(cmds, m.leave(msg.Page))
+		}
 		created, err := m.setCurrent(msg.Page)
 		if err != nil {
 			return m, tui.ReportError(fmt.Errorf("setting current page: %w", err))
 		}
+		if visiting {
+			cmds = append(cmds, m.visit())
+		}
 		if created {
 			cmds = append(cmds, m.currentModel().Init())
+			if m.loading() {
+				// Spin spinner whilst the model loads.
+				cmds = append(cmds, m.spinner.Tick)
+			}
 		}
+	case tui.TaskGroupCreatedMsg:
+		m.batches.add(msg.Group)
+		// Tasks may have finished before the message was received.
+		return m, m.reportFinishedBatches()
 	case tui.ErrorMsg:
 		m.err = error(msg)
+		m.notifications.add(notification{time: time.Now(), msg: m.err.Error(), err: true})
 	case tui.InfoMsg:
 		m.info = string(msg)
+		m.notifications.add(notification{time: time.Now(), msg: m.info})
 	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
-		m.resetDimensions()
+		if cmd := m.resize(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		} else {
+			m.applyDimensions()
+		}
+	case resizeMsg:
+		// Only resize models if the terminal has not been resized since.
+		if msg.seq == m.resizeSeq {
+			m.applyDimensions()
+		}
 	default:
 		// Send remaining msg types to all cached models
 		cmds = append(cmds, m.cache.UpdateAll(msg)...)
@@ -266,10 +522,23 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.mode == promptMode {
 			cmds = append(cmds, m.prompt.HandleBlink(msg))
 		}
+		// Likewise send message to the help filter if it's taking input.
+		if m.mode == helpMode {
+			m.helpFilter, cmd = m.helpFilter.Update(msg)
+			cmds = append(cmds, cmd)
+		}
 	}
 	return m, tea.Batch(cmds...)
 }
 
+// applyDimensions resizes models to fit the terminal.
+func (m *model) applyDimensions() {
+	m.resetDimensions()
+	if m.mode == notificationsMode {
+		m.notificationsPane.SetDimensions(m.viewWidth(), m.viewHeight())
+	}
+}
+
 func (m *model) resetDimensions() {
 	// Inform navigator of new dimensions for when it builds new models
 	m.navigator.width = m.viewWidth()
@@ -287,24 +556,53 @@ var (
 	messageFooterHeight = 1
 )
 
+// titleWidth returns the width available to the title of the current page in
+// the header, which is whatever is left over by the status.
+func (m model) titleWidth() int {
+	width := m.width
+	if statusable, ok := m.currentModel().(tui.ModelStatus); ok {
+		width -= tui.Width(statusable.Status())
+	}
+	return max(0, width)
+}
+
+// clickBreadcrumb navigates to the page of the breadcrumb in the header that
+// is clicked.
+func (m model) clickBreadcrumb(msg tea.MouseMsg) tea.Cmd {
+	if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+		return nil
+	}
+	model, ok := m.currentModel().(tui.ModelTitle)
+	if !ok {
+		return nil
+	}
+	crumb, ok := model.Title().At(msg.X, m.titleWidth())
+	if !ok || crumb.Page == nil || *crumb.Page == m.currentPage() {
+		return nil
+	}
+	return tui.CmdHandler(tui.NavigationMsg{Page: *crumb.Page})
+}
+
 func (m model) View() string {
+	if m.tooSmall() {
+		return m.tooSmallView()
+	}
 	// Compose header
 	var (
-		header   string
-		status   string
-		leftover int
+		header string
+		status string
 	)
-	// Optionally render title on the left of header
-	if model, ok := m.currentModel().(tui.ModelTitle); ok {
-		header = model.Title()
-		leftover = m.width - tui.Width(header)
-	}
 	// Optionally render status on the right of header
 	if statusable, ok := m.currentModel().(tui.ModelStatus); ok {
 		status = statusable.Status()
-		leftover -= tui.Width(status)
+	}
+	// Optionally render title on the left of header, in the space beside the
+	// status.
+	if model, ok := m.currentModel().(tui.ModelTitle); ok {
+		header = model.Title().Render(m.titleWidth())
 	}
 	// Fill in left over space in between title and status with background color
+	leftover := m.width - tui.Width(header) - tui.Width(status)
 	header += tui.Regular.Width(leftover).Background(tui.Purple).Render()
 	header += status
 	// Style the header
@@ -323,11 +621,15 @@ func (m model) View() string {
 	if m.mode == promptMode {
 		components = append(components, m.prompt.View(m.width))
 	}
-	// Add main content
+	// Add main content, or the notifications pane if it is open.
+	content := m.currentModel().View()
+	if m.mode == notificationsMode {
+		content = m.notificationsPane.View()
+	}
 	components = append(components, lipgloss.NewStyle().
 		Height(m.viewHeight()).
 		Width(m.viewWidth()).
-		Render(m.currentModel().View()),
+		Render(content),
 	)
 
 	// Add help if enabled
@@ -337,6 +639,17 @@ func (m model) View() string {
 
 	// Compose footer
 	footer := tui.Padded.Background(tui.Grey).Foreground(tui.White).Render("? help")
+	// Indicate the number of unread notifications, besides any shown below.
+	unread := m.notifications.unread
+	if m.err != nil || m.info != "" {
+		unread--
+	}
+	if unread > 0 {
+		footer += tui.Padded.
+			Background(tui.Orange).
+			Foreground(tui.Black).
+			Render(fmt.Sprintf("%d unread", unread))
+	}
 	if m.err != nil {
 		footer += tui.Padded.
 			Bold(true).
@@ -353,11 +666,26 @@ func (m model) View() string {
 			Background(tui.EvenLighterGrey).
 			Render(m.info)
 	}
+	// Summarise the progress of unfinished task groups
+	if summary := m.batches.summary(); summary != "" {
+		footer += tui.Padded.
+			Background(tui.Blue).
+			Foreground(tui.White).
+			Render(summary)
+	}
+	// Optionally render the current page on the right of the footer
+	var pagination string
+	if model, ok := m.currentModel().(tui.ModelPagination); ok {
+		if page := model.Pagination(); page != "" {
+			pagination = tui.Padded.Background(tui.Grey).Foreground(tui.White).Render(page)
+		}
+	}
 	workdir := tui.Padded.Background(tui.LightGrey).Foreground(tui.White).Render(m.workdir)
 	version := tui.Padded.Background(tui.DarkGrey).Foreground(tui.White).Render(version.Version)
 	// Fill in left over space with background color
-	leftover = m.width - tui.Width(footer) - tui.Width(workdir) - tui.Width(version)
+	leftover = m.width - tui.Width(footer) - tui.Width(pagination) - tui.Width(workdir) - tui.Width(version)
 	footer += tui.Regular.Width(leftover).Background(tui.EvenLighterGrey).Render()
+	footer += pagination
 	footer += workdir
 	footer += version
 
@@ -395,21 +723,45 @@ func (m model) viewWidth() int {
 }
 
 var (
-	helpKeyStyle  = tui.Bold.Foreground(tui.HelpKey).Margin(0, 1, 0, 0)
-	helpDescStyle = tui.Regular.Foreground(tui.HelpDesc)
 	// Height of help widget, including borders
 	helpWidgetHeight = 12
 )
 
 // help renders key bindings
 func (m model) help() string {
-	// Compile list of bindings to render
+	m.setHelpContent()
+	content := m.helpPane.View()
+	if m.helpFilterVisible() {
+		if len(m.helpBindings()) == 0 {
+			content = tui.Regular.Foreground(tui.HelpDesc).Render("No matching bindings")
+		}
+		content = lipgloss.JoinVertical(lipgloss.Left, m.helpFilter.View(), content)
+	}
+	// Subtract 2 to accommodate borders
+	return tui.Border.Height(helpWidgetHeight - 2).Width(m.width - 2).Render(content)
+}
+
+// helpBindings returns the bindings listed in the help widget, filtered by the
+// help filter.
+func (m model) helpBindings() []key.Binding {
 	bindings := []key.Binding{keys.Global.Help, keys.Global.Quit}
 	switch m.mode {
 	case promptMode:
 		bindings = append(bindings, m.prompt.HelpBindings()...)
 	case filterMode:
 		bindings = append(bindings, keys.KeyMapToSlice(keys.Filter)...)
+	case helpMode:
+		// Searching the help also searches the current page's bindings.
+		bindings = append(bindings, keys.KeyMapToSlice(keys.Filter)...)
+		if model, ok := m.currentModel().(tui.ModelHelpBindings); ok {
+			bindings = append(bindings, model.HelpBindings()...)
+		}
+	case jumpMode:
+		bindings = append(bindings, keys.KeyMapToSlice(keys.Jump)...)
+	case columnsMode:
+		bindings = append(bindings, keys.KeyMapToSlice(keys.ColumnPicker)...)
+	case notificationsMode:
+		bindings = append(bindings, keys.Global.Notifications, keys.Global.Back)
 	default:
 		if model, ok := m.currentModel().(tui.ModelHelpBindings); ok {
 			bindings = append(bindings, model.HelpBindings()...)
@@ -417,16 +769,53 @@ func (m model) help() string {
 	}
 	bindings = append(bindings, keys.KeyMapToSlice(keys.Global)...)
 	bindings = append(bindings, keys.KeyMapToSlice(keys.Navigation)...)
+	bindings = append(bindings, keys.KeyMapToSlice(keys.Columns)...)
+	bindings = append(bindings, keys.KeyMapToSlice(keys.Help)...)
 	bindings = removeDuplicateBindings(bindings)
+	return filterBindings(bindings, m.helpFilter.Value())
+}
+
+// setHelpContent populates the help pane with the bindings, sizing it to fit
+// within the help widget.
+func (m *model) setHelpContent() {
+	// Subtract 2 to accommodate borders
+	rows := helpWidgetHeight - 2
+	if m.helpFilterVisible() {
+		// Make room for filter widget
+		rows--
+	}
+	m.helpPane.Width = max(0, m.width-2)
+	m.helpPane.Height = rows
+	m.helpPane.SetContent(renderBindings(m.helpBindings(), m.helpPane.Width, rows))
+}
 
-	// Enumerate through each group of bindings, populating a series of
-	// pairs of columns, one for keys, one for descriptions
+// renderBindings lays out bindings in as many pairs of columns as fit within
+// the given width, one column for keys and one for descriptions. Each pair of
+// columns is at least the given number of rows tall, and taller still if the
+// bindings don't otherwise fit, in which case the help pane must be scrolled to
+// see them all.
+func renderBindings(bindings []key.Binding, width, rows int) string {
+	// Styles are constructed upon rendering in order to pick up any theme.
 	var (
-		pairs []string
-		width int
-		// Subtract 2 to accommodate borders
-		rows = helpWidgetHeight - 2
+		helpKeyStyle  = tui.Bold.Foreground(tui.HelpKey).Margin(0, 1, 0, 0)
+		helpDescStyle = tui.Regular.Foreground(tui.HelpDesc)
 	)
+	if len(bindings) == 0 || rows < 1 {
+		return ""
+	}
+	// Determine how many pairs of columns fit, allowing for the widest key and
+	// the widest description, and for the three space margin separating pairs.
+	var keyWidth, descWidth int
+	for _, b := range bindings {
+		keyWidth = max(keyWidth, lipgloss.Width(helpKeyStyle.Render(b.Help().Key)))
+		descWidth = max(descWidth, lipgloss.Width(b.Help().Desc))
+	}
+	const margin = 3
+	pairs := max(1, (width+margin)/(keyWidth+descWidth+margin))
+	// Lengthen columns until the bindings fit into the pairs of columns.
+	rows = max(rows, (len(bindings)+pairs-1)/pairs)
+
+	cols := make([]string, 0, 3*pairs)
 	for i := 0; i < len(bindings); i += rows {
 		var (
 			keys  []string
@@ -436,29 +825,42 @@ func (m model) help() string {
 			keys = append(keys, helpKeyStyle.Render(bindings[j].Help().Key))
 			descs = append(descs, helpDescStyle.Render(bindings[j].Help().Desc))
 		}
-		// Render pair of columns; beyond the first pair, render a three space
-		// left margin, in order to visually separate the pairs.
-		var cols []string
-		if len(pairs) > 0 {
-			cols = []string{"   "}
+		// Beyond the first pair, render a three space left margin, in order
+		// to visually separate the pairs.
+		if len(cols) > 0 {
+			cols = append(cols, strings.Repeat(" ", margin))
 		}
 		cols = append(cols,
 			strings.Join(keys, "\n"),
 			strings.Join(descs, "\n"),
 		)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, cols...)
+}
+
+// helpFilterVisible returns true if the help filter is either taking input or
+// has a non-empty value.
+func (m model) helpFilterVisible() bool {
+	return m.helpFilter.Focused() || m.helpFilter.Value() != ""
+}
 
-		pair := lipgloss.JoinHorizontal(lipgloss.Top, cols...)
-		// check whether it exceeds the maximum width avail (the width of the
-		// terminal, subtracting 2 for the borders).
-		width += lipgloss.Width(pair)
-		if width > m.width-2 {
-			break
+// filterBindings returns those bindings for which either the help key or the
+// help description contains the filter value, ignoring case. An empty filter
+// value matches all bindings.
+func filterBindings(bindings []key.Binding, value string) []key.Binding {
+	if value == "" {
+		return bindings
+	}
+	value = strings.ToLower(value)
+	var filtered []key.Binding
+	for _, b := range bindings {
+		help := b.Help()
+		if strings.Contains(strings.ToLower(help.Key), value) ||
+			strings.Contains(strings.ToLower(help.Desc), value) {
+			filtered = append(filtered, b)
 		}
-		pairs = append(pairs, pair)
 	}
-	// Join pairs of columns and enclose in a border
-	content := lipgloss.JoinHorizontal(lipgloss.Top, pairs...)
-	return tui.Border.Height(rows).Width(m.width - 2).Render(content)
+	return filtered
 }
 
 // removeDuplicateBindings removes duplicate bindings from a list of bindings. A
@@ -478,3 +880,9 @@ func removeDuplicateBindings(bindings []key.Binding) []key.Binding {
 	}
 	return bindings[:i]
 }
+
+// loading returns true if the current model is yet to load its content.
+func (m model) loading() bool {
+	model, ok := m.currentModel().(tui.ModelLoading)
+	return ok && model.Loading()
+}
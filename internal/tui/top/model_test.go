@@ -0,0 +1,106 @@
+package top
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/leg100/pug/internal/tui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterBindings(t *testing.T) {
+	apply := key.NewBinding(key.WithKeys("a"), key.WithHelp("a", "apply"))
+	plan := key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "plan"))
+	autoscroll := key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("ctrl+s", "toggle autoscroll"))
+	bindings := []key.Binding{apply, plan, autoscroll}
+
+	tests := []struct {
+		name   string
+		filter string
+		want   []key.Binding
+	}{
+		{"empty filter", "", bindings},
+		{"match description", "apply", []key.Binding{apply}},
+		{"match key", "ctrl", []key.Binding{autoscroll}},
+		{"match several", "p", []key.Binding{apply, plan}},
+		{"ignore case", "PLAN", []key.Binding{plan}},
+		{"no match", "destroy", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, filterBindings(bindings, tt.filter))
+		})
+	}
+}
+
+func TestRenderBindings(t *testing.T) {
+	bindings := make([]key.Binding, 10)
+	for i := range bindings {
+		k := strconv.Itoa(i)
+		bindings[i] = key.NewBinding(key.WithKeys(k), key.WithHelp(k, "action"+k))
+	}
+
+	tests := []struct {
+		name  string
+		width int
+		// wantRows is the number of lines rendered.
+		wantRows int
+	}{
+		{"fits in several pairs", 80, 4},
+		{"lengthens single pair to fit", 10, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := renderBindings(bindings, tt.width, 4)
+			assert.Equal(t, tt.wantRows, lipgloss.Height(got))
+			for i := range bindings {
+				assert.Contains(t, got, "action"+strconv.Itoa(i))
+			}
+		})
+	}
+}
+
+func TestHelpPane_Scroll(t *testing.T) {
+	m := model{width: 10, showHelp: true, navigator: &navigator{history: []tui.Page{{}}, cache: tui.NewCache()}}
+	m.setHelpContent()
+	require.Greater(t, m.helpPane.TotalLineCount(), m.helpPane.Height)
+
+	// Scroll down to reveal bindings beyond the bottom of the help widget.
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+	m = updated.(model)
+	assert.Equal(t, 1, m.helpPane.YOffset)
+
+	// Toggling help retains the scroll position.
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+	m = updated.(model)
+	assert.True(t, m.showHelp)
+	assert.Equal(t, 1, m.helpPane.YOffset)
+}
+
+type fakeHelpModel struct {
+	fakeFilterModel
+}
+
+func (fakeHelpModel) HelpBindings() []key.Binding {
+	return []key.Binding{key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "page action"))}
+}
+
+func TestHelpBindings_HelpMode(t *testing.T) {
+	page := tui.Page{Kind: tui.TaskKind}
+	cache := tui.NewCache()
+	cache.Put(page, fakeHelpModel{})
+	m := model{mode: helpMode, navigator: &navigator{history: []tui.Page{page}, cache: cache}}
+	m.helpFilter = textinput.New()
+	m.helpFilter.SetValue("page action")
+
+	// Searching the help finds the current page's bindings.
+	got := m.helpBindings()
+	require.Len(t, got, 1)
+	assert.Equal(t, "page action", got[0].Help().Desc)
+}
@@ -16,25 +16,24 @@ type navigator struct {
 	cache *tui.Cache
 	// directory of model makers for each kind
 	makers map[tui.Kind]tui.Maker
+	// filters retains the filter last used on each kind of page, so that it
+	// can be restored on new pages of the same kind.
+	filters map[tui.Kind]string
 
 	// navigator needs to know width and height when making a model
 	width  int
 	height int
 }
 
-func newNavigator(firstPage string, makers map[tui.Kind]tui.Maker) (*navigator, error) {
+func newNavigator(firstPage tui.Page, makers map[tui.Kind]tui.Maker) (*navigator, error) {
 	n := &navigator{
-		makers: makers,
-		cache:  tui.NewCache(),
-	}
-
-	firstKind, err := tui.FirstPageKind(firstPage)
-	if err != nil {
-		return nil, err
+		makers:  makers,
+		cache:   tui.NewCache(),
+		filters: make(map[tui.Kind]string),
 	}
 
 	// ignore returned init cmd; instead the main model should invoke it
-	if _, err = n.setCurrent(tui.Page{Kind: firstKind}); err != nil {
+	if _, err := n.setCurrent(firstPage); err != nil {
 		return nil, err
 	}
 	return n, nil
@@ -54,6 +53,8 @@ func (n *navigator) setCurrent(page tui.Page) (created bool, err error) {
 		return false, nil
 	}
 
+	n.saveFilter()
+
 	// Check target page model is cached; if not then create and cache it
 	if !n.cache.Exists(page) {
 		maker, ok := n.makers[page.Kind]
@@ -66,6 +67,10 @@ func (n *navigator) setCurrent(page tui.Page) (created bool, err error) {
 		}
 		n.cache.Put(page, model)
 		created = true
+		// Restore the filter last used on this kind of page.
+		if filter := n.filters[page.Kind]; filter != "" {
+			_ = n.cache.Update(page, tui.FilterSetMsg(filter))
+		}
 	}
 	// Push new current page to history
 	n.history = append(n.history, page)
@@ -76,11 +81,43 @@ func (n *navigator) updateCurrent(msg tea.Msg) tea.Cmd {
 	return n.cache.Update(n.currentPage(), msg)
 }
 
-func (n *navigator) goBack() {
+func (n *navigator) goBack() tea.Cmd {
 	if len(n.history) == 1 {
 		// Silently refuse to go back further than first page.
-		return
+		return nil
 	}
+	leave := n.leave(n.history[len(n.history)-2])
+	n.saveFilter()
 	// Pop current page from history
 	n.history = n.history[:len(n.history)-1]
+	return tea.Batch(leave, n.visit())
+}
+
+// leave informs the model of the current page that the user is navigating
+// away from it to another page.
+func (n *navigator) leave(to tui.Page) tea.Cmd {
+	if model, ok := n.currentModel().(tui.ModelLeave); ok {
+		return model.Leave(to)
+	}
+	return nil
+}
+
+// visit informs the model of the current page that the user has navigated to
+// it.
+func (n *navigator) visit() tea.Cmd {
+	if model, ok := n.currentModel().(tui.ModelVisit); ok {
+		return model.Visit()
+	}
+	return nil
+}
+
+// saveFilter retains the filter of the current page, if it has one, before
+// navigating away from it.
+func (n *navigator) saveFilter() {
+	if len(n.history) == 0 {
+		return
+	}
+	if model, ok := n.currentModel().(tui.ModelFilter); ok {
+		n.filters[n.currentPage().Kind] = model.FilterValue()
+	}
 }
@@ -0,0 +1,231 @@
+package top
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/tui"
+	"github.com/leg100/pug/internal/tui/table"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFilterModel struct {
+	filter string
+}
+
+func (m fakeFilterModel) Init() tea.Cmd { return nil }
+
+func (m fakeFilterModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tui.FilterSetMsg:
+		m.filter = string(msg)
+	case tui.FilterCloseMsg:
+		m.filter = ""
+	}
+	return m, nil
+}
+
+func (m fakeFilterModel) View() string { return "" }
+
+func (m fakeFilterModel) FilterValue() string { return m.filter }
+
+type fakeFilterMaker struct{}
+
+func (fakeFilterMaker) Make(resource.ID, int, int) (tea.Model, error) {
+	return fakeFilterModel{}, nil
+}
+
+func TestNavigator_RestoreFilter(t *testing.T) {
+	makers := map[tui.Kind]tui.Maker{
+		tui.WorkspaceListKind: fakeFilterMaker{},
+		tui.TaskListKind:      fakeFilterMaker{},
+	}
+	workspaces := tui.Page{Kind: tui.WorkspaceListKind}
+	n, err := newNavigator(workspaces, makers)
+	require.NoError(t, err)
+
+	filter := func() string {
+		return n.currentModel().(tui.ModelFilter).FilterValue()
+	}
+
+	_ = n.updateCurrent(tui.FilterSetMsg("dev"))
+
+	// Navigating away and back retains the filter.
+	_, err = n.setCurrent(tui.Page{Kind: tui.TaskListKind})
+	require.NoError(t, err)
+	assert.Equal(t, "", filter())
+	n.goBack()
+	assert.Equal(t, "dev", filter())
+
+	// A new page of the same kind is given the same filter.
+	module := resource.NewID(resource.Module)
+	_, err = n.setCurrent(tui.Page{Kind: tui.WorkspaceListKind, ID: module})
+	require.NoError(t, err)
+	assert.Equal(t, "dev", filter())
+
+	// Closing the filter means new pages of the same kind are not filtered.
+	n.goBack()
+	_ = n.updateCurrent(tui.FilterCloseMsg{})
+	_, err = n.setCurrent(tui.Page{Kind: tui.WorkspaceListKind, ID: resource.NewID(resource.Module)})
+	require.NoError(t, err)
+	assert.Equal(t, "", filter())
+}
+
+type fakeItem struct {
+	resource.ID
+}
+
+func (fakeItem) String() string { return "" }
+
+type fakeTableModel struct {
+	table table.Model[fakeItem]
+}
+
+func (m fakeTableModel) Init() tea.Cmd { return nil }
+
+func (m fakeTableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+func (m fakeTableModel) View() string { return "" }
+
+type fakeTableMaker struct {
+	items []fakeItem
+}
+
+func (mm fakeTableMaker) Make(resource.ID, int, int) (tea.Model, error) {
+	renderer := func(fakeItem) table.RenderedRow { return nil }
+	tbl := table.New(nil, renderer, 0, 0,
+		table.WithSelectable[fakeItem](true),
+		table.WithSortFunc(func(i, j fakeItem) int {
+			return int(i.Serial) - int(j.Serial)
+		}),
+	)
+	tbl.SetItems(mm.items...)
+	return fakeTableModel{table: tbl}, nil
+}
+
+func TestNavigator_RetainSelection(t *testing.T) {
+	items := []fakeItem{
+		{resource.NewID(resource.Workspace)},
+		{resource.NewID(resource.Workspace)},
+		{resource.NewID(resource.Workspace)},
+	}
+	makers := map[tui.Kind]tui.Maker{
+		tui.WorkspaceListKind: fakeTableMaker{items: items},
+		tui.TaskListKind:      fakeTableMaker{},
+	}
+	workspaces := tui.Page{Kind: tui.WorkspaceListKind}
+	n, err := newNavigator(workspaces, makers)
+	require.NoError(t, err)
+
+	selected := func() []resource.ID {
+		return n.currentModel().(fakeTableModel).table.SelectedOrCurrentIDs()
+	}
+	update := func(fn func(tbl *table.Model[fakeItem])) {
+		m := n.currentModel().(fakeTableModel)
+		fn(&m.table)
+		n.cache.Put(n.currentPage(), m)
+	}
+
+	update(func(tbl *table.Model[fakeItem]) {
+		tbl.ToggleSelectionByID(items[0].ID)
+		tbl.ToggleSelectionByID(items[1].ID)
+	})
+
+	// Navigating away and back retains the selection.
+	_, err = n.setCurrent(tui.Page{Kind: tui.TaskListKind})
+	require.NoError(t, err)
+	n.goBack()
+	assert.ElementsMatch(t, []resource.ID{items[0].ID, items[1].ID}, selected())
+
+	// Items that no longer exist are pruned from the selection.
+	update(func(tbl *table.Model[fakeItem]) {
+		tbl.SetItems(items[0], items[2])
+	})
+	assert.Equal(t, []resource.ID{items[0].ID}, selected())
+
+	// The selection is cleared explicitly, leaving only the current row.
+	update(func(tbl *table.Model[fakeItem]) {
+		tbl.ToggleSelectionByID(items[2].ID)
+	})
+	require.Len(t, selected(), 2)
+	_ = n.updateCurrent(tea.KeyMsg{Type: tea.KeyCtrlBackslash})
+	current, ok := n.currentModel().(fakeTableModel).table.CurrentRow()
+	require.True(t, ok)
+	assert.Equal(t, []resource.ID{current.ID}, selected())
+}
+
+type fakeLeaveModel struct {
+	fakeFilterModel
+}
+
+// Leave returns a command that returns the page navigated to.
+func (fakeLeaveModel) Leave(to tui.Page) tea.Cmd {
+	return func() tea.Msg { return to }
+}
+
+type fakeLeaveMaker struct{}
+
+func (fakeLeaveMaker) Make(resource.ID, int, int) (tea.Model, error) {
+	return fakeLeaveModel{}, nil
+}
+
+func TestNavigator_Leave(t *testing.T) {
+	makers := map[tui.Kind]tui.Maker{
+		tui.WorkspaceListKind: fakeFilterMaker{},
+		tui.TaskKind:          fakeLeaveMaker{},
+	}
+	workspaces := tui.Page{Kind: tui.WorkspaceListKind}
+	n, err := newNavigator(workspaces, makers)
+	require.NoError(t, err)
+
+	// Leaving a page that doesn't implement ModelLeave does nothing.
+	assert.Nil(t, n.leave(tui.Page{Kind: tui.TaskKind}))
+
+	_, err = n.setCurrent(tui.Page{Kind: tui.TaskKind})
+	require.NoError(t, err)
+
+	// Going back informs the model of the page navigated to.
+	cmd := n.goBack()
+	require.NotNil(t, cmd)
+	assert.Equal(t, workspaces, cmd())
+}
+
+type fakeVisitModel struct {
+	fakeFilterModel
+}
+
+// Visit returns a command that returns true.
+func (fakeVisitModel) Visit() tea.Cmd {
+	return func() tea.Msg { return true }
+}
+
+type fakeVisitMaker struct{}
+
+func (fakeVisitMaker) Make(resource.ID, int, int) (tea.Model, error) {
+	return fakeVisitModel{}, nil
+}
+
+func TestNavigator_Visit(t *testing.T) {
+	makers := map[tui.Kind]tui.Maker{
+		tui.WorkspaceListKind: fakeVisitMaker{},
+		tui.TaskKind:          fakeFilterMaker{},
+	}
+	n, err := newNavigator(tui.Page{Kind: tui.WorkspaceListKind}, makers)
+	require.NoError(t, err)
+
+	// Visiting a page that doesn't implement ModelVisit does nothing.
+	_, err = n.setCurrent(tui.Page{Kind: tui.TaskKind})
+	require.NoError(t, err)
+	assert.Nil(t, n.visit())
+
+	// Going back informs the model of the page returned to.
+	cmd := n.goBack()
+	require.NotNil(t, cmd)
+	assert.Equal(t, true, cmd())
+}
@@ -0,0 +1,56 @@
+package top
+
+import (
+	"strings"
+	"time"
+
+	"github.com/leg100/pug/internal/tui"
+)
+
+// maxNotifications is the maximum number of notifications retained.
+const maxNotifications = 100
+
+// notification is an error or informational message reported to the user.
+type notification struct {
+	time time.Time
+	msg  string
+	err  bool
+}
+
+// notifications retains the most recent notifications, discarding the oldest
+// once there are more than the maximum.
+type notifications struct {
+	// items are ordered oldest first.
+	items []notification
+	// unread is the number of notifications added since the user last viewed
+	// them.
+	unread int
+}
+
+func (n *notifications) add(nt notification) {
+	n.items = append(n.items, nt)
+	if len(n.items) > maxNotifications {
+		n.items = n.items[len(n.items)-maxNotifications:]
+	}
+	n.unread++
+}
+
+// render renders notifications, newest first, for display in the
+// notifications pane, and marks them as read.
+func (n *notifications) render() string {
+	n.unread = 0
+	if len(n.items) == 0 {
+		return "No notifications."
+	}
+	lines := make([]string, 0, len(n.items))
+	for i := len(n.items) - 1; i >= 0; i-- {
+		nt := n.items[i]
+		style := tui.Regular.Foreground(tui.Green)
+		if nt.err {
+			style = tui.Regular.Foreground(tui.Red)
+		}
+		timestamp := tui.Regular.Foreground(tui.LightGrey).Render(nt.time.Format("15:04:05"))
+		lines = append(lines, timestamp+" "+style.Render(nt.msg))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,40 @@
+package top
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/leg100/pug/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifications(t *testing.T) {
+	var n notifications
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	n.add(notification{time: now, msg: "created 3 runs"})
+	n.add(notification{time: now.Add(time.Second), msg: "boom", err: true})
+	assert.Equal(t, 2, n.unread)
+
+	got := internal.StripAnsi(n.render())
+	assert.Equal(t, "12:00:01 boom\n12:00:00 created 3 runs", got)
+	assert.Equal(t, 0, n.unread, "rendering marks notifications as read")
+}
+
+func TestNotifications_Max(t *testing.T) {
+	var n notifications
+	for i := range maxNotifications + 5 {
+		n.add(notification{msg: fmt.Sprintf("msg %d", i)})
+	}
+
+	assert.Len(t, n.items, maxNotifications)
+	assert.Equal(t, "msg 5", n.items[0].msg)
+	assert.Equal(t, fmt.Sprintf("msg %d", maxNotifications+4), n.items[len(n.items)-1].msg)
+}
+
+func TestNotifications_Empty(t *testing.T) {
+	var n notifications
+
+	assert.Equal(t, "No notifications.", n.render())
+}
@@ -0,0 +1,28 @@
+package top
+
+import (
+	"fmt"
+	"io"
+	"runtime/debug"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/logging"
+)
+
+// runProgram runs the program, blocking until it finishes. If the program
+// panics then the terminal is restored and the panic is reported, along with a
+// stack trace, to stderr and to the log. The panic is then returned as an error
+// rather than re-panicking, so that the app is still cleaned up, which stops
+// any running tasks and closes the log file.
+func runProgram(p *tea.Program, logger logging.Interface, stderr io.Writer) (final tea.Model, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			_ = p.ReleaseTerminal()
+			stack := debug.Stack()
+			fmt.Fprintf(stderr, "panic: %v\n\n%s\n", r, stack)
+			logger.Error("panic", "error", r, "stack", string(stack))
+			final, err = nil, fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return p.Run()
+}
@@ -0,0 +1,42 @@
+package top
+
+import (
+	"bytes"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type panicModel struct{}
+
+func (panicModel) Init() tea.Cmd { return nil }
+
+func (panicModel) Update(tea.Msg) (tea.Model, tea.Cmd) { panic("boom") }
+
+func (panicModel) View() string { return "" }
+
+func TestRunProgram_Panic(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	p := tea.NewProgram(panicModel{},
+		tea.WithInput(nil),
+		tea.WithOutput(&stdout),
+		tea.WithoutCatchPanics(),
+		tea.WithoutSignals(),
+	)
+	go p.Send(struct{}{})
+	logger := logging.NewLogger(logging.Options{Level: "info"})
+
+	final, err := runProgram(p, logger, &stderr)
+	assert.Nil(t, final)
+	assert.EqualError(t, err, "panic: boom")
+
+	// The panic is reported with a stack trace to stderr and to the log.
+	assert.Contains(t, stderr.String(), "panic: boom")
+	assert.Contains(t, stderr.String(), "goroutine")
+	msgs := logger.List()
+	require.Len(t, msgs, 1)
+	assert.Equal(t, "panic", msgs[0].Message)
+}
@@ -0,0 +1,73 @@
+package top
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/task"
+	"github.com/leg100/pug/internal/tui"
+)
+
+// batches tracks the progress of task groups that have yet to finish, i.e.
+// batches of tasks created together, for example by invoking init on several
+// selected modules.
+type batches []*task.Group
+
+// add starts tracking the progress of a task group.
+func (b *batches) add(group *task.Group) {
+	*b = append(*b, group)
+}
+
+// prune stops tracking task groups whose tasks have all finished, returning
+// them.
+func (b *batches) prune() (finished []*task.Group) {
+	var unfinished batches
+	for _, group := range *b {
+		if group.Finished() == len(group.Tasks) {
+			finished = append(finished, group)
+		} else {
+			unfinished = append(unfinished, group)
+		}
+	}
+	*b = unfinished
+	return finished
+}
+
+// summary summarises the progress of each task group being tracked, e.g.
+// "init 7/20 complete, 1 failed".
+func (b batches) summary() string {
+	summaries := make([]string, len(b))
+	for i, group := range b {
+		summaries[i] = batchSummary(group)
+	}
+	return strings.Join(summaries, " · ")
+}
+
+// batchSummary summarises the progress of a task group. Tasks that finished
+// without exiting successfully, i.e. those that errored or were canceled, are
+// counted as failed.
+func batchSummary(group *task.Group) string {
+	s := fmt.Sprintf("%s %d/%d complete", group.Command, group.Finished(), len(group.Tasks))
+	if failed := group.Finished() - group.Exited(); failed > 0 {
+		s += fmt.Sprintf(", %d failed", failed)
+	}
+	return s
+}
+
+// reportFinishedBatches stops tracking task groups that have finished,
+// reporting a summary of each. The summary is reported as an error if any of
+// the group's tasks failed.
+func (m *model) reportFinishedBatches() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, group := range m.batches.prune() {
+		summary := batchSummary(group)
+		if group.Finished() > group.Exited() {
+			cmds = append(cmds, tui.ReportError(errors.New(summary)))
+		} else {
+			cmds = append(cmds, tui.ReportInfo("%s", summary))
+		}
+	}
+	return tea.Batch(cmds...)
+}
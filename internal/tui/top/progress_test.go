@@ -0,0 +1,44 @@
+package top
+
+import (
+	"testing"
+
+	"github.com/leg100/pug/internal/task"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatches(t *testing.T) {
+	initGroup := &task.Group{
+		Command: "init",
+		Tasks: []*task.Task{
+			{State: task.Exited},
+			{State: task.Errored},
+			{State: task.Running},
+			{State: task.Queued},
+		},
+	}
+	validate := &task.Group{
+		Command: "validate",
+		Tasks: []*task.Task{
+			{State: task.Exited},
+			{State: task.Running},
+		},
+	}
+	var b batches
+	b.add(initGroup)
+	b.add(validate)
+
+	assert.Empty(t, b.prune())
+	assert.Equal(t, "init 2/4 complete, 1 failed · validate 1/2 complete", b.summary())
+
+	validate.Tasks[1].State = task.Exited
+
+	assert.Equal(t, []*task.Group{validate}, b.prune())
+	assert.Equal(t, "init 2/4 complete, 1 failed", b.summary())
+
+	initGroup.Tasks[2].State = task.Canceled
+	initGroup.Tasks[3].State = task.Exited
+
+	assert.Equal(t, []*task.Group{initGroup}, b.prune())
+	assert.Empty(t, b.summary())
+}
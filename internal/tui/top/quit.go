@@ -0,0 +1,31 @@
+package top
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/tui"
+)
+
+// forceQuitWindow is the period within which pressing the quit key a second
+// time quits without confirmation.
+const forceQuitWindow = time.Second
+
+// quit quits the app, prompting the user for confirmation if any tasks are
+// still active. Pressing the quit key twice in quick succession forces the app
+// to quit without confirmation.
+func (m *model) quit(active int, now time.Time) tea.Cmd {
+	force := m.forceQuit(now)
+	m.lastQuit = now
+	if force || active == 0 {
+		return tea.Quit
+	}
+	return tui.YesNoPrompt(fmt.Sprintf("%d task(s) still active. Quit pug?", active), tea.Quit)
+}
+
+// forceQuit determines whether the quit key has been pressed a second time in
+// quick succession.
+func (m *model) forceQuit(now time.Time) bool {
+	return !m.lastQuit.IsZero() && now.Sub(m.lastQuit) < forceQuitWindow
+}
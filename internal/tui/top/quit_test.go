@@ -0,0 +1,44 @@
+package top
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/tui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuit(t *testing.T) {
+	now := time.Now()
+
+	t.Run("no active tasks", func(t *testing.T) {
+		m := &model{}
+
+		assert.Equal(t, tea.QuitMsg{}, m.quit(0, now)())
+	})
+
+	t.Run("active tasks", func(t *testing.T) {
+		m := &model{}
+
+		got, ok := m.quit(3, now)().(tui.PromptMsg)
+		require.True(t, ok)
+		assert.Equal(t, "3 task(s) still active. Quit pug? (y/N): ", got.Prompt)
+	})
+
+	t.Run("force quit", func(t *testing.T) {
+		m := &model{}
+		_ = m.quit(3, now)
+
+		assert.Equal(t, tea.QuitMsg{}, m.quit(3, now.Add(500*time.Millisecond))())
+	})
+
+	t.Run("second press too late", func(t *testing.T) {
+		m := &model{}
+		_ = m.quit(3, now)
+
+		_, ok := m.quit(3, now.Add(2*time.Second))().(tui.PromptMsg)
+		assert.True(t, ok)
+	})
+}
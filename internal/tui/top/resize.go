@@ -0,0 +1,59 @@
+package top
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// resizeDebounce is how long the terminal must go unresized before models are
+// resized to fit it.
+const resizeDebounce = 50 * time.Millisecond
+
+// resizeMsg resizes models to fit the terminal once it has stopped being
+// resized.
+type resizeMsg struct {
+	seq int
+}
+
+// resize records the new dimensions of the terminal. Resizing a terminal
+// produces a rapid succession of window size messages, so rather than resize
+// every model for each message, a command is returned that only resizes models
+// once the messages have stopped. The first message is handled immediately,
+// in which case a nil command is returned.
+func (m *model) resize(msg tea.WindowSizeMsg) tea.Cmd {
+	first := m.width == 0 && m.height == 0
+	m.width = msg.Width
+	m.height = msg.Height
+	m.resizeSeq++
+	if first {
+		return nil
+	}
+	seq := m.resizeSeq
+	return tea.Tick(resizeDebounce, func(time.Time) tea.Msg {
+		return resizeMsg{seq: seq}
+	})
+}
+
+// minWidth is the narrowest terminal in which pages are rendered.
+const minWidth = 20
+
+// tooSmall determines whether the terminal is too small to render pages,
+// which need room for the header and footer as well as the minimum height of
+// the main view.
+func (m model) tooSmall() bool {
+	minHeight := breadcrumbsHeight + minViewHeight + messageFooterHeight
+	return m.width < minWidth || m.height < minHeight
+}
+
+// tooSmallView renders a message in place of the page when the terminal is too
+// small.
+func (m model) tooSmallView() string {
+	msg := fmt.Sprintf("Terminal too small (%dx%d)", m.width, m.height)
+	return lipgloss.NewStyle().
+		MaxWidth(m.width).
+		MaxHeight(m.height).
+		Render(lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, msg))
+}
@@ -0,0 +1,52 @@
+package top
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResize(t *testing.T) {
+	m := &model{}
+
+	// First message is handled immediately.
+	assert.Nil(t, m.resize(tea.WindowSizeMsg{Width: 80, Height: 24}))
+	assert.Equal(t, 80, m.width)
+	assert.Equal(t, 24, m.height)
+
+	// Subsequent messages are debounced, with only the last message resizing
+	// models.
+	first := m.resize(tea.WindowSizeMsg{Width: 90, Height: 30})
+	last := m.resize(tea.WindowSizeMsg{Width: 100, Height: 40})
+	assert.NotNil(t, first)
+	assert.NotNil(t, last)
+	assert.Equal(t, 100, m.width)
+	assert.Equal(t, 40, m.height)
+	assert.NotEqual(t, m.resizeSeq, first().(resizeMsg).seq)
+	assert.Equal(t, m.resizeSeq, last().(resizeMsg).seq)
+}
+
+func TestTooSmall(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		want          bool
+	}{
+		{"zero", 0, 0, true},
+		{"one row", 80, 1, true},
+		{"narrow", 10, 24, true},
+		{"minimum", minWidth, 12, false},
+		{"standard", 80, 24, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := model{width: tt.width, height: tt.height}
+			assert.Equal(t, tt.want, m.tooSmall())
+			if tt.want {
+				assert.LessOrEqual(t, lipgloss.Height(m.tooSmallView()), max(1, tt.height))
+			}
+		})
+	}
+}
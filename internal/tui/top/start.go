@@ -2,6 +2,9 @@ package top
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 
@@ -24,17 +27,22 @@ func Start(cfg app.Config) error {
 	if err != nil {
 		return err
 	}
+	defer m.close()
 
-	p := tea.NewProgram(m,
+	opts := []tea.ProgramOption{
 		// Use the full size of the terminal with its "alternate screen buffer"
 		tea.WithAltScreen(),
-		// Enabling mouse cell motion removes the ability to "blackboard" text
-		// with the mouse, which is useful for then copying text into the
-		// clipboard. Therefore we've decided to disable it and leave it
-		// commented out for posterity.
-		//
-		// tea.WithMouseCellMotion(),
-	)
+		// Panics are instead caught by runProgram, which reports them to the
+		// log as well as to the terminal.
+		tea.WithoutCatchPanics(),
+	}
+	// Enabling mouse cell motion removes the ability to "blackboard" text
+	// with the mouse, which is useful for then copying text into the
+	// clipboard. Therefore it is disabled unless the user opts in.
+	if cfg.Mouse {
+		opts = append(opts, tea.WithMouseCellMotion())
+	}
+	p := tea.NewProgram(m, opts...)
 
 	ch, unsub := setupSubscriptions(app, cfg)
 	defer unsub()
@@ -47,8 +55,17 @@ func Start(cfg app.Config) error {
 	}()
 
 	// Blocks until user quits
-	_, err = p.Run()
-	return err
+	final, err := runProgram(p, app.Logger, os.Stderr)
+	if err != nil {
+		return err
+	}
+	if cfg.RestorePage {
+		path := filepath.Join(cfg.DataDir, lastPageFile)
+		if err := SaveLastPage(final, path, cfg.Workdir.String()); err != nil {
+			return fmt.Errorf("saving last visited page: %w", err)
+		}
+	}
+	return ExitError(final)
 }
 
 // StartTest starts the TUI and returns a test model for testing purposes.
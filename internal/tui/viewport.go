@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -19,10 +20,26 @@ type Viewport struct {
 	viewport viewport.Model
 
 	Autoscroll bool
+	// scrolledAway is true if the user has scrolled away from the bottom of
+	// the content, pausing autoscroll until they return to the bottom.
+	scrolledAway bool
 
 	content []byte
-	json    bool
-	spinner *spinner.Model
+	// rendered is the wrapped and sanitized rendering of the complete lines of
+	// content, i.e. up to and including the last newline, which is retained
+	// so that only newly appended lines need rendering.
+	rendered []byte
+	// renderedUpTo is the length of content rendered into rendered.
+	renderedUpTo int
+	// sanitizer sanitizes the lines rendered thus far.
+	sanitizer sanitizer
+	json      bool
+	spinner   *spinner.Model
+
+	// minimap is true if a gutter marking errors and warnings is shown
+	minimap bool
+	// severities classifies each line of wrapped content
+	severities []severity
 }
 
 type ViewportOptions struct {
@@ -32,6 +49,9 @@ type ViewportOptions struct {
 	Border     bool
 	Autoscroll bool
 	Spinner    *spinner.Model
+	// Minimap shows a gutter marking where errors and warnings occur in the
+	// content.
+	Minimap bool
 }
 
 func NewViewport(opts ViewportOptions) Viewport {
@@ -40,7 +60,11 @@ func NewViewport(opts ViewportOptions) Viewport {
 		viewport:   viewport.New(0, 0),
 		json:       opts.JSON,
 		spinner:    opts.Spinner,
+		minimap:    opts.Minimap,
 	}
+	// Disable the upstream key bindings in favour of the navigation key
+	// bindings shared with tables, which are handled in Update.
+	m.viewport.KeyMap = viewport.KeyMap{}
 	m.SetDimensions(opts.Width, opts.Height)
 	return m
 }
@@ -58,17 +82,43 @@ func (m Viewport) Update(msg tea.Msg) (Viewport, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
+		case key.Matches(msg, keys.Navigation.LineUp):
+			m.viewport.LineUp(1)
+		case key.Matches(msg, keys.Navigation.LineDown):
+			m.viewport.LineDown(1)
+		case key.Matches(msg, keys.Navigation.PageUp):
+			m.viewport.ViewUp()
+		case key.Matches(msg, keys.Navigation.PageDown):
+			m.viewport.ViewDown()
+		case key.Matches(msg, keys.Navigation.HalfPageUp):
+			m.viewport.HalfViewUp()
+		case key.Matches(msg, keys.Navigation.HalfPageDown):
+			m.viewport.HalfViewDown()
 		case key.Matches(msg, keys.Navigation.GotoTop):
-			m.viewport.SetYOffset(0)
+			m.viewport.GotoTop()
 		case key.Matches(msg, keys.Navigation.GotoBottom):
-			m.viewport.SetYOffset(m.viewport.TotalLineCount())
+			m.viewport.GotoBottom()
+		case m.minimap && key.Matches(msg, keys.Minimap.NextMark):
+			if line, ok := nextMark(m.severities, m.viewport.YOffset, false); ok {
+				m.viewport.SetYOffset(line)
+			}
+		case m.minimap && key.Matches(msg, keys.Minimap.PrevMark):
+			if line, ok := nextMark(m.severities, m.viewport.YOffset, true); ok {
+				m.viewport.SetYOffset(line)
+			}
 		}
 	}
 
-	// Handle keyboard and mouse events in the viewport
+	// Handle mouse events in the viewport
 	m.viewport, cmd = m.viewport.Update(msg)
 	cmds = append(cmds, cmd)
 
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg:
+		// Pause autoscroll whilst the user is scrolled away from the bottom.
+		m.scrolledAway = !m.viewport.AtBottom()
+	}
+
 	return m, tea.Batch(cmds...)
 }
 
@@ -93,15 +143,22 @@ func (m Viewport) View() string {
 		m.viewport.VisibleLineCount(),
 		m.viewport.YOffset,
 	)
+	if m.minimap {
+		minimap := Minimap(m.viewport.Height, m.severities)
+		return lipgloss.JoinHorizontal(lipgloss.Top, output, scrollbar, minimap)
+	}
 	return lipgloss.JoinHorizontal(lipgloss.Top, output, scrollbar)
 }
 
 func (m *Viewport) SetDimensions(width, height int) {
 	width = max(0, width-ScrollbarWidth)
+	if m.minimap {
+		width = max(0, width-MinimapWidth)
+	}
 	// If width has changed, re-wrap existing content.
 	rewrap := m.viewport.Width != width
 	m.viewport.Width = width
-	m.viewport.Height = height
+	m.viewport.Height = max(0, height)
 	if rewrap {
 		m.setContent()
 	}
@@ -125,18 +182,58 @@ func (m *Viewport) AppendContent(content []byte, finished bool) (err error) {
 			}
 		}
 	}
-	m.setContent()
-	if m.Autoscroll {
+	if finished {
+		// Content may have been re-formatted, so render it afresh.
+		m.setContent()
+	} else {
+		m.appendContent()
+	}
+	if m.Autoscroll && !m.scrolledAway {
 		m.viewport.GotoBottom()
 	}
 	return err
 }
 
+// SetAutoscroll enables or disables autoscroll. Enabling autoscroll snaps the
+// viewport to the bottom of the content.
+func (m *Viewport) SetAutoscroll(enabled bool) {
+	m.Autoscroll = enabled
+	if enabled {
+		m.scrolledAway = false
+		m.viewport.GotoBottom()
+	}
+}
+
+// setContent renders all content afresh.
 func (m *Viewport) setContent() {
-	// Wrap content to the width of the viewport, whilst respecting ANSI escape
-	// codes (i.e. don't split codes across lines). The wrapper also ensures
-	// thekkkk
-	wrapped := wrap.Bytes(wordwrap.Bytes(m.content, m.viewport.Width), m.viewport.Width)
-	sanitized := SanitizeColors(wrapped)
-	m.viewport.SetContent(string(sanitized))
+	m.rendered = nil
+	m.renderedUpTo = 0
+	m.sanitizer = sanitizer{}
+	m.appendContent()
+}
+
+// appendContent renders content that has yet to be rendered. Complete lines
+// are rendered only once, whereas a trailing incomplete line is rendered each
+// time until it is completed.
+func (m *Viewport) appendContent() {
+	unrendered := m.content[m.renderedUpTo:]
+	if i := bytes.LastIndexByte(unrendered, '\n'); i >= 0 {
+		m.rendered = append(m.rendered, m.render(&m.sanitizer, unrendered[:i+1])...)
+		m.renderedUpTo += i + 1
+		unrendered = unrendered[i+1:]
+	}
+	// Render the incomplete line with a copy of the sanitizer, so that its
+	// state is only advanced by complete lines.
+	content := string(m.rendered) + string(m.render(m.sanitizer.clone(), unrendered))
+	m.viewport.SetContent(content)
+	if m.minimap {
+		m.severities = classifyLines(content)
+	}
+}
+
+// render wraps content to the width of the viewport, whilst respecting ANSI
+// escape codes (i.e. don't split codes across lines), and sanitizes colors.
+func (m *Viewport) render(s *sanitizer, content []byte) []byte {
+	wrapped := wrap.Bytes(wordwrap.Bytes(content, m.viewport.Width), m.viewport.Width)
+	return s.sanitize(wrapped)
 }
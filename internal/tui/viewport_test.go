@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testContent returns n lines of content, numbered from start. Content
+// following on from existing content begins with a new line.
+func testContent(start, n int) []byte {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", start+i)
+	}
+	content := strings.Join(lines, "\n")
+	if start > 0 {
+		content = "\n" + content
+	}
+	return []byte(content)
+}
+
+func TestViewport_Navigation(t *testing.T) {
+	runes := func(s string) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+	tests := []struct {
+		name string
+		key  tea.KeyMsg
+		want int
+	}{
+		{"line down", runes("j"), 51},
+		{"line down with arrow", tea.KeyMsg{Type: tea.KeyDown}, 51},
+		{"line up", runes("k"), 49},
+		{"line up with arrow", tea.KeyMsg{Type: tea.KeyUp}, 49},
+		{"page down", tea.KeyMsg{Type: tea.KeyPgDown}, 60},
+		{"page up", tea.KeyMsg{Type: tea.KeyPgUp}, 40},
+		{"half page down", tea.KeyMsg{Type: tea.KeyCtrlD}, 55},
+		{"half page up", tea.KeyMsg{Type: tea.KeyCtrlU}, 45},
+		{"go to top", runes("g"), 0},
+		{"go to top with home", tea.KeyMsg{Type: tea.KeyHome}, 0},
+		{"go to bottom", runes("G"), 90},
+		{"go to bottom with end", tea.KeyMsg{Type: tea.KeyEnd}, 90},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewViewport(ViewportOptions{Width: 20, Height: 10})
+			require.NoError(t, m.AppendContent(testContent(0, 100), true))
+			m.viewport.SetYOffset(50)
+
+			m, _ = m.Update(tt.key)
+
+			assert.Equal(t, tt.want, m.viewport.YOffset)
+		})
+	}
+}
+
+func TestViewport_AutoscrollPausedWhenScrolledAway(t *testing.T) {
+	m := NewViewport(ViewportOptions{Width: 20, Height: 10, Autoscroll: true})
+	require.NoError(t, m.AppendContent(testContent(0, 100), false))
+	require.Equal(t, 90, m.viewport.YOffset)
+
+	// Scroll up, away from the bottom, and expect new content to not move
+	// the viewport.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	require.NoError(t, m.AppendContent(testContent(100, 10), false))
+	assert.Equal(t, 89, m.viewport.YOffset)
+
+	// Return to the bottom and expect autoscroll to resume.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	require.NoError(t, m.AppendContent(testContent(110, 10), false))
+	assert.Equal(t, 110, m.viewport.YOffset)
+}
+
+func TestViewport_SetAutoscroll(t *testing.T) {
+	m := NewViewport(ViewportOptions{Width: 20, Height: 10})
+	require.NoError(t, m.AppendContent(testContent(0, 100), false))
+	require.Equal(t, 0, m.viewport.YOffset)
+
+	// Enabling autoscroll snaps to the bottom.
+	m.SetAutoscroll(true)
+	assert.Equal(t, 90, m.viewport.YOffset)
+
+	// Scroll away, then re-enable autoscroll, and expect to snap back to the
+	// bottom and follow new content.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
+	m.SetAutoscroll(false)
+	m.SetAutoscroll(true)
+	require.NoError(t, m.AppendContent(testContent(100, 10), false))
+	assert.Equal(t, 100, m.viewport.YOffset)
+}
+
+func TestViewport_AppendContentIncrementally(t *testing.T) {
+	// Content appended in chunks, including chunks that split lines and color
+	// sequences spanning lines, renders the same as content appended all at
+	// once.
+	content := "\x1b[31mred line one\nred line two\x1b[0m\nplain line that is long enough to wrap\n"
+
+	whole := NewViewport(ViewportOptions{Width: 20, Height: 10})
+	require.NoError(t, whole.AppendContent([]byte(content), false))
+
+	chunked := NewViewport(ViewportOptions{Width: 20, Height: 10})
+	for _, chunk := range []string{content[:10], content[10:30], content[30:]} {
+		require.NoError(t, chunked.AppendContent([]byte(chunk), false))
+	}
+	assert.Equal(t, whole.viewport.View(), chunked.viewport.View())
+}
@@ -0,0 +1,135 @@
+package workspace
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/state"
+	"github.com/leg100/pug/internal/tui"
+	"github.com/leg100/pug/internal/workspace"
+)
+
+var (
+	diffAddedStyle   = tui.Regular.Foreground(tui.Green)
+	diffRemovedStyle = tui.Regular.Foreground(tui.Red)
+	diffChangedStyle = tui.Regular.Foreground(tui.Yellow)
+)
+
+// DiffMaker makes models that show the differences between the states of two
+// workspaces.
+type DiffMaker struct {
+	States     *state.Service
+	Workspaces *workspace.Service
+	Helpers    *tui.Helpers
+}
+
+func (mm *DiffMaker) Make(id resource.ID, width, height int) (tea.Model, error) {
+	diff, err := mm.States.GetDiff(id)
+	if err != nil {
+		return nil, err
+	}
+	from, err := mm.Workspaces.Get(diff.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := mm.Workspaces.Get(diff.To)
+	if err != nil {
+		return nil, err
+	}
+
+	m := diffModel{
+		Helpers: mm.Helpers,
+		from:    from,
+		to:      to,
+	}
+	m.viewport = tui.NewViewport(tui.ViewportOptions{
+		Width:  m.viewportWidth(width),
+		Height: m.viewportHeight(height),
+	})
+	m.viewport.AppendContent([]byte(renderDiff(diff)), true)
+
+	return m, nil
+}
+
+type diffModel struct {
+	*tui.Helpers
+
+	viewport tui.Viewport
+	from     *workspace.Workspace
+	to       *workspace.Workspace
+}
+
+func (m diffModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m diffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.viewport.SetDimensions(m.viewportWidth(msg.Width), m.viewportHeight(msg.Height))
+		return m, nil
+	}
+
+	// Handle keyboard and mouse events in the viewport
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m diffModel) View() string {
+	return tui.Border.Render(m.viewport.View())
+}
+
+func (m diffModel) viewportWidth(width int) int {
+	// Subtract 2 to accommodate borders
+	return max(0, width-2)
+}
+
+func (m diffModel) viewportHeight(height int) int {
+	// Subtract 2 to accommodate borders
+	return max(0, height-2)
+}
+
+func (m diffModel) Title() tui.Breadcrumbs {
+	return m.Breadcrumbs("State Diff", nil,
+		tui.TitleWorkspace.Render(workspaceLabel(m.from)),
+		tui.TitleWorkspace.Render(workspaceLabel(m.to)),
+	)
+}
+
+// workspaceLabel labels a workspace with its module path, to distinguish
+// workspaces with the same name in different modules.
+func workspaceLabel(ws *workspace.Workspace) string {
+	return fmt.Sprintf("%s:%s", ws.ModulePath, ws.Name)
+}
+
+// renderDiff renders a line for each resource difference, prefixed with a
+// symbol and colored according to the type of difference.
+func renderDiff(diff *state.Diff) string {
+	if len(diff.Resources) == 0 {
+		return "No differences"
+	}
+	lines := make([]string, len(diff.Resources))
+	for i, res := range diff.Resources {
+		var (
+			symbol string
+			style  lipgloss.Style
+			line   = string(res.Address)
+		)
+		switch res.Type {
+		case state.DiffAdded:
+			symbol, style = "+", diffAddedStyle
+		case state.DiffRemoved:
+			symbol, style = "-", diffRemovedStyle
+		case state.DiffChanged:
+			symbol, style = "~", diffChangedStyle
+			line += fmt.Sprintf(" (%s)", strings.Join(res.Attributes, ", "))
+		}
+		lines[i] = style.Render(fmt.Sprintf("%s %s", symbol, line))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -1,12 +1,24 @@
 package workspace
 
 import (
+	"slices"
+
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/leg100/pug/internal/tui/keys"
 )
 
 type keyMap struct {
-	SetCurrent key.Binding
-	Enter      key.Binding
+	SetCurrent    key.Binding
+	Compare       key.Binding
+	ApplyPlanFile key.Binding
+	ApplySaved    key.Binding
+	AutoApply     key.Binding
+	PlanTargets   key.Binding
+	PlanVarFiles  key.Binding
+	PlanVars      key.Binding
+	PlanEnv       key.Binding
+	PreviewPlan   key.Binding
+	Enter         key.Binding
 }
 
 var localKeys = keyMap{
@@ -14,6 +26,42 @@ var localKeys = keyMap{
 		key.WithKeys("C"),
 		key.WithHelp("C", "set current"),
 	),
+	Compare: key.NewBinding(
+		key.WithKeys("="),
+		key.WithHelp("=", "compare state"),
+	),
+	ApplyPlanFile: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "apply plan file"),
+	),
+	ApplySaved: key.NewBinding(
+		key.WithKeys("alt+s"),
+		key.WithHelp("alt+s", "apply saved plan"),
+	),
+	AutoApply: key.NewBinding(
+		key.WithKeys("alt+a"),
+		key.WithHelp("alt+a", "toggle auto-apply"),
+	),
+	PlanTargets: key.NewBinding(
+		key.WithKeys("ctrl+p"),
+		key.WithHelp("ctrl+p", "plan with targets"),
+	),
+	PlanVarFiles: key.NewBinding(
+		key.WithKeys("V"),
+		key.WithHelp("V", "plan with var files"),
+	),
+	PlanVars: key.NewBinding(
+		key.WithKeys("ctrl+v"),
+		key.WithHelp("ctrl+v", "plan with vars"),
+	),
+	PlanEnv: key.NewBinding(
+		key.WithKeys("alt+e"),
+		key.WithHelp("alt+e", "plan with env"),
+	),
+	PreviewPlan: key.NewBinding(
+		key.WithKeys("alt+p"),
+		key.WithHelp("alt+p", "preview plan"),
+	),
 	Enter: key.NewBinding(
 		key.WithKeys("enter"),
 		key.WithHelp("enter", "state"),
@@ -24,7 +72,9 @@ type resourcesKeyMap struct {
 	Taint   key.Binding
 	Untaint key.Binding
 	Move    key.Binding
+	Replace key.Binding
 	Reload  key.Binding
+	Outputs key.Binding
 	Enter   key.Binding
 }
 
@@ -41,12 +91,48 @@ var resourcesKeys = resourcesKeyMap{
 		key.WithKeys("M"),
 		key.WithHelp("M", "move"),
 	),
+	Replace: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "plan replace"),
+	),
 	Reload: key.NewBinding(
 		key.WithKeys("ctrl+r"),
 		key.WithHelp("ctrl+r", "reload"),
 	),
+	Outputs: key.NewBinding(
+		key.WithKeys("ctrl+o"),
+		key.WithHelp("ctrl+o", "outputs"),
+	),
 	Enter: key.NewBinding(
 		key.WithKeys("enter"),
 		key.WithHelp("enter", "view resource"),
 	),
 }
+
+type outputsKeyMap struct {
+	Reveal key.Binding
+}
+
+var outputsKeys = outputsKeyMap{
+	Reveal: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "reveal sensitive"),
+	),
+}
+
+// mutatingKeys are disabled in read-only mode.
+var mutatingKeys = slices.Concat(keys.Mutating, []key.Binding{
+	localKeys.SetCurrent,
+	localKeys.ApplyPlanFile,
+	localKeys.ApplySaved,
+	localKeys.AutoApply,
+	localKeys.PlanTargets,
+	localKeys.PlanVarFiles,
+	localKeys.PlanVars,
+	localKeys.PlanEnv,
+	localKeys.PreviewPlan,
+	resourcesKeys.Taint,
+	resourcesKeys.Untaint,
+	resourcesKeys.Move,
+	resourcesKeys.Replace,
+})
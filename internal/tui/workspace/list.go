@@ -1,13 +1,16 @@
 package workspace
 
 import (
+	"errors"
 	"fmt"
+	"io"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/leg100/pug/internal/module"
 	"github.com/leg100/pug/internal/plan"
 	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/state"
 	"github.com/leg100/pug/internal/task"
 	"github.com/leg100/pug/internal/tui"
 	"github.com/leg100/pug/internal/tui/keys"
@@ -21,10 +24,48 @@ var currentColumn = table.Column{
 	Width: len("CURRENT"),
 }
 
+var autoApplyColumn = table.Column{
+	Key:   "auto_apply",
+	Title: "AUTO-APPLY",
+	Width: len("AUTO-APPLY"),
+}
+
+var lockedByColumn = table.Column{
+	Key:   "locked_by",
+	Title: "LOCKED BY",
+	Width: len("LOCKED BY"),
+}
+
+// lockedBy renders the plan or apply task locking the workspace, if any.
+func lockedBy(plans *plan.Service, ws *workspace.Workspace) string {
+	if t, ok := plans.LockedBy(ws.ID); ok {
+		return fmt.Sprintf("%s %s", t.Identifier, t.ID)
+	}
+	return ""
+}
+
+// autoApplyCheckmark renders a checkmark if auto-apply is enabled for the
+// workspace.
+func autoApplyCheckmark(ws *workspace.Workspace) string {
+	if ws.AutoApply {
+		return "✓"
+	}
+	return ""
+}
+
+// sortFunc sorts workspaces by their module path, then by their name, and
+// lastly by their ID so that they are always listed in the same order.
+var sortFunc = table.SortFuncs(
+	workspace.ByModulePath,
+	workspace.ByName,
+	workspace.ByID,
+)
+
 type ListMaker struct {
 	Modules    *module.Service
 	Workspaces *workspace.Service
 	Plans      *plan.Service
+	States     *state.Service
 	Helpers    *tui.Helpers
 }
 
@@ -33,6 +74,8 @@ func (m *ListMaker) Make(_ resource.ID, width, height int) (tea.Model, error) {
 		table.ModuleColumn,
 		table.WorkspaceColumn,
 		currentColumn,
+		autoApplyColumn,
+		lockedByColumn,
 		table.CostColumn,
 		table.ResourceCountColumn,
 	}
@@ -44,17 +87,28 @@ func (m *ListMaker) Make(_ resource.ID, width, height int) (tea.Model, error) {
 			table.ResourceCountColumn.Key: m.Helpers.WorkspaceResourceCount(ws),
 			table.CostColumn.Key:          m.Helpers.WorkspaceCost(ws),
 			currentColumn.Key:             m.Helpers.WorkspaceCurrentCheckmark(ws),
+			autoApplyColumn.Key:           autoApplyCheckmark(ws),
+			lockedByColumn.Key:            lockedBy(m.Plans, ws),
 		}
 	}
 
 	table := table.New(columns, renderer, width, height,
-		table.WithSortFunc(workspace.Sort(m.Modules)),
+		table.WithSortFunc(sortFunc),
+		table.WithColumnOrder[*workspace.Workspace](m.Helpers.ColumnOrder...),
+		table.WithPinnedColumn[*workspace.Workspace](m.Helpers.PinFirstColumn),
+		table.WithHeaderRule[*workspace.Workspace](m.Helpers.HeaderRule),
+		table.WithPagination[*workspace.Workspace](m.Helpers.Paginated("workspaces")),
+		table.WithDefaultFilter[*workspace.Workspace](m.Helpers.DefaultFilter("workspaces")),
+		table.WithLoading[*workspace.Workspace](m.Helpers.Spinner),
+		table.WithCopyFunc(func(ws *workspace.Workspace) string { return ws.ModulePath }),
+		table.WithFollowNew[*workspace.Workspace](m.Helpers.FollowNew),
 	)
 
 	return list{
 		Workspaces: m.Workspaces,
 		Modules:    m.Modules,
 		Plans:      m.Plans,
+		States:     m.States,
 		table:      table,
 		Helpers:    m.Helpers,
 	}, nil
@@ -66,6 +120,7 @@ type list struct {
 	Modules    *module.Service
 	Workspaces *workspace.Service
 	Plans      *plan.Service
+	States     *state.Service
 
 	table table.Model[*workspace.Workspace]
 }
@@ -107,16 +162,35 @@ func (m list) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.table.AddItems(ws)
 		}
 	case tea.KeyMsg:
+		if cmd := m.RefuseMutation(msg, mutatingKeys...); cmd != nil {
+			return m, cmd
+		}
 		switch {
 		case key.Matches(msg, keys.Common.Delete):
-			workspaceIDs := m.table.SelectedOrCurrentIDs()
-			if len(workspaceIDs) == 0 {
-				return m, nil
+			// Create specs here, de-selecting any workspaces that cannot be
+			// deleted, and force deleting those with resources.
+			var withResources int
+			specs, err := m.table.Prune(func(ws *workspace.Workspace) (task.Spec, error) {
+				force := m.hasResources(ws.ID)
+				if force {
+					withResources++
+				}
+				return m.Workspaces.Delete(ws.ID, force)
+			})
+			if err != nil {
+				return m, tui.ReportError(err)
 			}
-			return m, tui.YesNoPrompt(
-				fmt.Sprintf("Delete %d workspace(s)?", len(workspaceIDs)),
-				m.CreateTasks(m.Workspaces.Delete, workspaceIDs...),
-			)
+			deleteCmd := m.CreateTasksWithSpecs(specs...)
+			if withResources > 0 {
+				// Force deleting orphans resources, so ask separately before
+				// doing so.
+				deleteCmd = tui.YesNoPrompt(
+					fmt.Sprintf("%d workspace(s) still have resources, which will no longer be tracked. Force delete?", withResources),
+					deleteCmd,
+				)
+			}
+			prompt := fmt.Sprintf("Delete %d workspace(s)?", len(specs))
+			return m, tui.YesNoPrompt(prompt, deleteCmd)
 		case key.Matches(msg, keys.Common.InitUpgrade):
 			upgrade = true
 			fallthrough
@@ -134,6 +208,9 @@ func (m list) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		case key.Matches(msg, localKeys.SetCurrent):
 			if row, ok := m.table.CurrentRow(); ok {
+				if m.WorkspaceCurrentCheckmark(row.Value) != "" {
+					return m, tui.ReportInfo("%s is already the current workspace", row.Value)
+				}
 				return m, func() tea.Msg {
 					if err := m.Workspaces.SelectWorkspace(row.Value.ModuleID, row.Value.ID); err != nil {
 						return tui.ReportError(fmt.Errorf("setting current workspace: %w", err))()
@@ -145,28 +222,85 @@ func (m list) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			createRunOptions.Destroy = true
 			fallthrough
 		case key.Matches(msg, keys.Common.Plan):
-			workspaceIDs := m.table.SelectedOrCurrentIDs()
-			fn := func(workspaceID resource.ID) (task.Spec, error) {
-				return m.Plans.Plan(workspaceID, createRunOptions)
+			fn := m.Plans.Batch(plan.PlanOnly, createRunOptions)
+			return m, m.CreateTasks(fn, m.table.SelectedOrCurrentIDs()...)
+		case key.Matches(msg, localKeys.PlanTargets):
+			return m, m.TargetedPlan(m.table.SelectedOrCurrentIDs()...)
+		case key.Matches(msg, localKeys.PlanVarFiles):
+			return m, m.VarFilesPlan(m.table.SelectedOrCurrentIDs()...)
+		case key.Matches(msg, localKeys.PlanVars):
+			return m, m.VarsPlan(m.table.SelectedOrCurrentIDs()...)
+		case key.Matches(msg, localKeys.PlanEnv):
+			return m, m.EnvPlan(m.table.SelectedOrCurrentIDs()...)
+		case key.Matches(msg, localKeys.PreviewPlan):
+			// Only the current workspace is previewed, because a preview is
+			// discarded upon navigating away from it.
+			if row, ok := m.table.CurrentRow(); ok {
+				fn := func(workspaceID resource.ID) (task.Spec, error) {
+					return m.Plans.Plan(workspaceID, plan.CreateOptions{
+						Ephemeral: true,
+					})
+				}
+				return m, m.CreateTasks(fn, row.ID)
 			}
-			return m, m.CreateTasks(fn, workspaceIDs...)
 		case key.Matches(msg, keys.Common.Destroy):
 			createRunOptions.Destroy = true
 			applyPrompt = "Destroy resources of %d workspaces?"
 			fallthrough
 		case key.Matches(msg, keys.Common.Apply):
 			workspaceIDs := m.table.SelectedOrCurrentIDs()
-			fn := func(workspaceID resource.ID) (task.Spec, error) {
-				return m.Plans.Apply(workspaceID, createRunOptions)
-			}
-			return m, tui.YesNoPrompt(
+			fn := m.Plans.Batch(plan.PlanAndApply, createRunOptions)
+			return m, m.ConfirmApply(
 				fmt.Sprintf(applyPrompt, len(workspaceIDs)),
+				createRunOptions.Destroy,
 				m.CreateTasks(fn, workspaceIDs...),
 			)
+		case key.Matches(msg, localKeys.Compare):
+			rows := m.table.SelectedOrCurrent()
+			if len(rows) != 2 {
+				return m, tui.ReportError(errors.New("select two workspaces to compare"))
+			}
+			// Compare workspaces in the order in which they're listed.
+			if sortFunc(rows[0].Value, rows[1].Value) > 0 {
+				rows[0], rows[1] = rows[1], rows[0]
+			}
+			diff, err := m.States.Diff(rows[0].ID, rows[1].ID)
+			if err != nil {
+				return m, tui.ReportError(fmt.Errorf("comparing workspaces: %w", err))
+			}
+			return m, tui.NavigateTo(tui.StateDiffKind, tui.WithParent(diff.ID))
+		case key.Matches(msg, localKeys.AutoApply):
+			if row, ok := m.table.CurrentRow(); ok {
+				enabled, err := m.Workspaces.ToggleAutoApply(row.ID)
+				if err != nil {
+					return m, tui.ReportError(fmt.Errorf("toggling auto-apply: %w", err))
+				}
+				if enabled {
+					return m, tui.ReportInfo("Enabled auto-apply for %s", row.Value)
+				}
+				return m, tui.ReportInfo("Disabled auto-apply for %s", row.Value)
+			}
+		case key.Matches(msg, localKeys.ApplyPlanFile):
+			if row, ok := m.table.CurrentRow(); ok {
+				return m, m.applyPlanFile(row.ID)
+			}
+		case key.Matches(msg, localKeys.ApplySaved):
+			if row, ok := m.table.CurrentRow(); ok {
+				return m, m.applySavedPlan(row.ID)
+			}
 		case key.Matches(msg, keys.Common.State, localKeys.Enter):
 			if row, ok := m.table.CurrentRow(); ok {
 				return m, tui.NavigateTo(tui.ResourceListKind, tui.WithParent(row.ID))
 			}
+		case key.Matches(msg, keys.Common.Edit):
+			if row, ok := m.table.CurrentRow(); ok {
+				path := m.Workdir.Join(row.Value.ModulePath)
+				return m, tui.OpenEditor(path)
+			}
+		case key.Matches(msg, keys.Common.Shell):
+			if row, ok := m.table.CurrentRow(); ok {
+				return m, tui.OpenShell(m.Workdir.Join(row.Value.ModulePath))
+			}
 		case key.Matches(msg, keys.Common.Cost):
 			workspaceIDs := m.table.SelectedOrCurrentIDs()
 			spec, err := m.Workspaces.Cost(workspaceIDs...)
@@ -184,29 +318,116 @@ func (m list) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
-func (m list) Title() string {
+// hasResources determines whether the workspace has any resources in its
+// state.
+func (m list) hasResources(workspaceID resource.ID) bool {
+	s, err := m.States.Get(workspaceID)
+	if err != nil {
+		return false
+	}
+	return len(s.Resources) > 0
+}
+
+func (m list) Title() tui.Breadcrumbs {
 	return m.Breadcrumbs("Workspaces", nil)
 }
 
+// ExportCSV writes the table in CSV format.
+func (m list) ExportCSV(w io.Writer) error {
+	return m.table.ExportCSV(w)
+}
+
+// FilterValue returns the value of the table's filter.
+func (m list) FilterValue() string {
+	return m.table.FilterValue()
+}
+
+// Loading returns true if the table is yet to load its items.
+func (m list) Loading() bool {
+	return m.table.Loading()
+}
+
+// Pagination describes the page of the table currently visible.
+func (m list) Pagination() string {
+	return m.table.Pagination()
+}
+
 func (m list) View() string {
 	return m.table.View()
 }
 
 func (m list) HelpBindings() []key.Binding {
-	return []key.Binding{
+	bindings := []key.Binding{
 		keys.Common.Init,
 		keys.Common.InitUpgrade,
 		keys.Common.Format,
 		keys.Common.Validate,
 		keys.Common.Plan,
 		keys.Common.PlanDestroy,
+		localKeys.PlanTargets,
+		localKeys.PlanVarFiles,
+		localKeys.PlanVars,
+		localKeys.PlanEnv,
+		localKeys.PreviewPlan,
 		keys.Common.Apply,
 		keys.Common.Destroy,
 		keys.Common.Delete,
 		keys.Common.Cost,
+		keys.Common.Edit,
+		keys.Common.Shell,
 		localKeys.SetCurrent,
+		localKeys.Compare,
+		localKeys.ApplyPlanFile,
+		localKeys.ApplySaved,
+		localKeys.AutoApply,
 		keys.Common.State,
 	}
+	return m.HideMutations(bindings, mutatingKeys...)
+}
+
+// applyPlanFile prompts the user for the path to a plan file created outside
+// of pug, relative to the module directory, and then, once confirmed, applies
+// it to the workspace.
+func (m list) applyPlanFile(workspaceID resource.ID) tea.Cmd {
+	return tui.CmdHandler(tui.PromptMsg{
+		Prompt:      "Enter path to plan file: ",
+		Placeholder: "plan.out",
+		Action: func(v string) tea.Cmd {
+			if v == "" {
+				return nil
+			}
+			fn := func(workspaceID resource.ID) (task.Spec, error) {
+				return m.Plans.ImportPlan(workspaceID, v)
+			}
+			// Always confirm, even if confirmation is otherwise skipped,
+			// because pug cannot show what the plan file will change.
+			return tui.YesNoPrompt(
+				fmt.Sprintf("Apply plan file %s?", v),
+				m.CreateTasks(fn, workspaceID),
+			)
+		},
+		Key:    key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "confirm")),
+		Cancel: key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+	})
+}
+
+// applySavedPlan applies the plan saved for the workspace when pug last exited,
+// warning the user if the workspace's state has since changed.
+func (m list) applySavedPlan(workspaceID resource.ID) tea.Cmd {
+	saved, err := m.Plans.SavedPlan(workspaceID)
+	if err != nil {
+		return tui.ReportError(fmt.Errorf("applying saved plan: %w", err))
+	}
+	prompt := "Apply saved plan?"
+	if saved.StateChanged {
+		prompt = "State has changed since the plan was saved. Apply saved plan anyway?"
+	}
+	// Always confirm the warning, even if confirmation is otherwise skipped.
+	return m.ConfirmApply(
+		tui.ApplyPrompt(prompt, saved.Variables),
+		saved.Destroy || saved.StateChanged,
+		m.CreateTasks(m.Plans.ApplySavedPlan, workspaceID),
+	)
 }
 
 // selectedOrCurrentModuleIDs returns the IDs of the modules of the
@@ -0,0 +1,41 @@
+package workspace
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/tui"
+	"github.com/leg100/pug/internal/tui/table"
+	"github.com/leg100/pug/internal/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestList_ReadOnly(t *testing.T) {
+	renderer := func(ws *workspace.Workspace) table.RenderedRow {
+		return table.RenderedRow{table.WorkspaceColumn.Key: ws.Name}
+	}
+	tbl := table.New([]table.Column{table.WorkspaceColumn}, renderer, 100, 10)
+	tbl.SetItems(&workspace.Workspace{ID: resource.NewID(resource.Workspace), Name: "dev"})
+
+	// The list is constructed without any services: were a mutating key not
+	// refused then the test would panic.
+	m := list{
+		Helpers: &tui.Helpers{ReadOnly: true},
+		table:   tbl,
+	}
+
+	for _, k := range []string{"p", "P", "a", "d", "D", "i", "u", "f", "C", "A"} {
+		t.Run(k, func(t *testing.T) {
+			_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+			require.NotNil(t, cmd)
+			assert.Equal(t, tui.ErrorMsg(tui.ErrReadOnly), cmd())
+		})
+	}
+
+	// Mutating keys are hidden from the help.
+	for _, binding := range m.HelpBindings() {
+		assert.NotContains(t, []string{"p", "P", "a", "d", "D", "i", "u", "f", "C", "A"}, binding.Help().Key)
+	}
+}
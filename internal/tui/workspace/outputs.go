@@ -0,0 +1,146 @@
+package workspace
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/leg100/pug/internal/state"
+	"github.com/leg100/pug/internal/task"
+	"github.com/leg100/pug/internal/tui"
+	"github.com/leg100/pug/internal/tui/table"
+)
+
+var (
+	outputNameColumn = table.Column{
+		Key:        "name",
+		Title:      "NAME",
+		FlexFactor: 1,
+	}
+	outputValueColumn = table.Column{
+		Key:        "value",
+		Title:      "VALUE",
+		FlexFactor: 3,
+	}
+)
+
+// OutputsMaker makes models that list the outputs retrieved by an output task.
+type OutputsMaker struct {
+	Tasks   *task.Service
+	Helpers *tui.Helpers
+}
+
+func (mm *OutputsMaker) Make(id resource.ID, width, height int) (tea.Model, error) {
+	t, err := mm.Tasks.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// reveal is shared with the renderer so that toggling it takes effect
+	// the next time the rows are rendered.
+	reveal := new(bool)
+	renderer := func(out state.Output) table.RenderedRow {
+		value := out.Value
+		if out.Sensitive && !*reveal {
+			value = tui.Regular.Foreground(tui.LighterGrey).Render("(sensitive)")
+		}
+		return table.RenderedRow{
+			outputNameColumn.Key:  out.Name,
+			outputValueColumn.Key: value,
+		}
+	}
+	m := outputsModel{
+		Helpers:   mm.Helpers,
+		task:      t,
+		lastState: t.State,
+		reveal:    reveal,
+		table: table.New(
+			[]table.Column{outputNameColumn, outputValueColumn},
+			renderer,
+			width,
+			height,
+			table.WithCopyFunc(func(out state.Output) string { return out.Value }),
+		),
+	}
+	m.populate()
+	return m, nil
+}
+
+type outputsModel struct {
+	*tui.Helpers
+
+	task      *task.Task
+	lastState task.Status
+	table     table.Model[state.Output]
+	reveal    *bool
+}
+
+func (m outputsModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m outputsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case resource.Event[*task.Task]:
+		if msg.Payload.ID != m.task.ID {
+			// Ignore event for different task.
+			return m, nil
+		}
+		// Populate outputs once the output task finishes. The payload shares its
+		// pointer with m.task, so compare with the state seen previously.
+		finished := !m.lastState.IsFinal() && msg.Payload.State.IsFinal()
+		m.task = msg.Payload
+		m.lastState = msg.Payload.State
+		if finished {
+			m.populate()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, outputsKeys.Reveal):
+			*m.reveal = !*m.reveal
+			m.populate()
+			return m, nil
+		}
+	}
+	// Handle keyboard and mouse events in the table widget
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+func (m outputsModel) View() string {
+	var msg string
+	switch m.task.State {
+	case task.Exited:
+		if len(m.outputs()) > 0 {
+			return m.table.View()
+		}
+		msg = "No outputs."
+	case task.Errored, task.Canceled, task.Discarded:
+		msg = fmt.Sprintf("Retrieving outputs %s: see task output for details.", m.task.State)
+	default:
+		msg = "Retrieving outputs..."
+	}
+	return tui.Padded.Render(msg)
+}
+
+func (m outputsModel) Title() tui.Breadcrumbs {
+	return m.Breadcrumbs("Outputs", m.task)
+}
+
+func (m outputsModel) HelpBindings() []key.Binding {
+	return []key.Binding{outputsKeys.Reveal}
+}
+
+// populate populates the table with the outputs retrieved by the task.
+func (m *outputsModel) populate() {
+	m.table.SetItems(m.outputs()...)
+}
+
+func (m outputsModel) outputs() state.Outputs {
+	outputs, _ := m.task.Summary.(state.Outputs)
+	return outputs
+}
@@ -73,6 +73,9 @@ func (m resourceModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if cmd := m.RefuseMutation(msg, mutatingKeys...); cmd != nil {
+			return m, cmd
+		}
 		switch {
 		case key.Matches(msg, resourcesKeys.Taint):
 			fn := func(workspaceID resource.ID) (task.Spec, error) {
@@ -88,6 +91,10 @@ func (m resourceModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, m.Move(m.resource.WorkspaceID, m.resource.Address)
 		case key.Matches(msg, keys.Common.Delete):
 			fn := func(workspaceID resource.ID) (task.Spec, error) {
+				// Refuse to alter state whilst a plan or apply is in progress.
+				if err := m.plans.CheckLock(workspaceID); err != nil {
+					return task.Spec{}, err
+				}
 				return m.states.Delete(workspaceID, m.resource.Address)
 			}
 			return m, tui.YesNoPrompt(
@@ -139,16 +146,16 @@ func (m resourceModel) viewportHeight(height int) int {
 	return max(0, height)
 }
 
-func (m resourceModel) Title() string {
-	var tainted string
+func (m resourceModel) Title() tui.Breadcrumbs {
+	crumbs := m.Breadcrumbs("Resource", m.resource)
 	if m.resource.Tainted {
-		tainted = tui.TitleTainted.Render("tainted")
+		crumbs = append(crumbs, tui.Breadcrumb{Text: tui.TitleTainted.Render("tainted")})
 	}
-	return m.Breadcrumbs("Resource", m.resource) + tainted
+	return crumbs
 }
 
 func (m resourceModel) HelpBindings() []key.Binding {
-	return []key.Binding{
+	bindings := []key.Binding{
 		keys.Common.Plan,
 		keys.Common.PlanDestroy,
 		keys.Common.Delete,
@@ -156,4 +163,5 @@ func (m resourceModel) HelpBindings() []key.Binding {
 		resourcesKeys.Taint,
 		resourcesKeys.Untaint,
 	}
+	return m.HideMutations(bindings, mutatingKeys...)
 }
@@ -50,6 +50,12 @@ func (m *ResourceListMaker) Make(id resource.ID, width, height int) (tea.Model,
 	}
 	tableOptions := []table.Option[*state.Resource]{
 		table.WithSortFunc(state.Sort),
+		table.WithColumnOrder[*state.Resource](m.Helpers.ColumnOrder...),
+		table.WithPinnedColumn[*state.Resource](m.Helpers.PinFirstColumn),
+		table.WithHeaderRule[*state.Resource](m.Helpers.HeaderRule),
+		table.WithPagination[*state.Resource](m.Helpers.Paginated("resources")),
+		table.WithDefaultFilter[*state.Resource](m.Helpers.DefaultFilter("resources")),
+		table.WithCopyFunc(func(res *state.Resource) string { return string(res.Address) }),
 	}
 	splitModel := split.New(split.Options[*state.Resource]{
 		Columns:      columns,
@@ -64,8 +70,13 @@ func (m *ResourceListMaker) Make(id resource.ID, width, height int) (tea.Model,
 			disableBorders: true,
 		},
 	})
+	// Lazily retrieve state if it has not already been retrieved.
+	_, err = m.States.Get(id)
+	reloading := errors.Is(err, resource.ErrNotFound)
+
 	return resourceList{
 		Model:     splitModel,
+		reloading: reloading,
 		states:    m.States,
 		plans:     m.Plans,
 		workspace: ws,
@@ -94,6 +105,10 @@ type resourceList struct {
 type initState *state.State
 
 func (m resourceList) Init() tea.Cmd {
+	if m.reloading {
+		// State has yet to be retrieved.
+		return m.reload()
+	}
 	return func() tea.Msg {
 		state, err := m.states.Get(m.workspace.GetID())
 		if err != nil {
@@ -125,6 +140,9 @@ func (m resourceList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, tui.ReportInfo("reloading finished")
 	case tea.KeyMsg:
+		if cmd := m.RefuseMutation(msg, mutatingKeys...); cmd != nil {
+			return m, cmd
+		}
 		switch {
 		case key.Matches(msg, localKeys.Enter):
 			if row, ok := m.Table.CurrentRow(); ok {
@@ -135,19 +153,18 @@ func (m resourceList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, tui.ReportError(errors.New("reloading in progress"))
 			}
 			m.reloading = true
+			return m, m.reload()
+		case key.Matches(msg, resourcesKeys.Outputs):
 			return m, func() tea.Msg {
-				msg := reloadedMsg{workspaceID: m.workspace.GetID()}
-				if spec, err := m.states.Reload(msg.workspaceID); err != nil {
-					msg.err = err
-				} else {
-					task, err := m.Tasks.Create(spec)
-					if err != nil {
-						msg.err = err
-					} else if err := task.Wait(); err != nil {
-						msg.err = err
-					}
+				spec, err := m.states.Outputs(m.workspace.GetID())
+				if err != nil {
+					return tui.ErrorMsg(fmt.Errorf("retrieving outputs: %w", err))
+				}
+				task, err := m.Tasks.Create(spec)
+				if err != nil {
+					return tui.ErrorMsg(fmt.Errorf("retrieving outputs: %w", err))
 				}
-				return msg
+				return tui.NewNavigationMsg(tui.OutputsKind, tui.WithParent(task.ID))
 			}
 		case key.Matches(msg, keys.Common.Delete):
 			addrs := m.selectedOrCurrentAddresses()
@@ -156,6 +173,10 @@ func (m resourceList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			fn := func(workspaceID resource.ID) (task.Spec, error) {
+				// Refuse to alter state whilst a plan or apply is in progress.
+				if err := m.plans.CheckLock(workspaceID); err != nil {
+					return task.Spec{}, err
+				}
 				return m.states.Delete(workspaceID, addrs...)
 			}
 			return m, tui.YesNoPrompt(
@@ -173,6 +194,17 @@ func (m resourceList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				from := row.Value.Address
 				return m, m.Move(m.workspace.GetID(), from)
 			}
+		case key.Matches(msg, resourcesKeys.Replace):
+			// Create a plan replacing the selected resources.
+			createRunOptions.ReplaceAddrs = m.selectedOrCurrentAddresses()
+			if len(createRunOptions.ReplaceAddrs) == 0 {
+				// no rows; do nothing
+				return m, nil
+			}
+			fn := func(workspaceID resource.ID) (task.Spec, error) {
+				return m.plans.Plan(workspaceID, createRunOptions)
+			}
+			return m, m.CreateTasks(fn, m.workspace.GetID())
 		case key.Matches(msg, keys.Common.PlanDestroy):
 			// Create a targeted destroy plan.
 			createRunOptions.Destroy = true
@@ -197,8 +229,9 @@ func (m resourceList) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			fn := func(workspaceID resource.ID) (task.Spec, error) {
 				return m.plans.Apply(workspaceID, createRunOptions)
 			}
-			return m, tui.YesNoPrompt(
+			return m, m.ConfirmApply(
 				fmt.Sprintf(applyPrompt, len(resourceIDs)),
+				createRunOptions.Destroy,
 				m.CreateTasks(fn, m.workspace.GetID()),
 			)
 		}
@@ -260,7 +293,7 @@ func (m resourceList) View() string {
 	)
 }
 
-func (m resourceList) Title() string {
+func (m resourceList) Title() tui.Breadcrumbs {
 	var serial string
 	if m.state != nil {
 		serial = serialBreadcrumb(m.state.Serial)
@@ -276,13 +309,34 @@ func (m resourceList) HelpBindings() []key.Binding {
 		keys.Common.Destroy,
 		keys.Common.Delete,
 		resourcesKeys.Move,
+		resourcesKeys.Replace,
 		resourcesKeys.Taint,
 		resourcesKeys.Untaint,
 		resourcesKeys.Reload,
+		resourcesKeys.Outputs,
 	}
+	bindings = m.HideMutations(bindings, mutatingKeys...)
 	return append(bindings, keys.KeyMapToSlice(split.Keys)...)
 }
 
+// reload pulls the workspace's state, sending a reloadedMsg once finished.
+func (m resourceList) reload() tea.Cmd {
+	return func() tea.Msg {
+		msg := reloadedMsg{workspaceID: m.workspace.GetID()}
+		if spec, err := m.states.Reload(msg.workspaceID); err != nil {
+			msg.err = err
+		} else {
+			task, err := m.Tasks.Create(spec)
+			if err != nil {
+				msg.err = err
+			} else if err := task.Wait(); err != nil {
+				msg.err = err
+			}
+		}
+		return msg
+	}
+}
+
 func (m resourceList) selectedOrCurrentAddresses() []state.ResourceAddress {
 	rows := m.Table.SelectedOrCurrent()
 	addrs := make([]state.ResourceAddress, len(rows))
@@ -93,6 +93,7 @@ func (r *reloader) resetWorkspaces(mod *module.Module, discovered []string, curr
 			if err != nil {
 				return nil, nil, fmt.Errorf("adding workspace: %w", err)
 			}
+			add.AutoApply = r.autoApplyEnabled(mod.Path, name)
 			r.table.Add(add.ID, add)
 			added = append(added, name)
 		}
@@ -1,7 +1,10 @@
 package workspace
 
 import (
+	"errors"
 	"fmt"
+	"path"
+	"sync"
 
 	"github.com/leg100/pug/internal"
 	"github.com/leg100/pug/internal/logging"
@@ -20,6 +23,12 @@ type Service struct {
 	datadir string
 	workdir internal.Workdir
 
+	// autoApply records the workspaces with auto-apply enabled, keyed by
+	// module path and workspace name, so that the setting survives a
+	// workspace being removed and re-added by a reload.
+	autoApply   map[string]bool
+	autoApplyMu sync.Mutex
+
 	*pubsub.Broker[*Workspace]
 	*reloader
 	*costTaskSpecCreator
@@ -60,13 +69,14 @@ func NewService(opts ServiceOptions) *Service {
 	})
 
 	s := &Service{
-		Broker:  broker,
-		table:   table,
-		modules: opts.Modules,
-		tasks:   opts.Tasks,
-		logger:  opts.Logger,
-		datadir: opts.DataDir,
-		workdir: opts.Workdir,
+		Broker:    broker,
+		table:     table,
+		modules:   opts.Modules,
+		tasks:     opts.Tasks,
+		logger:    opts.Logger,
+		datadir:   opts.DataDir,
+		workdir:   opts.Workdir,
+		autoApply: make(map[string]bool),
 	}
 	s.reloader = &reloader{s}
 	s.costTaskSpecCreator = &costTaskSpecCreator{s}
@@ -173,7 +183,8 @@ func (s *Service) List(opts ListOptions) []*Workspace {
 
 // SelectWorkspace runs the `terraform workspace select <workspace_name>`
 // command, which sets the current workspace for the module. Once that's
-// finished it then updates the current workspace in pug itself too.
+// finished it then updates the current workspace in pug itself too. If the
+// workspace is already the current workspace then nothing is done.
 func (s *Service) SelectWorkspace(moduleID, workspaceID resource.ID) error {
 	ws, err := s.table.Get(workspaceID)
 	if err != nil {
@@ -183,6 +194,9 @@ func (s *Service) SelectWorkspace(moduleID, workspaceID resource.ID) error {
 	if err != nil {
 		return err
 	}
+	if mod.CurrentWorkspaceID != nil && *mod.CurrentWorkspaceID == workspaceID {
+		return nil
+	}
 	// Create task to immediately set workspace as current workspace for module.
 	_, err = s.tasks.Create(task.Spec{
 		ModuleID: &mod.ID,
@@ -206,8 +220,14 @@ func (s *Service) SelectWorkspace(moduleID, workspaceID resource.ID) error {
 	return nil
 }
 
-// Delete a workspace. Asynchronous.
-func (s *Service) Delete(workspaceID resource.ID) (task.Spec, error) {
+// ErrCurrentWorkspace is returned when attempting to delete a module's current
+// workspace.
+var ErrCurrentWorkspace = errors.New("cannot delete the current workspace")
+
+// Delete a workspace. Asynchronous. Set force to delete a workspace that still
+// has resources in its state. The module's current workspace cannot be
+// deleted.
+func (s *Service) Delete(workspaceID resource.ID, force bool) (task.Spec, error) {
 	ws, err := s.table.Get(workspaceID)
 	if err != nil {
 		return task.Spec{}, fmt.Errorf("deleting workspace: %w", err)
@@ -216,12 +236,19 @@ func (s *Service) Delete(workspaceID resource.ID) (task.Spec, error) {
 	if err != nil {
 		return task.Spec{}, err
 	}
+	if mod.CurrentWorkspaceID != nil && *mod.CurrentWorkspaceID == ws.ID {
+		return task.Spec{}, fmt.Errorf("%s: %w", ws, ErrCurrentWorkspace)
+	}
+	args := []string{ws.Name}
+	if force {
+		args = append([]string{"-force"}, args...)
+	}
 	return task.Spec{
 		ModuleID: &mod.ID,
 		Path:     mod.Path,
 		Execution: task.Execution{
 			TerraformCommand: []string{"workspace", "delete"},
-			Args:             []string{ws.Name},
+			Args:             args,
 		},
 		Blocking: true,
 		AfterExited: func(*task.Task) {
@@ -229,3 +256,30 @@ func (s *Service) Delete(workspaceID resource.ID) (task.Spec, error) {
 		},
 	}, nil
 }
+
+// ToggleAutoApply toggles whether plans for the workspace are automatically
+// applied, returning the new setting. Plans already created keep the setting
+// they were created with.
+func (s *Service) ToggleAutoApply(workspaceID resource.ID) (bool, error) {
+	s.autoApplyMu.Lock()
+	defer s.autoApplyMu.Unlock()
+
+	ws, err := s.table.Update(workspaceID, func(existing *Workspace) error {
+		existing.AutoApply = !existing.AutoApply
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	s.autoApply[path.Join(ws.ModulePath, ws.Name)] = ws.AutoApply
+	return ws.AutoApply, nil
+}
+
+// autoApplyEnabled returns whether auto-apply has been enabled for the
+// workspace with the given name belonging to the module with the given path.
+func (s *Service) autoApplyEnabled(modulePath, name string) bool {
+	s.autoApplyMu.Lock()
+	defer s.autoApplyMu.Unlock()
+
+	return s.autoApply[path.Join(modulePath, name)]
+}
@@ -0,0 +1,114 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/leg100/pug/internal/logging"
+	"github.com/leg100/pug/internal/module"
+	"github.com/leg100/pug/internal/pubsub"
+	"github.com/leg100/pug/internal/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Delete(t *testing.T) {
+	mod := module.New(module.Options{Path: "a/b/c"})
+	dev, err := New(mod, "dev")
+	require.NoError(t, err)
+	staging, err := New(mod, "staging")
+	require.NoError(t, err)
+	mod.CurrentWorkspaceID = &dev.ID
+
+	table := resource.NewTable(pubsub.NewBroker[*Workspace](logging.Discard))
+	table.Add(dev.ID, dev)
+	table.Add(staging.ID, staging)
+	svc := &Service{
+		table:   table,
+		modules: &fakeModuleGetter{mod: mod},
+	}
+
+	t.Run("delete", func(t *testing.T) {
+		spec, err := svc.Delete(staging.ID, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"staging"}, spec.Execution.Args)
+	})
+
+	t.Run("force delete", func(t *testing.T) {
+		spec, err := svc.Delete(staging.ID, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"-force", "staging"}, spec.Execution.Args)
+	})
+
+	t.Run("refuse to delete current workspace", func(t *testing.T) {
+		_, err := svc.Delete(dev.ID, true)
+		assert.ErrorIs(t, err, ErrCurrentWorkspace)
+	})
+}
+
+func TestService_SelectWorkspace_AlreadyCurrent(t *testing.T) {
+	mod := module.New(module.Options{Path: "a/b/c"})
+	dev, err := New(mod, "dev")
+	require.NoError(t, err)
+	mod.CurrentWorkspaceID = &dev.ID
+
+	table := resource.NewTable(pubsub.NewBroker[*Workspace](logging.Discard))
+	table.Add(dev.ID, dev)
+	// No task service is configured, so selecting the workspace would panic
+	// if a task were created.
+	svc := &Service{
+		table:   table,
+		modules: &fakeModuleGetter{mod: mod},
+	}
+
+	assert.NoError(t, svc.SelectWorkspace(mod.ID, dev.ID))
+}
+
+type fakeModuleGetter struct {
+	mod *module.Module
+
+	modules
+}
+
+func (f *fakeModuleGetter) Get(resource.ID) (*module.Module, error) {
+	return f.mod, nil
+}
+
+func TestService_ToggleAutoApply(t *testing.T) {
+	mod := module.New(module.Options{Path: "a/b/c"})
+	dev, err := New(mod, "dev")
+	require.NoError(t, err)
+
+	table := resource.NewTable(pubsub.NewBroker[*Workspace](logging.Discard))
+	table.Add(dev.ID, dev)
+	svc := &Service{
+		table:     table,
+		autoApply: make(map[string]bool),
+	}
+
+	enabled, err := svc.ToggleAutoApply(dev.ID)
+	require.NoError(t, err)
+	assert.True(t, enabled)
+	assert.True(t, dev.AutoApply)
+
+	// The setting is remembered should the workspace be re-added by a
+	// reload.
+	var gotCurrent resource.ID
+	fake := &fakeWorkspaceTable{}
+	svc.table = fake
+	svc.modules = &fakeModuleService{current: &gotCurrent}
+	reloader := &reloader{svc}
+	// The current workspace is looked up amongst the existing workspaces.
+	fake.existing = []*Workspace{{Name: "default", ModuleID: mod.ID, ModulePath: mod.Path}}
+	_, _, err = reloader.resetWorkspaces(mod, []string{"default", "dev"}, "default")
+	require.NoError(t, err)
+	if assert.Len(t, fake.added, 1) {
+		assert.True(t, fake.added[0].AutoApply)
+	}
+
+	// Toggling again disables auto-apply.
+	svc.table = table
+	enabled, err = svc.ToggleAutoApply(dev.ID)
+	require.NoError(t, err)
+	assert.False(t, enabled)
+	assert.False(t, svc.autoApplyEnabled(mod.Path, "dev"))
+}
@@ -1,39 +1,21 @@
 package workspace
 
 import (
-	"github.com/leg100/pug/internal/module"
-	"github.com/leg100/pug/internal/resource"
+	"strings"
 )
 
-type moduleGetter interface {
-	Get(id resource.ID) (*module.Module, error)
+// ByModulePath sorts workspaces by their module path, lexicographically.
+func ByModulePath(i, j *Workspace) int {
+	return strings.Compare(i.ModulePath, j.ModulePath)
 }
 
-// Sort sorts workspaces accordingly:
-//
-// 1. first by their module path, lexicographically.
-// 2. then, if module paths are equal, then by their workspace name, lexicographically
-func Sort(getter moduleGetter) func(*Workspace, *Workspace) int {
-	return func(i, j *Workspace) int {
-		if i.ModuleID == j.ModuleID {
-			// same module, compare workspace name
-			switch {
-			case i.Name < j.Name:
-				return -1
-			case i.Name > j.Name:
-				return 1
-			default:
-				// same workspace (unlikely)
-				return 0
-			}
-		}
-		imod, _ := getter.Get(i.ModuleID)
-		jmod, _ := getter.Get(j.ModuleID)
+// ByName sorts workspaces by their name, lexicographically.
+func ByName(i, j *Workspace) int {
+	return strings.Compare(i.Name, j.Name)
+}
 
-		if imod.Path < jmod.Path {
-			return -1
-		} else {
-			return 1
-		}
-	}
+// ByID sorts workspaces by their ID, which serves to break any remaining ties
+// between workspaces, ensuring they are always sorted in the same order.
+func ByID(i, j *Workspace) int {
+	return strings.Compare(i.ID.String(), j.ID.String())
 }
@@ -0,0 +1,35 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/leg100/pug/internal/module"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSort(t *testing.T) {
+	modA := module.New(module.Options{Path: "a"})
+	modB := module.New(module.Options{Path: "b"})
+
+	newWorkspace := func(mod *module.Module, name string) *Workspace {
+		ws, err := New(mod, name)
+		require.NoError(t, err)
+		return ws
+	}
+	aDev := newWorkspace(modA, "dev")
+	aProd := newWorkspace(modA, "prod")
+	bDev := newWorkspace(modB, "dev")
+
+	assert.Negative(t, ByModulePath(aProd, bDev))
+	assert.Zero(t, ByModulePath(aDev, aProd))
+	assert.Negative(t, ByName(aDev, aProd))
+	assert.Zero(t, ByName(aDev, bDev))
+
+	// Workspaces with the same module path and name are only distinguished
+	// by their ID.
+	aDevDup := newWorkspace(modA, "dev")
+	assert.NotZero(t, ByID(aDev, aDevDup))
+	assert.Equal(t, -ByID(aDev, aDevDup), ByID(aDevDup, aDev))
+	assert.Zero(t, ByID(aDev, aDev))
+}
@@ -19,6 +19,9 @@ type Workspace struct {
 	ModuleID   resource.ID
 	ModulePath string
 	Cost       float64
+	// AutoApply applies plans for the workspace as soon as they finish,
+	// provided they have changes and are not destroy plans.
+	AutoApply bool
 }
 
 func New(mod *module.Module, name string) (*Workspace, error) {
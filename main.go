@@ -1,10 +1,12 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/leg100/pug/internal/app"
+	"github.com/leg100/pug/internal/headless"
 	"github.com/leg100/pug/internal/tui/top"
 	"github.com/leg100/pug/internal/version"
 )
@@ -12,6 +14,9 @@ import (
 func main() {
 	if err := run(); err != nil {
 		fmt.Println(err.Error())
+		if errors.As(err, &app.TasksFailedError{}) {
+			os.Exit(app.ExitCodeTasksFailed)
+		}
 		os.Exit(1)
 	}
 }
@@ -26,6 +31,10 @@ func run() error {
 		fmt.Fprintln(os.Stdout, "pug", version.Version)
 		return nil
 	}
+	if cfg.Headless != "" {
+		// Run action without the TUI and block til it finishes.
+		return headless.Start(cfg, os.Stdout)
+	}
 	// Start TUI and block til user exits.
 	return top.Start(cfg)
 }